@@ -3,6 +3,7 @@ package cli
 import (
 	"time"
 
+	"github.com/dfuse-io/dfuse-eosio/filtering"
 	eosSearch "github.com/dfuse-io/dfuse-eosio/search"
 	"github.com/dfuse-io/dlauncher/launcher"
 	"github.com/spf13/cobra"
@@ -18,7 +19,7 @@ func init() {
 
 		// Network config
 		cmd.Flags().String("common-network-id", NetworkID, "Short network identifier, for billing purposes (usually maps namespaces on deployments). Used by: dgraphql")
-		cmd.Flags().String("common-chain-id", "", "Chain ID in hex. Used by: trxdb-loader (to reverse the signatures and extract public keys)") // TODO: eventually, pluck that from somewhere instead of asking for it here (!). You risk noticing its missing very late, and it'll require reprocessing if you want the pubkeys.
+		cmd.Flags().String("common-chain-id", "", "Chain ID in hex. Used by: trxdb-loader (to reverse the signatures and extract public keys), filtering (to make trx.signers available to filter programs)") // TODO: eventually, pluck that from somewhere instead of asking for it here (!). You risk noticing its missing very late, and it'll require reprocessing if you want the pubkeys.
 
 		// Authentication, metering and rate limiter plugins
 		cmd.Flags().String("common-auth-plugin", "null://", "Auth plugin URI, see dfuse-io/dauth repository")
@@ -26,7 +27,7 @@ func init() {
 		cmd.Flags().String("common-ratelimiter-plugin", "null://", "Rate Limiter plugin URI, see dfuse-io/dauth repository")
 
 		// Database connection strings
-		cmd.Flags().String("common-trxdb-dsn", TrxdbDSN, "kvdb connection string to trxdb database. Used by: trxdb-loader, abicodec, eosws, dgraphql")
+		cmd.Flags().String("common-trxdb-dsn", TrxdbDSN, "kvdb connection string to trxdb database, or postgres:// connection string to use the SQL driver (sqlite3:// too when built with the sqlite3 build tag). Used by: trxdb-loader, abicodec, eosws, dgraphql")
 
 		// Service addresses
 		cmd.Flags().String("common-search-addr", RouterServingAddr, "gRPC endpoint to reach the Search Router. Used by: abicodec, eosws, dgraphql")
@@ -35,6 +36,12 @@ func init() {
 		// Filtering
 		cmd.Flags().String("common-include-filter-expr", "*", "[COMMON] CEL program to determine if a given action should be included for processing purposes. See https://github.com/dfuse-io/dfuse-eosio/blob/develop/FILTERING.md.")
 		cmd.Flags().String("common-exclude-filter-expr", "", "[COMMON] CEL program to determine if an included action should be excluded. See https://github.com/dfuse-io/dfuse-eosio/blob/develop/FILTERING.md.")
+		cmd.Flags().StringSlice("common-filter-sets", nil, "[COMMON] Named sets of values usable in filter programs through the `in_set(<value>, '<name>')` function, each in the form <name>=<path>, the file containing one value per line. See https://github.com/dfuse-io/dfuse-eosio/blob/develop/FILTERING.md.")
+		cmd.Flags().String("common-filter-schedule-file", "", "[COMMON] YAML file defining the include and exclude CEL programs to use by block ranges, takes precedence over --common-include-filter-expr and --common-exclude-filter-expr when set. See https://github.com/dfuse-io/dfuse-eosio/blob/develop/FILTERING.md.")
+		cmd.Flags().Bool("common-filter-strict", false, "[COMMON] When enabled, the db, RAM, table and permission operations of a kept transaction performed by actions that did not match the filter are removed. See https://github.com/dfuse-io/dfuse-eosio/blob/develop/FILTERING.md.")
+		cmd.Flags().StringSlice("common-filter-always-include", filtering.DefaultAlwaysIncludedActions, "[COMMON] System actions, each in the form <account>:<action>, that are always included regardless of the filter programs, set to an empty value to disable. See https://github.com/dfuse-io/dfuse-eosio/blob/develop/FILTERING.md.")
+		cmd.Flags().Bool("common-filter-validate", false, "[COMMON] When enabled, filter programs are statically validated at startup against the ABIs of the abicodec cache (--abicodec-cache-base-url), each probable mistake found being logged as a warning. See https://github.com/dfuse-io/dfuse-eosio/blob/develop/FILTERING.md.")
+		cmd.Flags().String("common-filter-validate-fluxdb-addr", "", "[COMMON] HTTP address of a fluxdb instance, like http://localhost:13029, used by --common-filter-validate to fetch the ABIs missing from the abicodec cache")
 
 		// Search flags
 		cmd.Flags().String("search-common-mesh-store-addr", "", "[COMMON] Address of the backing etcd cluster for mesh service discovery.")
@@ -44,6 +51,8 @@ func init() {
 		cmd.Flags().String("search-common-dfuse-events-action-name", "", "[COMMON] The dfuse Events action name to intercept")
 		cmd.Flags().Bool("search-common-dfuse-events-unrestricted", false, "[COMMON] Flag to disable all restrictions of dfuse Events specialize indexing, for example for a private deployment")
 		cmd.Flags().String("search-common-indices-store-url", IndicesStoreURL, "[COMMON] Indices path to read or write index shards Used by: search-indexer, search-archiver.")
+		cmd.Flags().String("search-common-include-filter-expr", "", "[COMMON] CEL program to determine if a given action should be indexed by search-indexer, search-live and search-forkresolver, defaults to --common-include-filter-expr (or the --common-filter-schedule-file entries) when empty. Applied on top of the common filter.")
+		cmd.Flags().String("search-common-exclude-filter-expr", "", "[COMMON] CEL program to determine if an included action should not be indexed by search-indexer, search-live and search-forkresolver, defaults to --common-exclude-filter-expr (or the --common-filter-schedule-file entries) when empty. Applied on top of the common filter.")
 		cmd.Flags().String("search-common-indexed-terms", eosSearch.DefaultIndexedTerms, "[COMMON] Comma separated list of terms available for indexing. These include: receiver, account, action, auth, scheduled, status, notif, input, event, ram.consumed, ram.released, db.table, db.key, data.[freeform]. Ex: 'data.from', 'data.to', they are those fields dynamically specified by smart contracts as part of their action invocations.")
 
 		return nil
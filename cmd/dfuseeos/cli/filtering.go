@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/dfuse-io/bstream"
+	"github.com/dfuse-io/dfuse-eosio/abicodec"
+	"github.com/dfuse-io/dfuse-eosio/filtering"
+	"github.com/dfuse-io/dlauncher/launcher"
+	"github.com/dfuse-io/dstore"
+	"github.com/eoscanada/eos-go"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// newCommonBlockFilter creates the block filter defined by the common filtering flags, the
+// `--common-filter-schedule-file` flag taking precedence over the include/exclude expressions.
+//
+// The named sets of `--common-filter-sets` are loaded first, they are then used by all filters,
+// including component ones.
+func newCommonBlockFilter(dataDir string) (*filtering.BlockFilter, error) {
+	if err := filtering.LoadNamedSetFiles(viper.GetStringSlice("common-filter-sets")); err != nil {
+		return nil, err
+	}
+
+	schedule, err := commonFilterSchedule()
+	if err != nil {
+		return nil, err
+	}
+
+	blockFilter, err := filtering.NewScheduledBlockFilter(schedule, commonBlockFilterOptions()...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setBlockFilterChainID(blockFilter); err != nil {
+		return nil, err
+	}
+
+	blockFilter.SetStrict(viper.GetBool("common-filter-strict"))
+	warnFilterValidationIssues(dataDir, blockFilter)
+
+	return blockFilter, nil
+}
+
+// commonFilterSchedule returns the filter schedule of `--common-filter-schedule-file` when set,
+// otherwise a schedule made of the common include/exclude expressions applying to all blocks.
+func commonFilterSchedule() (filtering.FilterSchedule, error) {
+	scheduleFile := viper.GetString("common-filter-schedule-file")
+	if scheduleFile == "" {
+		return filtering.FilterSchedule{{
+			StartBlock: 0,
+			Include:    viper.GetString("common-include-filter-expr"),
+			Exclude:    viper.GetString("common-exclude-filter-expr"),
+		}}, nil
+	}
+
+	schedule, err := filtering.LoadFilterSchedule(scheduleFile)
+	if err != nil {
+		return nil, err
+	}
+
+	userLog.Debug("using filter schedule", zap.String("schedule_file", scheduleFile), zap.Int("entry_count", len(schedule)))
+	return schedule, nil
+}
+
+// commonBlockFilterOptions returns the block filter options shared by the common and the
+// component block filters, like the always included actions of `--common-filter-always-include`.
+func commonBlockFilterOptions() []filtering.BlockFilterOption {
+	return []filtering.BlockFilterOption{
+		filtering.WithAlwaysIncludedActions(viper.GetStringSlice("common-filter-always-include")),
+	}
+}
+
+// componentBlockFilter returns the block filter function a component should use. When
+// none of the component's `<flagPrefix>-include-filter-expr` and `<flagPrefix>-exclude-filter-expr`
+// flags are set, the common block filter of the runtime is used. Otherwise, a dedicated block
+// filter is created from the common filter schedule, the set component flags overriding the
+// programs of all its entries, the unset one falling back to the common programs.
+//
+// Blocks received from the relayer or read from `merged-filter` output are already filtered
+// with the common filter, a component filter can only further restrict what gets processed.
+func componentBlockFilter(runtime *launcher.Runtime, flagPrefix string) (func(blk *bstream.Block) error, error) {
+	includeExpr := viper.GetString(flagPrefix + "-include-filter-expr")
+	excludeExpr := viper.GetString(flagPrefix + "-exclude-filter-expr")
+	if includeExpr == "" && excludeExpr == "" {
+		return runtime.BlockFilter.TransformInPlace, nil
+	}
+
+	schedule, err := commonFilterSchedule()
+	if err != nil {
+		return nil, err
+	}
+
+	userLog.Debug("using component specific block filter",
+		zap.String("flag_prefix", flagPrefix),
+		zap.String("include_expr", includeExpr),
+		zap.String("exclude_expr", excludeExpr),
+		zap.Int("schedule_entry_count", len(schedule)),
+	)
+
+	blockFilter, err := filtering.NewScheduledBlockFilter(schedule.Override(includeExpr, excludeExpr), commonBlockFilterOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s block filter: %w", flagPrefix, err)
+	}
+
+	if err := setBlockFilterChainID(blockFilter); err != nil {
+		return nil, err
+	}
+
+	blockFilter.SetStrict(viper.GetBool("common-filter-strict"))
+	warnFilterValidationIssues(runtime.AbsDataDir, blockFilter)
+
+	return blockFilter.TransformInPlace, nil
+}
+
+// setBlockFilterChainID configures the `--common-chain-id` on the block filter so that filter
+// programs can use `trx.signers`, which is left undefined when the flag is not set.
+func setBlockFilterChainID(blockFilter *filtering.BlockFilter) error {
+	chainID := viper.GetString("common-chain-id")
+	if chainID == "" {
+		return nil
+	}
+
+	hexChainID, err := hex.DecodeString(chainID)
+	if err != nil {
+		return fmt.Errorf("unable to decode chain id %q: %w", chainID, err)
+	}
+
+	blockFilter.SetChainID(hexChainID)
+	return nil
+}
+
+var filterValidationABIGetterOnce sync.Once
+var filterValidationABIGetter filtering.ABIGetter
+
+// warnFilterValidationIssues statically validates the filter programs against the ABIs of the
+// `abicodec` cache when `--common-filter-validate` is set, the ABIs missing from the cache being
+// fetched from the fluxdb of `--common-filter-validate-fluxdb-addr` when set. Each issue found is
+// logged as a warning, the validation never prevents the filter from being used.
+func warnFilterValidationIssues(dataDir string, blockFilter *filtering.BlockFilter) {
+	if !viper.GetBool("common-filter-validate") {
+		return
+	}
+
+	filterValidationABIGetterOnce.Do(func() {
+		filterValidationABIGetter = filtering.FirstABIGetter(newFilterValidationCacheABIGetter(dataDir), newFilterValidationFluxDBABIGetter())
+	})
+
+	issues, err := blockFilter.Validate(filterValidationABIGetter)
+	if err != nil {
+		userLog.Warn("unable to validate filter", zap.Error(err))
+		return
+	}
+
+	for _, issue := range issues {
+		userLog.Warn("filter validation issue", zap.Stringer("issue", issue))
+	}
+}
+
+func newFilterValidationCacheABIGetter(dataDir string) filtering.ABIGetter {
+	cacheBaseURL := mustReplaceDataDir(dataDir, viper.GetString("abicodec-cache-base-url"))
+	store, err := dstore.NewSimpleStore(cacheBaseURL)
+	if err != nil {
+		userLog.Warn("unable to create abicodec cache store, filter validation will not use it", zap.String("cache_base_url", cacheBaseURL), zap.Error(err))
+		return nil
+	}
+
+	cache, err := abicodec.NewABICache(store, viper.GetString("abicodec-cache-file-name"))
+	if err != nil {
+		userLog.Warn("unable to load abicodec cache, filter validation will not use it", zap.String("cache_base_url", cacheBaseURL), zap.Error(err))
+		return nil
+	}
+
+	return func(account string) (*eos.ABI, error) {
+		item := cache.ABIAtBlockNum(account, math.MaxUint32)
+		if item == nil {
+			return nil, nil
+		}
+
+		return item.ABI, nil
+	}
+}
+
+// newFilterValidationFluxDBABIGetter returns the fluxdb ABI getter of the filter validation. The
+// fluxdb instance might not be ready at startup, an account whose ABI cannot be fetched is then
+// treated as having no known ABI instead of failing the whole validation.
+func newFilterValidationFluxDBABIGetter() filtering.ABIGetter {
+	fluxdbAddr := viper.GetString("common-filter-validate-fluxdb-addr")
+	if fluxdbAddr == "" {
+		return nil
+	}
+
+	getABI := filtering.NewFluxDBABIGetter(fluxdbAddr)
+	return func(account string) (*eos.ABI, error) {
+		abi, err := getABI(account)
+		if err != nil {
+			userLog.Warn("unable to fetch abi from fluxdb, filter validation will not check its data fields", zap.String("account", account), zap.Error(err))
+			return nil, nil
+		}
+
+		return abi, nil
+	}
+}
@@ -30,6 +30,7 @@ func init() {
 		MetricsID:   "mindreader-stdin",
 		Logger:      launcher.NewLoggingDef("github.com/dfuse-io/dfuse-eosio/mindreader_stdin$", []zapcore.Level{zap.WarnLevel, zap.WarnLevel, zap.InfoLevel, zap.DebugLevel}),
 		RegisterFlags: func(cmd *cobra.Command) error {
+			cmd.Flags().Bool("mindreader-stdin-fork-aware", false, "When enabled, the deep-mind reader tracks the blocks that are not irreversible yet and logs the blocks undone by each fork switch read from standard input")
 			return nil
 		},
 		FactoryFunc: func(runtime *launcher.Runtime) (launcher.App, error) {
@@ -37,17 +38,38 @@ func init() {
 			archiveStoreURL := mustReplaceDataDir(dfuseDataDir, viper.GetString("common-oneblock-store-url"))
 			mergeArchiveStoreURL := mustReplaceDataDir(dfuseDataDir, viper.GetString("common-blocks-store-url"))
 
+			forkAware := viper.GetBool("mindreader-stdin-fork-aware")
+
+			var forkAwareOptions []codec.ConsoleReaderOption
+			if forkAware {
+				forkAwareOptions = append(forkAwareOptions, codec.WithForkAwareness())
+			}
+
+			consoleReaderOptions := consoleReaderOptions(forkAwareOptions...)
 			consoleReaderFactory := func(reader io.Reader) (mindreader.ConsolerReader, error) {
-				return codec.NewConsoleReader(reader)
+				consoleReader, err := codec.NewConsoleReader(reader, consoleReaderOptions...)
+				if err != nil || !forkAware {
+					return consoleReader, err
+				}
+
+				return &forkSwitchLoggingReader{consoleReader, appLogger}, nil
 			}
 
+			verifyBlockIntegrity := viper.GetBool("mindreader-verify-block-integrity")
+			blockFromProto := blockFromProtoFunc()
 			consoleReaderBlockTransformer := func(obj interface{}) (*bstream.Block, error) {
 				blk, ok := obj.(*pbcodec.Block)
 				if !ok {
 					return nil, fmt.Errorf("expected *pbcodec.Block, got %T", obj)
 				}
 
-				return codec.BlockFromProto(blk)
+				if verifyBlockIntegrity {
+					if err := checkBlockIntegrity(blk); err != nil {
+						return nil, err
+					}
+				}
+
+				return blockFromProto(blk)
 			}
 
 			metricID := "mindreader-stdin"
@@ -75,3 +97,36 @@ func init() {
 		},
 	})
 }
+
+// forkSwitchLoggingReader logs the fork switches returned by a fork-aware console reader and
+// skips them, the mindreader plugin only accepting blocks.
+type forkSwitchLoggingReader struct {
+	*codec.ConsoleReader
+	logger *zap.Logger
+}
+
+func (r *forkSwitchLoggingReader) Read() (interface{}, error) {
+	for {
+		obj, err := r.ConsoleReader.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		forkSwitch, ok := obj.(*codec.ForkSwitch)
+		if !ok {
+			return obj, nil
+		}
+
+		undoneBlocks := make([]string, len(forkSwitch.UndoneBlocks))
+		for i, ref := range forkSwitch.UndoneBlocks {
+			undoneBlocks[i] = ref.String()
+		}
+
+		r.logger.Info("fork switch read from deep-mind",
+			zap.String("from_block_id", forkSwitch.FromBlockID),
+			zap.String("to_block_id", forkSwitch.ToBlockID),
+			zap.Stringer("fork_point", forkSwitch.ForkPoint),
+			zap.Strings("undone_blocks", undoneBlocks),
+		)
+	}
+}
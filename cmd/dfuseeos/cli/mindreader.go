@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/dfuse-io/bstream"
@@ -73,6 +74,10 @@ func init() {
 			cmd.Flags().Bool("mindreader-start-failure-handler", true, "Enables the startup function handler, that gets called if mindreader fails on startup")
 			cmd.Flags().Bool("mindreader-fail-on-non-contiguous-block", false, "Enables the Continuity Checker that stops (or refuses to start) the superviser if a block was missed. It has a significant performance cost on reprocessing large segments of blocks")
 			cmd.Flags().Duration("mindreader-wait-upload-complete-on-shutdown", 30*time.Second, "When the mindreader is shutting down, it will wait up to that amount of time for the archiver to finish uploading the blocks before leaving anyway")
+			cmd.Flags().Bool("mindreader-verify-block-integrity", false, "Enables the verification of each block read from nodeos (block id, transaction and action merkle roots, producer signature), mindreader stops on the first block failing it. It has a significant performance cost on reprocessing large segments of blocks")
+			cmd.Flags().Int("mindreader-deep-mind-decoding-workers", 0, "When greater than 1, the hex and binary payloads of deep-mind lines are decoded concurrently by that many workers, blocks still being assembled in order. Raise this number when reprocessing large segments of blocks is bound by the decoding of the deep-mind output")
+			cmd.Flags().Bool("mindreader-deep-mind-strict", false, "When enabled, mindreader fails on deep-mind lines unknown to the deep mind version reported by nodeos instead of only logging them")
+			cmd.Flags().Bool("mindreader-deduplicate-block-payloads", false, "When enabled, blocks are written using the deduplicated payload format, storing the actions and the account names repeated in a block only once. Reduces the size of one block and merged blocks files on spam-heavy chains, all readers decoding both formats transparently")
 			return nil
 		},
 		InitFunc: func(runtime *launcher.Runtime) error {
@@ -98,17 +103,31 @@ func init() {
 				}
 
 			}
+			nodeosBuildInfo, err := NodeosBuildInfo(viper.GetString("mindreader-nodeos-path"))
+			if err != nil {
+				userLog.Warn("unable to determine nodeos build info, it will not be recorded on blocks", zap.Error(err))
+			}
+
+			consoleReaderOptions := consoleReaderOptions(codec.WithNodeosBuildInfo(nodeosBuildInfo))
 			consoleReaderFactory := func(reader io.Reader) (mindreader.ConsolerReader, error) {
-				return codec.NewConsoleReader(reader)
+				return codec.NewConsoleReader(reader, consoleReaderOptions...)
 			}
 			//
+			verifyBlockIntegrity := viper.GetBool("mindreader-verify-block-integrity")
+			blockFromProto := blockFromProtoFunc()
 			consoleReaderBlockTransformer := func(obj interface{}) (*bstream.Block, error) {
 				blk, ok := obj.(*pbcodec.Block)
 				if !ok {
 					return nil, fmt.Errorf("expected *pbcodec.Block, got %T", obj)
 				}
 
-				return codec.BlockFromProto(blk)
+				if verifyBlockIntegrity {
+					if err := checkBlockIntegrity(blk); err != nil {
+						return nil, err
+					}
+				}
+
+				return blockFromProto(blk)
 			}
 
 			var p *profiler.Profiler
@@ -212,3 +231,34 @@ func init() {
 	})
 
 }
+
+func consoleReaderOptions(opts ...codec.ConsoleReaderOption) []codec.ConsoleReaderOption {
+	opts = append(opts, codec.WithParallelDecoding(viper.GetInt("mindreader-deep-mind-decoding-workers")))
+	if viper.GetBool("mindreader-deep-mind-strict") {
+		opts = append(opts, codec.WithStrictLineHandling())
+	}
+
+	return opts
+}
+
+func blockFromProtoFunc() func(b *pbcodec.Block) (*bstream.Block, error) {
+	if viper.GetBool("mindreader-deduplicate-block-payloads") {
+		return codec.DeduplicatedBlockFromProto
+	}
+
+	return codec.BlockFromProto
+}
+
+func checkBlockIntegrity(blk *pbcodec.Block) error {
+	issues := codec.VerifyBlock(blk)
+	if len(issues) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(issues))
+	for i, issue := range issues {
+		messages[i] = issue.String()
+	}
+
+	return fmt.Errorf("block #%d (%s) failed integrity verification: %s", blk.Num(), blk.ID(), strings.Join(messages, "; "))
+}
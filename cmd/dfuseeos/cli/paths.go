@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os/exec"
+	"strings"
 )
 
 func CheckNodeosInstallation(path string) error {
@@ -12,3 +13,14 @@ func CheckNodeosInstallation(path string) error {
 
 	return nil
 }
+
+// NodeosBuildInfo returns the full version of the nodeos binary at path, which contains the
+// version as well as the commit it was built from.
+func NodeosBuildInfo(path string) (string, error) {
+	out, err := exec.Command(path, "--full-version").Output()
+	if err != nil {
+		return "", fmt.Errorf("unable to get full version of %s: %w", path, err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
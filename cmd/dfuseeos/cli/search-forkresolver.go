@@ -38,6 +38,11 @@ func init() {
 
 			eosSearch.RegisterHandlers(mapper.IndexedTerms())
 
+			blockFilter, err := componentBlockFilter(runtime, "search-common")
+			if err != nil {
+				return nil, err
+			}
+
 			return forkresolverApp.New(&forkresolverApp.Config{
 				ServiceVersion:  viper.GetString("search-common-mesh-service-version"),
 				GRPCListenAddr:  viper.GetString("search-forkresolver-grpc-listen-addr"),
@@ -46,7 +51,7 @@ func init() {
 				IndicesPath:     viper.GetString("search-forkresolver-indices-path"),
 				BlocksStoreURL:  mustReplaceDataDir(dfuseDataDir, viper.GetString("common-blocks-store-url")),
 			}, &forkresolverApp.Modules{
-				BlockFilter: runtime.BlockFilter.TransformInPlace,
+				BlockFilter: blockFilter,
 				BlockMapper: mapper,
 				Dmesh:       runtime.SearchDmeshClient,
 			}), nil
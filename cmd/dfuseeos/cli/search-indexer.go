@@ -46,6 +46,11 @@ func init() {
 
 			eosSearch.RegisterHandlers(mapper.IndexedTerms())
 
+			blockFilter, err := componentBlockFilter(runtime, "search-common")
+			if err != nil {
+				return nil, err
+			}
+
 			dfuseDataDir := runtime.AbsDataDir
 			blocksStoreURL := mustReplaceDataDir(dfuseDataDir, viper.GetString("common-blocks-store-url"))
 			blockstreamAddr := viper.GetString("common-blockstream-addr")
@@ -69,7 +74,7 @@ func init() {
 				IndicesStoreURL:       mustReplaceDataDir(dataDir, viper.GetString("search-common-indices-store-url")),
 				BlocksStoreURL:        blocksStoreURL,
 			}, &indexerApp.Modules{
-				BlockFilter: runtime.BlockFilter.TransformInPlace,
+				BlockFilter: blockFilter,
 				BlockMapper: mapper,
 				Tracker:     tracker,
 			}), nil
@@ -47,6 +47,11 @@ func init() {
 
 			eosSearch.RegisterHandlers(mapper.IndexedTerms())
 
+			blockFilter, err := componentBlockFilter(runtime, "search-common")
+			if err != nil {
+				return nil, err
+			}
+
 			blockmetaAddr := viper.GetString("common-blockmeta-addr")
 			blockstreamAddr := viper.GetString("common-blockstream-addr")
 
@@ -67,7 +72,7 @@ func init() {
 				HubChannelSize:           viper.GetInt("search-live-hub-channel-size"),
 				PreProcConcurrentThreads: viper.GetInt("search-live-preprocessor-concurrent-threads"),
 			}, &liveApp.Modules{
-				BlockFilter: runtime.BlockFilter.TransformInPlace,
+				BlockFilter: blockFilter,
 				BlockMapper: mapper,
 				Dmesh:       runtime.SearchDmeshClient,
 				Tracker:     runtime.Tracker,
@@ -21,7 +21,6 @@ import (
 	"time"
 
 	"github.com/dfuse-io/dfuse-eosio/codec"
-	"github.com/dfuse-io/dfuse-eosio/filtering"
 	"github.com/dfuse-io/dgrpc"
 	"github.com/dfuse-io/dstore"
 	pbblockmeta "github.com/dfuse-io/pbgo/dfuse/blockmeta/v1"
@@ -30,6 +29,7 @@ import (
 	"github.com/dfuse-io/derr"
 	_ "github.com/dfuse-io/dfuse-eosio/codec"
 	_ "github.com/dfuse-io/dfuse-eosio/trxdb/kv"
+	_ "github.com/dfuse-io/dfuse-eosio/trxdb/sql"
 	"github.com/dfuse-io/dlauncher/launcher"
 	dmeshClient "github.com/dfuse-io/dmesh/client"
 	_ "github.com/dfuse-io/kvdb/store/badger"
@@ -80,7 +80,7 @@ func Start(dataDir string, args []string) (err error) {
 		return fmt.Errorf("unable to create dmesh client: %w", err)
 	}
 
-	blockfilter, err := filtering.NewBlockFilter(viper.GetString("common-include-filter-expr"), viper.GetString("common-exclude-filter-expr"))
+	blockfilter, err := newCommonBlockFilter(dataDirAbs)
 	if err != nil {
 		return fmt.Errorf("unable to create block filter: %w", err)
 	}
@@ -28,11 +28,21 @@ func init() {
 			cmd.Flags().Bool("trxdb-loader-truncation-enabled", false, "Write truncation markers, and enable the automated purge of blocks past the window")
 			cmd.Flags().Uint64("trxdb-loader-truncation-purge-interval", 1000, "Interval of blocks between each purge.")
 			cmd.Flags().Uint64("trxdb-loader-truncation-window", 0, "When truncating, purge blocks older than this amount of blocks.")
+			cmd.Flags().String("trxdb-loader-retention-policy", "", "Comma separated list of table=ttl pairs (e.g. 'trx_traces=100000,dtrxs=1000'), rows of those tables being purged ttl blocks after being written, other tables being kept forever. Deferred transaction rows are kept until the transaction executes, expires, fails or is cancelled. Purges every --trxdb-loader-truncation-purge-interval blocks, cannot be combined with --trxdb-loader-truncation-enabled. Tables: blocks, trxs, trx_traces, implicit_trxs, dtrxs, accounts, account_history, timeline")
+			cmd.Flags().Bool("trxdb-loader-account-history-enabled", false, "Write the account history index, listing the irreversible actions received or authorized by each account (respecting the filter), served without requiring search")
+			cmd.Flags().String("trxdb-loader-repair-plan-file", "", "[PATCH] Repair plan file produced by 'dfuseeos tools check trxdb-blocks --deep', the blocks it lists are written again, the start and stop block numbers being taken from the plan")
+			cmd.Flags().String("trxdb-loader-include-filter-expr", "", "CEL program to determine if a given action should be included in trxdb, defaults to --common-include-filter-expr (or the --common-filter-schedule-file entries) when empty. Applied on top of the common filter.")
+			cmd.Flags().String("trxdb-loader-exclude-filter-expr", "", "CEL program to determine if an included action should be excluded from trxdb, defaults to --common-exclude-filter-expr (or the --common-filter-schedule-file entries) when empty. Applied on top of the common filter.")
 			return nil
 		},
 		FactoryFunc: func(runtime *launcher.Runtime) (launcher.App, error) {
 			dfuseDataDir := runtime.AbsDataDir
 
+			blockFilter, err := componentBlockFilter(runtime, "trxdb-loader")
+			if err != nil {
+				return nil, err
+			}
+
 			return trxdbLoaderApp.New(&trxdbLoaderApp.Config{
 				ChainID:                   viper.GetString("common-chain-id"),
 				ProcessingType:            viper.GetString("trxdb-loader-processing-type"),
@@ -49,8 +59,11 @@ func init() {
 				EnableTruncationMarker:    viper.GetBool("trxdb-loader-truncation-enabled"),
 				TruncationWindow:          viper.GetUint64("trxdb-loader-truncation-window"),
 				PurgerInterval:            viper.GetUint64("trxdb-loader-truncation-purge-interval"),
+				EnableAccountHistory:      viper.GetBool("trxdb-loader-account-history-enabled"),
+				RetentionPolicy:           viper.GetString("trxdb-loader-retention-policy"),
+				RepairPlanFile:            viper.GetString("trxdb-loader-repair-plan-file"),
 			}, &trxdbLoaderApp.Modules{
-				BlockFilter: runtime.BlockFilter.TransformInPlace,
+				BlockFilter: blockFilter,
 			}), nil
 		},
 	})
@@ -229,6 +229,17 @@ func (c *ABIDecoder) processTransaction(trxTrace *pbcodec.TransactionTrace) erro
 		}
 	}
 
+	for _, dbOp := range trxTrace.DbOps {
+		// Rows are decoded against the ABI that was active when the action performing the operation executed,
+		// which might not be the one active at the end of the transaction if it updated the ABI of the contract.
+		globalSequence := mostRecentActiveABI
+		if int(dbOp.ActionIndex) < len(trxTrace.ActionTraces) {
+			globalSequence = actionTraceGlobalSequence(trxTrace.ActionTraces[dbOp.ActionIndex])
+		}
+
+		decodingJobs = append(decodingJobs, dbOpDecodingJob{dbOp, c.activeBlockNum, trxTrace.Id, globalSequence, localCache})
+	}
+
 	zlog.Debug("queuing transaction trace decoding jobs", zap.Uint64("block_num", c.activeBlockNum), zap.String("id", trxTrace.Id), zap.Int("job_count", len(decodingJobs)))
 	return c.addJobs(decodingJobs)
 }
@@ -346,12 +357,15 @@ type decodingJob interface {
 
 func (j actionDecodingJob) blockNum() uint64 { return j.actualblockNum }
 func (j dtrxDecodingJob) blockNum() uint64   { return j.actionDecodingJob.actualblockNum }
+func (j dbOpDecodingJob) blockNum() uint64   { return j.actualblockNum }
 
 func (j actionDecodingJob) trxID() string { return j.actualTrxID }
 func (j dtrxDecodingJob) trxID() string   { return j.actionDecodingJob.actualTrxID }
+func (j dbOpDecodingJob) trxID() string   { return j.actualTrxID }
 
 func (j actionDecodingJob) kind() string { return "action" }
 func (j dtrxDecodingJob) kind() string   { return "dtrx" }
+func (j dbOpDecodingJob) kind() string   { return "dbop" }
 
 type actionDecodingJob struct {
 	action         *pbcodec.Action
@@ -365,6 +379,14 @@ type dtrxDecodingJob struct {
 	actionDecodingJob
 }
 
+type dbOpDecodingJob struct {
+	dbOp           *pbcodec.DBOp
+	actualblockNum uint64
+	actualTrxID    string
+	globalSequence uint64
+	localCache     *ABICache
+}
+
 func (d *ABIDecoder) addJobs(jobs []decodingJob) error {
 	for _, job := range jobs {
 		if traceEnabled {
@@ -427,6 +449,8 @@ func (d *ABIDecoder) executeDecodingJob(ctx context.Context, batch []interface{}
 		return []interface{}{job.kind()}, d.decodeAction(v.action, v.globalSequence, job.trxID(), job.blockNum(), v.localCache)
 	case dtrxDecodingJob:
 		return []interface{}{job.kind()}, d.decodeAction(v.action, v.globalSequence, job.trxID(), job.blockNum(), v.localCache)
+	case dbOpDecodingJob:
+		return []interface{}{job.kind()}, d.decodeDBOp(v.dbOp, v.globalSequence, job.trxID(), job.blockNum(), v.localCache)
 	default:
 		return nil, fmt.Errorf("unknown decoding job kind %s", job.kind())
 	}
@@ -537,6 +561,61 @@ func (d *ABIDecoder) decodeAction(action *pbcodec.Action, globalSequence uint64,
 	return nil
 }
 
+func (d *ABIDecoder) decodeDBOp(dbOp *pbcodec.DBOp, globalSequence uint64, trxID string, blockNum uint64, localCache *ABICache) error {
+	if traceEnabled {
+		zlog.Debug("decoding db op", zap.String("contract", dbOp.Code), zap.String("table", dbOp.TableName), zap.Uint64("global_sequence", globalSequence))
+	}
+
+	if len(dbOp.OldData) <= 0 && len(dbOp.NewData) <= 0 {
+		return nil
+	}
+
+	abi := d.findABI(dbOp.Code, globalSequence, localCache)
+	if abi == nil {
+		if traceEnabled {
+			zlog.Debug("skipping db op since no ABI found for it", zap.String("contract", dbOp.Code), zap.String("table", dbOp.TableName), zap.Uint64("global_sequence", globalSequence))
+		}
+		return nil
+	}
+
+	tableDef := abi.TableForName(eos.TableName(dbOp.TableName))
+	if tableDef == nil {
+		if traceEnabled {
+			zlog.Debug("skipping db op since table was not in ABI", zap.String("contract", dbOp.Code), zap.String("table", dbOp.TableName), zap.Uint64("global_sequence", globalSequence))
+		}
+		return nil
+	}
+
+	decodeRow := func(data []byte, tag string) string {
+		if len(data) <= 0 {
+			return ""
+		}
+
+		jsonData, err := abi.DecodeTableRowTyped(tableDef.Type, data)
+		if err != nil {
+			// Like for actions, rows can contain anything that does not fit the ABI, we cannot error out here
+			zlog.Debug("skipping db op row since we were not able to decode it against ABI",
+				zap.Uint64("block_num", blockNum),
+				zap.String("trx_id", trxID),
+				zap.String("contract", dbOp.Code),
+				zap.String("table", dbOp.TableName),
+				zap.String("primary_key", dbOp.PrimaryKey),
+				zap.String("row", tag),
+				zap.Uint64("global_sequence", globalSequence),
+				zap.Error(err),
+			)
+			return ""
+		}
+
+		return string(jsonData)
+	}
+
+	dbOp.OldDataJson = decodeRow(dbOp.OldData, "old")
+	dbOp.NewDataJson = decodeRow(dbOp.NewData, "new")
+
+	return nil
+}
+
 func (d *ABIDecoder) findABI(contract string, globalSequence uint64, localCache *ABICache) *eos.ABI {
 	if localCache != emptyCache {
 		localCache.RLock()
@@ -369,6 +369,38 @@ func TestABIDecoder(t *testing.T) {
 				{"block 0/trace 2/action 0", `{"from":"eosio","to":"token","quantity":"1.0000 EOS","memo":"With memo"}`},
 			},
 		},
+		{
+			name: "db ops are correctly decoded",
+			blocks: in(
+				testBlock(t, "00000002aa", "00000001aa",
+					trxTrace(t,
+						actionTraceSetABI(t, "test", 0, 1, testABI1),
+						actionTrace(t, "test:test:act1", 1, 2, testABI1, `{"from":"test1"}`),
+						dbOp(t, 1, "insert", "test/test/rows/a", testABI1, "", `{"from":"row1"}`),
+						actionTraceSetABI(t, "test", 2, 3, testABI2),
+						actionTrace(t, "test:test:act2", 3, 4, testABI2, `{"to":20}`),
+						dbOp(t, 3, "insert", "test/test/rows/b", testABI2, "", `{"to":1}`),
+					),
+				),
+				testBlock(t, "00000003aa", "00000002aa",
+					trxTrace(t,
+						actionTrace(t, "test:test:act2", 0, 5, testABI2, `{"to":20}`),
+						dbOp(t, 0, "update", "test/test/rows/b", testABI2, `{"to":1}`, `{"to":2}`),
+						dbOp(t, 0, "remove", "test/test/rows/b", testABI2, `{"to":2}`, ""),
+						dbOp(t, 0, "insert", "test/test/unknown/c", testABI2, "", `{"to":3}`),
+					),
+				),
+			),
+			expectations: []expectation{
+				{"block 0/trace 0/dbOp 0/new", `{"from":"row1"}`},
+				{"block 0/trace 0/dbOp 1/new", `{"to":1}`},
+				{"block 1/trace 0/dbOp 0/old", `{"to":1}`},
+				{"block 1/trace 0/dbOp 0/new", `{"to":2}`},
+				{"block 1/trace 0/dbOp 1/old", `{"to":2}`},
+				{"block 1/trace 0/dbOp 2/new", `0300000000000000`},
+			},
+		},
+
 		// TODO: Add those tests
 		//        - ensures "hard-coded" system methods like `setabi`, `setcode` always work?
 	}
@@ -384,6 +416,7 @@ func TestABIDecoder(t *testing.T) {
 	actionTraceRegex := regexp.MustCompile("^block (\\d+)/trace (\\d+)/action (\\d+)$")
 	dtrxOpRegex := regexp.MustCompile("^block (\\d+)/trace (\\d+)/dtrxOp (\\d+)/(action|cfaAction) (\\d+)$")
 	trxOpRegex := regexp.MustCompile("^block (\\d+)/trxOp (\\d+)/(action|cfaAction) (\\d+)$")
+	dbOpRegex := regexp.MustCompile("^block (\\d+)/trace (\\d+)/dbOp (\\d+)/(old|new)$")
 
 	toInt := func(in string) int {
 		out, err := strconv.ParseInt(in, 10, 32)
@@ -409,6 +442,17 @@ func TestABIDecoder(t *testing.T) {
 		}
 	}
 
+	assertMatchDBOpRow := func(expected string, rawData []byte, jsonData string, dbOp *pbcodec.DBOp) {
+		if hexRegex.MatchString(expected) {
+			require.Equal(t, expected, hex.EncodeToString(rawData), toString(dbOp))
+			require.Empty(t, jsonData, "JSON data should be empty\n%s", toString(dbOp))
+		} else {
+			require.NotEmpty(t, rawData, "Raw data should still be populated\n%s", toString(dbOp))
+			require.NotEmpty(t, jsonData, "JSON data should not be empty\n%s", toString(dbOp))
+			assert.JSONEq(t, expected, jsonData)
+		}
+	}
+
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			decoder := newABIDecoder()
@@ -457,6 +501,18 @@ func TestABIDecoder(t *testing.T) {
 					continue
 				}
 
+				if match = fullMatchRegex(dbOpRegex, expect.path); match != nil {
+					_, trace := extractTrace(&test, match)
+					dbOp := trace.DbOps[toInt(match[3])]
+
+					if match[4] == "old" {
+						assertMatchDBOpRow(expect.value, dbOp.OldData, dbOp.OldDataJson, dbOp)
+					} else if match[4] == "new" {
+						assertMatchDBOpRow(expect.value, dbOp.NewData, dbOp.NewDataJson, dbOp)
+					}
+					continue
+				}
+
 				if match = fullMatchRegex(trxOpRegex, expect.path); match != nil {
 					block := test.blocks[toInt(match[1])]
 					trxOp := block.UnfilteredImplicitTransactionOps[toInt(match[2])]
@@ -655,6 +711,37 @@ func dtrxOp(t *testing.T, actionIndex uint32, operation string, signedTrx *pbcod
 	return op
 }
 
+func dbOp(t *testing.T, actionIndex uint32, operation string, path string, abi *eos.ABI, oldData string, newData string) *pbcodec.DBOp {
+	opName := pbcodec.DBOp_Operation_value["OPERATION_"+strings.ToUpper(operation)]
+
+	parts := strings.Split(path, "/")
+	op := &pbcodec.DBOp{
+		Operation:   pbcodec.DBOp_Operation(opName),
+		ActionIndex: actionIndex,
+		Code:        parts[0],
+		Scope:       parts[1],
+		TableName:   parts[2],
+		PrimaryKey:  parts[3],
+	}
+
+	encodeRow := func(data string) []byte {
+		if data == "" {
+			return nil
+		}
+
+		// Rows are always encoded using the `rows` table type, so rows of tables unknown to the ABI can be created
+		rawData, err := abi.EncodeTable("rows", []byte(data))
+		require.NoError(t, err)
+
+		return rawData
+	}
+
+	op.OldData = encodeRow(oldData)
+	op.NewData = encodeRow(newData)
+
+	return op
+}
+
 func maybePrintBlock(t *testing.T, block *pbcodec.Block) {
 	if os.Getenv("DEBUG") == "" && os.Getenv("TRACE") != "true" {
 		return
@@ -32,6 +32,10 @@ func BlockFromProto(b *pbcodec.Block) (*bstream.Block, error) {
 		return nil, fmt.Errorf("unable to marshal to binary form: %s", err)
 	}
 
+	return blockFromProtoPayload(b, 1, content)
+}
+
+func blockFromProtoPayload(b *pbcodec.Block, payloadVersion int32, content []byte) (*bstream.Block, error) {
 	blockTime, err := b.Time()
 	if err != nil {
 		return nil, err
@@ -44,7 +48,7 @@ func BlockFromProto(b *pbcodec.Block) (*bstream.Block, error) {
 		Timestamp:      blockTime,
 		LibNum:         b.LIBNum(),
 		PayloadKind:    pbbstream.Protocol_EOS,
-		PayloadVersion: 1,
+		PayloadVersion: payloadVersion,
 		PayloadBuffer:  content,
 	}, nil
 }
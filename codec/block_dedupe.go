@@ -0,0 +1,455 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dfuse-io/bstream"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+)
+
+// DeduplicatedPayloadVersion is the `bstream.Block` payload version of blocks encoded by
+// `DeduplicatedBlockFromProto`.
+//
+// The payload is made of a per-block dictionary of the actions appearing more than once in
+// the block (notifications of a same action, identical spam actions, etc.) and a per-block
+// dictionary of the strings (account names, permissions, transaction and block ids) appearing
+// more than once, followed by the proto of the block in which those are replaced by references
+// into the dictionaries:
+//
+//	uvarint(len(strings)) [uvarint(len(string)) string]...
+//	uvarint(len(actions)) [uvarint(len(action)) proto(action)]...
+//	uvarint(len(action traces)) [uvarint(action ref)]...
+//	proto(block)
+//
+// An action ref of 0 means the action is inlined in its action trace, otherwise it is the index
+// plus one of the action in the dictionary. A referenced string is replaced by `\x00` followed
+// by its index in the dictionary in base 36.
+//
+// The dictionaries are per block, and not per bundle of blocks, so that a `bstream.Block` stays
+// decodable on its own wherever it flows (relayer, hub, one block files).
+const DeduplicatedPayloadVersion = 2
+
+const stringRefPrefix = "\x00"
+
+var errNotDeduplicable = errors.New("block contains a string starting with the string reference prefix")
+
+// DeduplicatedBlockFromProto is like `BlockFromProto` but encodes the payload using the
+// deduplicated format (see `DeduplicatedPayloadVersion`). Blocks that cannot be deduplicated
+// are encoded using the standard format, `BlockDecoder` transparently decoding both.
+func DeduplicatedBlockFromProto(b *pbcodec.Block) (*bstream.Block, error) {
+	content, err := encodeDeduplicatedBlock(b)
+	if err == errNotDeduplicable {
+		zlog.Info("block cannot be deduplicated, using standard payload format", zap.Stringer("block", b.AsRef()))
+		return BlockFromProto(b)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode deduplicated block: %w", err)
+	}
+
+	return blockFromProtoPayload(b, DeduplicatedPayloadVersion, content)
+}
+
+func encodeDeduplicatedBlock(b *pbcodec.Block) ([]byte, error) {
+	block := proto.Clone(b).(*pbcodec.Block)
+
+	actions, actionRefs, err := deduplicateActions(block)
+	if err != nil {
+		return nil, err
+	}
+
+	strs, err := deduplicateStrings(block, actions)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	writeUvarint(buffer, uint64(len(strs)))
+	for _, str := range strs {
+		writeBytes(buffer, []byte(str))
+	}
+
+	writeUvarint(buffer, uint64(len(actions)))
+	for _, action := range actions {
+		data, err := proto.Marshal(action)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal action: %w", err)
+		}
+
+		writeBytes(buffer, data)
+	}
+
+	writeUvarint(buffer, uint64(len(actionRefs)))
+	for _, ref := range actionRefs {
+		writeUvarint(buffer, ref)
+	}
+
+	data, err := proto.Marshal(block)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal block: %w", err)
+	}
+
+	buffer.Write(data)
+	return buffer.Bytes(), nil
+}
+
+func decodeDeduplicatedBlock(payload []byte) (*pbcodec.Block, error) {
+	reader := bytes.NewReader(payload)
+
+	strCount, err := readCount(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read strings count: %w", err)
+	}
+
+	strs := make([]string, strCount)
+	for i := range strs {
+		data, err := readBytes(reader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read string #%d: %w", i, err)
+		}
+
+		strs[i] = string(data)
+	}
+
+	actionCount, err := readCount(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read actions count: %w", err)
+	}
+
+	actions := make([]*pbcodec.Action, actionCount)
+	for i := range actions {
+		data, err := readBytes(reader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read action #%d: %w", i, err)
+		}
+
+		actions[i] = new(pbcodec.Action)
+		if err := proto.Unmarshal(data, actions[i]); err != nil {
+			return nil, fmt.Errorf("unable to decode action #%d: %w", i, err)
+		}
+	}
+
+	refCount, err := readCount(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read action refs count: %w", err)
+	}
+
+	actionRefs := make([]uint64, refCount)
+	for i := range actionRefs {
+		if actionRefs[i], err = binary.ReadUvarint(reader); err != nil {
+			return nil, fmt.Errorf("unable to read action ref #%d: %w", i, err)
+		}
+	}
+
+	block := new(pbcodec.Block)
+	if err := proto.Unmarshal(payload[len(payload)-reader.Len():], block); err != nil {
+		return nil, fmt.Errorf("unable to decode block: %w", err)
+	}
+
+	if err := reduplicateStrings(block, actions, strs); err != nil {
+		return nil, err
+	}
+
+	if err := reduplicateActions(block, actions, actionRefs); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// deduplicateActions moves the actions appearing more than once in the block to the returned
+// dictionary, returning the reference of the action of each action trace.
+func deduplicateActions(block *pbcodec.Block) (actions []*pbcodec.Action, refs []uint64, err error) {
+	var keys []string
+	counts := map[string]int{}
+	walkActionTraces(block, func(actionTrace *pbcodec.ActionTrace) {
+		key := ""
+		if actionTrace.Action != nil {
+			data, marshalErr := proto.Marshal(actionTrace.Action)
+			if marshalErr != nil && err == nil {
+				err = fmt.Errorf("unable to marshal action: %w", marshalErr)
+			}
+
+			key = string(data)
+			counts[key]++
+		}
+
+		keys = append(keys, key)
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	indexes := map[string]uint64{}
+	refs = make([]uint64, len(keys))
+
+	i := 0
+	walkActionTraces(block, func(actionTrace *pbcodec.ActionTrace) {
+		key := keys[i]
+		i++
+
+		if actionTrace.Action == nil || counts[key] < 2 {
+			return
+		}
+
+		index, found := indexes[key]
+		if !found {
+			index = uint64(len(actions))
+			indexes[key] = index
+			actions = append(actions, actionTrace.Action)
+		}
+
+		refs[i-1] = index + 1
+		actionTrace.Action = nil
+	})
+
+	return actions, refs, nil
+}
+
+func reduplicateActions(block *pbcodec.Block, actions []*pbcodec.Action, refs []uint64) (err error) {
+	i := 0
+	walkActionTraces(block, func(actionTrace *pbcodec.ActionTrace) {
+		if err != nil {
+			return
+		}
+
+		if i >= len(refs) {
+			err = fmt.Errorf("block has more action traces than the %d action refs", len(refs))
+			return
+		}
+
+		ref := refs[i]
+		i++
+
+		if ref == 0 {
+			return
+		}
+
+		if ref > uint64(len(actions)) {
+			err = fmt.Errorf("action ref %d is out of the %d actions dictionary", ref, len(actions))
+			return
+		}
+
+		// Each action trace gets its own copy so that mutating one does not affect the others
+		actionTrace.Action = proto.Clone(actions[ref-1]).(*pbcodec.Action)
+	})
+
+	if err == nil && i != len(refs) {
+		err = fmt.Errorf("block has %d action traces, expected %d", i, len(refs))
+	}
+
+	return
+}
+
+// deduplicateStrings replaces the strings appearing more than once in the block and the actions
+// by references into the returned dictionary.
+func deduplicateStrings(block *pbcodec.Block, actions []*pbcodec.Action) (strs []string, err error) {
+	var order []string
+	counts := map[string]int{}
+	walkStrings(block, actions, func(str *string) {
+		if strings.HasPrefix(*str, stringRefPrefix) {
+			err = errNotDeduplicable
+		}
+
+		if counts[*str] == 0 {
+			order = append(order, *str)
+		}
+		counts[*str]++
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	refs := map[string]string{}
+	for _, str := range order {
+		ref := stringRefPrefix + strconv.FormatUint(uint64(len(strs)), 36)
+		if counts[str] < 2 || len(str) <= len(ref) {
+			continue
+		}
+
+		refs[str] = ref
+		strs = append(strs, str)
+	}
+
+	walkStrings(block, actions, func(str *string) {
+		if ref, found := refs[*str]; found {
+			*str = ref
+		}
+	})
+
+	return strs, nil
+}
+
+func reduplicateStrings(block *pbcodec.Block, actions []*pbcodec.Action, strs []string) (err error) {
+	walkStrings(block, actions, func(str *string) {
+		if err != nil || !strings.HasPrefix(*str, stringRefPrefix) {
+			return
+		}
+
+		index, parseErr := strconv.ParseUint((*str)[len(stringRefPrefix):], 36, 64)
+		if parseErr != nil {
+			err = fmt.Errorf("invalid string ref %q: %w", *str, parseErr)
+			return
+		}
+
+		if index >= uint64(len(strs)) {
+			err = fmt.Errorf("string ref %d is out of the %d strings dictionary", index, len(strs))
+			return
+		}
+
+		*str = strs[index]
+	})
+
+	return
+}
+
+// walkActionTraces calls `f` on each action trace of the block, in a stable order
+func walkActionTraces(block *pbcodec.Block, f func(actionTrace *pbcodec.ActionTrace)) {
+	walkTransactionTraces(block, func(trace *pbcodec.TransactionTrace) {
+		for _, actionTrace := range trace.ActionTraces {
+			f(actionTrace)
+		}
+	})
+}
+
+// walkTransactionTraces calls `f` on each transaction trace of the block, failed deferred
+// transaction traces included, in a stable order
+func walkTransactionTraces(block *pbcodec.Block, f func(trace *pbcodec.TransactionTrace)) {
+	var walk func(trace *pbcodec.TransactionTrace)
+	walk = func(trace *pbcodec.TransactionTrace) {
+		f(trace)
+		if trace.FailedDtrxTrace != nil {
+			walk(trace.FailedDtrxTrace)
+		}
+	}
+
+	for _, trace := range block.UnfilteredTransactionTraces {
+		walk(trace)
+	}
+
+	for _, trace := range block.FilteredTransactionTraces {
+		walk(trace)
+	}
+}
+
+// walkStrings calls `f` on each deduplicated string field of the block and of the actions, in
+// a stable order
+func walkStrings(block *pbcodec.Block, actions []*pbcodec.Action, f func(str *string)) {
+	walkAction := func(action *pbcodec.Action) {
+		if action == nil {
+			return
+		}
+
+		f(&action.Account)
+		f(&action.Name)
+		for _, authorization := range action.Authorization {
+			f(&authorization.Actor)
+			f(&authorization.Permission)
+		}
+	}
+
+	for _, action := range actions {
+		walkAction(action)
+	}
+
+	walkTransactionTraces(block, func(trace *pbcodec.TransactionTrace) {
+		f(&trace.Id)
+		f(&trace.ProducerBlockId)
+
+		for _, actionTrace := range trace.ActionTraces {
+			f(&actionTrace.Receiver)
+			f(&actionTrace.TransactionId)
+			f(&actionTrace.ProducerBlockId)
+			walkAction(actionTrace.Action)
+
+			if actionTrace.Receipt != nil {
+				f(&actionTrace.Receipt.Receiver)
+				for _, authSequence := range actionTrace.Receipt.AuthSequence {
+					f(&authSequence.AccountName)
+				}
+			}
+
+			for _, ramDelta := range actionTrace.AccountRamDeltas {
+				f(&ramDelta.Account)
+			}
+		}
+
+		for _, dbOp := range trace.DbOps {
+			f(&dbOp.Code)
+			f(&dbOp.Scope)
+			f(&dbOp.TableName)
+			f(&dbOp.OldPayer)
+			f(&dbOp.NewPayer)
+		}
+
+		for _, ramOp := range trace.RamOps {
+			f(&ramOp.Payer)
+		}
+	})
+}
+
+func writeUvarint(buffer *bytes.Buffer, value uint64) {
+	var scratch [binary.MaxVarintLen64]byte
+	buffer.Write(scratch[:binary.PutUvarint(scratch[:], value)])
+}
+
+func writeBytes(buffer *bytes.Buffer, data []byte) {
+	writeUvarint(buffer, uint64(len(data)))
+	buffer.Write(data)
+}
+
+// readCount reads an elements count, each element taking at least one byte of the payload
+func readCount(reader *bytes.Reader) (uint64, error) {
+	count, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	if count > uint64(reader.Len()) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	return count, nil
+}
+
+func readBytes(reader *bytes.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if length > uint64(reader.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduplicatedBlockFromProto(t *testing.T) {
+	block := spamBlock(t, 200)
+
+	standard, err := BlockFromProto(block)
+	require.NoError(t, err)
+
+	deduplicated, err := DeduplicatedBlockFromProto(block)
+	require.NoError(t, err)
+	assert.Equal(t, int32(DeduplicatedPayloadVersion), deduplicated.PayloadVersion)
+	assert.Equal(t, standard.Id, deduplicated.Id)
+	assert.Equal(t, standard.Number, deduplicated.Number)
+
+	standardSize := len(standard.PayloadBuffer)
+	deduplicatedSize := len(deduplicated.PayloadBuffer)
+	t.Logf("payload size of %d bytes deduplicated to %d bytes (%.1f%% reduction)", standardSize, deduplicatedSize, 100*(1-float64(deduplicatedSize)/float64(standardSize)))
+	assert.Less(t, deduplicatedSize, standardSize/2)
+
+	decoded, err := BlockDecoder(deduplicated)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(block, decoded.(*pbcodec.Block)), "decoded block differs from original block")
+
+	// Encoding works on a copy of the block
+	assert.True(t, proto.Equal(spamBlock(t, 200), block))
+}
+
+func TestDeduplicatedBlockFromProto_DeepMindLog(t *testing.T) {
+	cr := testFileConsoleReader(t, "testdata/deep-mind.dmlog")
+
+	var blockCount, standardSize, deduplicatedSize int
+	for {
+		out, err := cr.Read()
+		if out != nil && out.(*pbcodec.Block) != nil {
+			block := out.(*pbcodec.Block)
+			blockCount++
+
+			standard, err := BlockFromProto(block)
+			require.NoError(t, err)
+
+			deduplicated, err := DeduplicatedBlockFromProto(block)
+			require.NoError(t, err)
+
+			standardSize += len(standard.PayloadBuffer)
+			deduplicatedSize += len(deduplicated.PayloadBuffer)
+
+			decoded, err := BlockDecoder(deduplicated)
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(block, decoded.(*pbcodec.Block)), "decoded block #%d differs from original block", block.Number)
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+	}
+
+	t.Logf("payload size of %d blocks of %d bytes (version 1) deduplicated to %d bytes (version 2), %.1f%% reduction", blockCount, standardSize, deduplicatedSize, 100*(1-float64(deduplicatedSize)/float64(standardSize)))
+	assert.NotZero(t, blockCount)
+	assert.Less(t, deduplicatedSize, standardSize)
+}
+
+func TestDeduplicatedBlockFromProto_Fallback(t *testing.T) {
+	block := spamBlock(t, 2)
+	block.UnfilteredTransactionTraces[0].DbOps[0].Scope = "\x00abc"
+
+	blk, err := DeduplicatedBlockFromProto(block)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), blk.PayloadVersion)
+
+	decoded, err := BlockDecoder(blk)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(block, decoded.(*pbcodec.Block)), "decoded block differs from original block")
+}
+
+func TestBlockDecoder_DeduplicatedPayload_Invalid(t *testing.T) {
+	blk, err := DeduplicatedBlockFromProto(spamBlock(t, 2))
+	require.NoError(t, err)
+
+	blk.PayloadBuffer = blk.PayloadBuffer[0:10]
+	_, err = BlockDecoder(blk)
+	assert.Error(t, err)
+
+	blk.PayloadVersion = 3
+	_, err = BlockDecoder(blk)
+	assert.EqualError(t, err, "this decoder only knows about version 1 and 2, got 3")
+}
+
+func TestDeduplicateActions_Refs(t *testing.T) {
+	block := spamBlock(t, 2)
+	block.UnfilteredTransactionTraces[1].ActionTraces[0].Action.RawData = []byte{0xff}
+
+	actions, refs, err := deduplicateActions(block)
+	require.NoError(t, err)
+
+	// The first transaction action is notified twice and shared with the notifications of the
+	// second transaction, which input action differs.
+	require.Len(t, actions, 1)
+	assert.Equal(t, []uint64{1, 1, 1, 0, 1, 1}, refs)
+	assert.Nil(t, block.UnfilteredTransactionTraces[0].ActionTraces[0].Action)
+	assert.NotNil(t, block.UnfilteredTransactionTraces[1].ActionTraces[0].Action)
+}
+
+// spamBlock returns a block made of `trxCount` identical token transfers, each notifying the
+// sender and the receiver, like spam-heavy chains produce.
+func spamBlock(t *testing.T, trxCount int) *pbcodec.Block {
+	blockTime, err := ptypes.TimestampProto(time.Date(2020, 7, 14, 13, 49, 4, 500000000, time.UTC))
+	require.NoError(t, err)
+
+	blockID := "0000000c" + "aa1122334455667788990011223344556677889900112233445566778899"
+	block := &pbcodec.Block{
+		Id:      blockID,
+		Number:  12,
+		Version: 1,
+		Header: &pbcodec.BlockHeader{
+			Timestamp: blockTime,
+			Producer:  "eosio",
+			Previous:  "0000000b" + "aa1122334455667788990011223344556677889900112233445566778899",
+		},
+	}
+
+	for i := 0; i < trxCount; i++ {
+		trxID := fmt.Sprintf("%064x", i)
+		trace := &pbcodec.TransactionTrace{
+			Id:              trxID,
+			BlockNum:        12,
+			BlockTime:       blockTime,
+			ProducerBlockId: blockID,
+			Receipt:         &pbcodec.TransactionReceiptHeader{Status: pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED},
+			DbOps: []*pbcodec.DBOp{
+				{Operation: pbcodec.DBOp_OPERATION_UPDATE, Code: "eosio.token", Scope: "spammer1", TableName: "accounts", PrimaryKey: "EOS", OldPayer: "spammer1", NewPayer: "spammer1", OldData: []byte{0x01}, NewData: []byte{0x02}},
+				{Operation: pbcodec.DBOp_OPERATION_UPDATE, Code: "eosio.token", Scope: "victim1", TableName: "accounts", PrimaryKey: "EOS", OldPayer: "victim1", NewPayer: "victim1", OldData: []byte{0x01}, NewData: []byte{0x02}},
+			},
+		}
+
+		for j, receiver := range []string{"eosio.token", "spammer1", "victim1"} {
+			ordinal := uint32(j + 1)
+			creatorOrdinal := uint32(0)
+			if j > 0 {
+				creatorOrdinal = 1
+			}
+
+			trace.ActionTraces = append(trace.ActionTraces, &pbcodec.ActionTrace{
+				Receiver: receiver,
+				Receipt: &pbcodec.ActionReceipt{
+					Receiver:       receiver,
+					Digest:         "5f2d2d7c0be1b2ddbd5d2af0e4ab5f8d5fd5a4e1f1d50a3e8a22c5f9ba3c8c71",
+					GlobalSequence: uint64(1000 + 3*i + j),
+					AuthSequence:   []*pbcodec.AuthSequence{{AccountName: "spammer1", Sequence: uint64(100 + i)}},
+					RecvSequence:   uint64(10 + i),
+				},
+				Action: &pbcodec.Action{
+					Account:       "eosio.token",
+					Name:          "transfer",
+					Authorization: []*pbcodec.PermissionLevel{{Actor: "spammer1", Permission: "active"}},
+					JsonData:      `{"from":"spammer1","to":"victim1","quantity":"0.0001 EOS","memo":"Visit our website to claim your free tokens now, limited offer!"}`,
+					RawData:       []byte("\x10\x42\x08\x21\x03\xa5\x5c\xc6\x00\x00\x00\x80\x8d\x4c\xe5\x5c\x01\x00\x00\x00\x00\x00\x00\x00\x04\x45\x4f\x53\x00\x00\x00\x00\x3eVisit our website to claim your free tokens now, limited offer!"),
+				},
+				TransactionId:        trxID,
+				BlockNum:             12,
+				ProducerBlockId:      blockID,
+				BlockTime:            blockTime,
+				ActionOrdinal:        ordinal,
+				CreatorActionOrdinal: creatorOrdinal,
+				ExecutionIndex:       uint32(j),
+			})
+		}
+
+		block.UnfilteredTransactionTraces = append(block.UnfilteredTransactionTraces, trace)
+	}
+
+	return block
+}
@@ -23,6 +23,7 @@ import (
 	"io"
 	"strconv"
 	"strings"
+	"sync"
 
 	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
 	"github.com/eoscanada/eos-go"
@@ -30,8 +31,6 @@ import (
 	"go.uber.org/zap"
 )
 
-var supportedVersions = []string{"12", "13"}
-
 // ConsoleReader is what reads the `nodeos` output directly. It builds
 // up some LogEntry objects. See `LogReader to read those entries .
 type ConsoleReader struct {
@@ -41,57 +40,142 @@ type ConsoleReader struct {
 	readBuffer chan string
 	done       chan interface{}
 
+	// stop is closed by `Close` to release the goroutines reading and decoding lines, they would
+	// otherwise block forever once `Read` is not called anymore
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	// scanErr is the error that stopped the line scanner, set before `done` is closed
+	scanErr error
+
+	decodingWorkers int
+	units           chan *lineUnit
+	activeUnit      *lineUnit
+	activeUnitIndex int
+
+	forkTracker *forkTracker
+	queued      interface{}
+
 	ctx *parseCtx
 }
 
+type ConsoleReaderOption func(l *ConsoleReader)
+
+// WithParallelDecoding makes the reader decode the hex and binary payloads of deep-mind lines,
+// like the ones of `APPLIED_TRANSACTION` and `DB_OP`, using that many concurrent workers. The
+// decoded lines are still processed one at a time, in order, so the produced blocks are the
+// same as the ones of the sequential reader, used when workers is lower than 2.
+func WithParallelDecoding(workers int) ConsoleReaderOption {
+	return func(l *ConsoleReader) {
+		l.decodingWorkers = workers
+	}
+}
+
+// WithStrictLineHandling makes the reader return an error for each deep-mind line unknown to
+// the deep mind version in use instead of only logging it.
+func WithStrictLineHandling() ConsoleReaderOption {
+	return func(l *ConsoleReader) {
+		l.ctx.strictLineHandling = true
+	}
+}
+
+// WithNodeosBuildInfo sets the build information of the instrumented nodeos, recorded on
+// each block read.
+func WithNodeosBuildInfo(buildInfo string) ConsoleReaderOption {
+	return func(l *ConsoleReader) {
+		l.ctx.nodeosBuildInfo = buildInfo
+	}
+}
+
+// WithForkAwareness makes the reader track the blocks it emitted that are not irreversible
+// yet and return a `*ForkSwitch`, listing the blocks undone, before the first block of a new
+// fork. Consumers reading deep-mind output directly, without a relayer or a forkable, can
+// then handle reorgs.
+func WithForkAwareness() ConsoleReaderOption {
+	return func(l *ConsoleReader) {
+		l.forkTracker = &forkTracker{}
+	}
+}
+
 // TODO: At some point, the interface of a ConsoleReader should be re-done.
 //       Indeed, the `ConsoleReader` could simply receive each line already split
 //       since the upstream caller is already doing this job it self. This way, we
 //       would have a single split job instead of two. Only the upstream would split
 //       the line and the console reader would simply process each line, one at a time.
-func NewConsoleReader(reader io.Reader) (*ConsoleReader, error) {
+func NewConsoleReader(reader io.Reader, opts ...ConsoleReaderOption) (*ConsoleReader, error) {
 	l := &ConsoleReader{
 		src:   reader,
 		close: func() {},
 		ctx:   newParseCtx(),
 		done:  make(chan interface{}),
+		stop:  make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(l)
 	}
-	l.setupScanner()
+
+	if l.decodingWorkers > 1 {
+		l.setupPipeline()
+	} else {
+		l.setupScanner()
+	}
+
 	return l, nil
 }
 
-func (l *ConsoleReader) setupScanner() {
+func newLineScanner(reader io.Reader) *bufio.Scanner {
 	buf := make([]byte, 50*1024*1024)
-	scanner := bufio.NewScanner(l.src)
+	scanner := bufio.NewScanner(reader)
 	scanner.Buffer(buf, len(buf))
-	l.scanner = scanner
+
+	return scanner
+}
+
+func (l *ConsoleReader) setupScanner() {
+	l.scanner = newLineScanner(l.src)
 	l.readBuffer = make(chan string, 10)
 
 	go func() {
+		defer func() {
+			l.recordScanError()
+			close(l.readBuffer)
+			close(l.done)
+		}()
+
 		for l.scanner.Scan() {
 			line := l.scanner.Text()
 			if !strings.HasPrefix(line, "DMLOG ") {
 				continue
 			}
-			l.readBuffer <- line
-		}
 
-		err := l.scanner.Err()
-		if err != nil && err != io.EOF {
-			zlog.Error("console read line scanner encountered an error", zap.Error(err))
+			select {
+			case l.readBuffer <- line:
+			case <-l.stop:
+				return
+			}
 		}
-
-		close(l.readBuffer)
-		close(l.done)
 	}()
+}
 
+// recordScanError keeps the error of the line scanner, if any, so that `Read` returns it once
+// all the lines read before it were processed. It must be called before closing the channel
+// `Read` consumes.
+func (l *ConsoleReader) recordScanError() {
+	if err := l.scanner.Err(); err != nil {
+		zlog.Error("console read line scanner encountered an error", zap.Error(err))
+		l.scanErr = err
+	}
 }
 
 func (l *ConsoleReader) Done() <-chan interface{} {
 	return l.done
 }
 
+// Close stops reading and decoding lines, `Read` returning `io.EOF` once the lines already
+// read were processed.
 func (l *ConsoleReader) Close() {
+	l.stopOnce.Do(func() { close(l.stop) })
 	l.close()
 }
 
@@ -102,6 +186,15 @@ type parseCtx struct {
 
 	trx         *pbcodec.TransactionTrace
 	creationOps []*creationOp
+
+	version              *deepMindVersion
+	deepMindMinorVersion uint32
+	deepMindReported     bool
+	strictLineHandling   bool
+	nodeosBuildInfo      string
+
+	// switchFork is the last `SWITCH_FORK` seen, consumed by the fork tracker
+	switchFork *switchFork
 }
 
 func newParseCtx() *parseCtx {
@@ -109,11 +202,24 @@ func newParseCtx() *parseCtx {
 		abiDecoder: newABIDecoder(),
 		block:      &pbcodec.Block{},
 		trx:        &pbcodec.TransactionTrace{},
+		version:    latestDeepMindVersion,
 	}
 }
 
+// Read returns the next `*pbcodec.Block` read and, when fork awareness is enabled, a
+// `*ForkSwitch` before the first block of each new fork.
 func (l *ConsoleReader) Read() (out interface{}, err error) {
-	ctx := l.ctx
+	if l.forkTracker != nil {
+		return l.readForkAware()
+	}
+
+	return l.read()
+}
+
+func (l *ConsoleReader) read() (out interface{}, err error) {
+	if l.units != nil {
+		return l.readDecodedUnits()
+	}
 
 	for line := range l.readBuffer {
 		line = line[6:]
@@ -122,98 +228,45 @@ func (l *ConsoleReader) Read() (out interface{}, err error) {
 			zlog.Debug("extracing deep mind data from line", zap.String("line", line))
 		}
 
-		// Order of conditions is based (approximately) on those that will appear more often
-		switch {
-		case strings.HasPrefix(line, "RAM_OP"):
-			err = ctx.readRAMOp(line)
-
-		case strings.HasPrefix(line, "CREATION_OP"):
-			err = ctx.readCreationOp(line)
-
-		case strings.HasPrefix(line, "DB_OP"):
-			err = ctx.readDBOp(line)
-
-		case strings.HasPrefix(line, "RLIMIT_OP"):
-			err = ctx.readRlimitOp(line)
-
-		case strings.HasPrefix(line, "TRX_OP"):
-			err = ctx.readTrxOp(line)
-
-		case strings.HasPrefix(line, "APPLIED_TRANSACTION"):
-			err = ctx.readAppliedTransaction(line)
-
-		case strings.HasPrefix(line, "TBL_OP"):
-			err = ctx.readTableOp(line)
-
-		case strings.HasPrefix(line, "PERM_OP"):
-			err = ctx.readPermOp(line)
-
-		case strings.HasPrefix(line, "DTRX_OP CREATE"):
-			err = ctx.readCreateOrCancelDTrxOp("CREATE", line)
-
-		case strings.HasPrefix(line, "DTRX_OP MODIFY_CREATE"):
-			err = ctx.readCreateOrCancelDTrxOp("MODIFY_CREATE", line)
-
-		case strings.HasPrefix(line, "DTRX_OP MODIFY_CANCEL"):
-			err = ctx.readCreateOrCancelDTrxOp("MODIFY_CANCEL", line)
-
-		case strings.HasPrefix(line, "RAM_CORRECTION_OP"):
-			err = ctx.readRAMCorrectionOp(line)
-
-		case strings.HasPrefix(line, "DTRX_OP PUSH_CREATE"):
-			err = ctx.readCreateOrCancelDTrxOp("PUSH_CREATE", line)
-
-		case strings.HasPrefix(line, "DTRX_OP CANCEL"):
-			err = ctx.readCreateOrCancelDTrxOp("CANCEL", line)
-
-		case strings.HasPrefix(line, "DTRX_OP FAILED"):
-			err = ctx.readFailedDTrxOp(line)
+		handler, decoded, err := l.ctx.version.decodeLine(line)
+		if err != nil {
+			return nil, l.formatError(line, err)
+		}
 
-		case strings.HasPrefix(line, "ACCEPTED_BLOCK"):
-			block, err := ctx.readAcceptedBlock(line)
-			if err != nil {
-				return nil, l.formatError(line, err)
-			}
+		block, err := l.ctx.processLine(line, handler, decoded)
+		if err != nil {
+			return nil, l.formatError(line, err)
+		}
 
+		if block != nil {
 			return block, nil
+		}
+	}
 
-		case strings.HasPrefix(line, "START_BLOCK"):
-			err = ctx.readStartBlock(line)
-
-		case strings.HasPrefix(line, "FEATURE_OP ACTIVATE"):
-			err = ctx.readFeatureOpActivate(line)
-
-		case strings.HasPrefix(line, "FEATURE_OP PRE_ACTIVATE"):
-			err = ctx.readFeatureOpPreActivate(line)
-
-		case strings.HasPrefix(line, "SWITCH_FORK"):
-			zlog.Info("fork signal, restarting state accumulation from beginning")
-			ctx.resetBlock()
-
-		case strings.HasPrefix(line, "ABIDUMP START"):
-			err = ctx.readABIStart(line)
-		case strings.HasPrefix(line, "ABIDUMP ABI"):
-			err = ctx.readABIDump(line)
-		case strings.HasPrefix(line, "ABIDUMP END"):
-			//noop
+	return l.endOfInput()
+}
 
-		case strings.HasPrefix(line, "DEEP_MIND_VERSION"):
-			err = ctx.readDeepmindVersion(line)
+func (l *ConsoleReader) endOfInput() (interface{}, error) {
+	if l.scanErr != nil {
+		return nil, l.scanErr
+	}
 
-		default:
-			zlog.Info("unknown log line", zap.String("line", line))
-		}
+	return nil, io.EOF
+}
 
-		if err != nil {
-			return nil, l.formatError(line, err)
+// processLine applies the line to the parse context using its handler, found and decoded by
+// `deepMindVersion.decodeLine`. It returns the block when the line completes one.
+func (ctx *parseCtx) processLine(line string, handler *lineHandler, decoded interface{}) (*pbcodec.Block, error) {
+	if handler == nil {
+		if ctx.strictLineHandling {
+			return nil, fmt.Errorf("unknown line for deep mind version %d", ctx.version.major)
 		}
-	}
 
-	if l.scanner.Err() == nil {
-		return nil, io.EOF
+		zlog.Info("unknown log line", zap.String("line", line))
+		return nil, nil
 	}
 
-	return nil, l.scanner.Err()
+	return handler.apply(ctx, line, decoded)
 }
 
 func (l *ConsoleReader) formatError(line string, err error) error {
@@ -410,9 +463,14 @@ func (ctx *parseCtx) readStartBlock(line string) error {
 	return nil
 }
 
+type acceptedBlock struct {
+	blockNum   int64
+	blockState *eos.BlockState
+}
+
 // Line format:
 //   ACCEPTED_BLOCK ${block_num} ${block_state_hex}
-func (ctx *parseCtx) readAcceptedBlock(line string) (*pbcodec.Block, error) {
+func decodeAcceptedBlock(line string) (*acceptedBlock, error) {
 	chunks := strings.SplitN(line, " ", 3)
 	if len(chunks) != 3 {
 		return nil, fmt.Errorf("expected 3 fields, got %d", len(chunks))
@@ -423,10 +481,6 @@ func (ctx *parseCtx) readAcceptedBlock(line string) (*pbcodec.Block, error) {
 		return nil, fmt.Errorf("block_num not a valid string, got: %q", chunks[1])
 	}
 
-	if ctx.activeBlockNum != blockNum {
-		return nil, fmt.Errorf("block_num %d doesn't match the active block num (%d)", blockNum, ctx.activeBlockNum)
-	}
-
 	blockStateHex, err := hex.DecodeString(chunks[2])
 	if err != nil {
 		return nil, fmt.Errorf("unable to decode block %d state hex: %w", blockNum, err)
@@ -438,6 +492,15 @@ func (ctx *parseCtx) readAcceptedBlock(line string) (*pbcodec.Block, error) {
 		return nil, fmt.Errorf("unmarshalling binary block state: %w", err)
 	}
 
+	return &acceptedBlock{blockNum: blockNum, blockState: blockState}, nil
+}
+
+func (ctx *parseCtx) applyAcceptedBlock(accepted *acceptedBlock) (*pbcodec.Block, error) {
+	if ctx.activeBlockNum != accepted.blockNum {
+		return nil, fmt.Errorf("block_num %d doesn't match the active block num (%d)", accepted.blockNum, ctx.activeBlockNum)
+	}
+
+	blockState := accepted.blockState
 	signedBlock := blockState.SignedBlock
 
 	ctx.block.Id = blockState.BlockID.String()
@@ -455,6 +518,11 @@ func (ctx *parseCtx) readAcceptedBlock(line string) (*pbcodec.Block, error) {
 	ctx.block.ProducerToLastImpliedIrb = ProducerToLastImpliedIrbToDEOS(blockState.ProducerToLastImpliedIRB)
 	ctx.block.ActivatedProtocolFeatures = ActivatedProtocolFeaturesToDEOS(blockState.ActivatedProtocolFeatures)
 	ctx.block.ProducerSignature = signedBlock.ProducerSignature.String()
+	ctx.block.NodeosBuildInfo = ctx.nodeosBuildInfo
+	if ctx.deepMindReported {
+		ctx.block.DeepMindMajorVersion = ctx.version.major
+		ctx.block.DeepMindMinorVersion = ctx.deepMindMinorVersion
+	}
 
 	ctx.block.ConfirmCount = make([]uint32, len(blockState.ConfirmCount))
 	for i, count := range blockState.ConfirmCount {
@@ -517,7 +585,7 @@ func (ctx *parseCtx) readAcceptedBlock(line string) (*pbcodec.Block, error) {
 	block := ctx.block
 
 	zlog.Debug("blocking until abi decoder has decoded every transaction pushed to it")
-	err = ctx.abiDecoder.endBlock(ctx.block)
+	err := ctx.abiDecoder.endBlock(ctx.block)
 	if err != nil {
 		return nil, fmt.Errorf("abi decoding post-process failed: %w", err)
 	}
@@ -527,35 +595,44 @@ func (ctx *parseCtx) readAcceptedBlock(line string) (*pbcodec.Block, error) {
 	return block, nil
 }
 
+type appliedTransaction struct {
+	blockNum int64
+	trace    *pbcodec.TransactionTrace
+}
+
 // Line format:
 //   APPLIED_TRANSACTION ${block_num} ${trace_hex}
-func (ctx *parseCtx) readAppliedTransaction(line string) error {
+func decodeAppliedTransaction(line string) (*appliedTransaction, error) {
 	chunks := strings.SplitN(line, " ", 3)
 	if len(chunks) != 3 {
-		return fmt.Errorf("expected 3 fields, got %d", len(chunks))
+		return nil, fmt.Errorf("expected 3 fields, got %d", len(chunks))
 	}
 
 	blockNum, err := strconv.ParseInt(chunks[1], 10, 64)
 	if err != nil {
-		return fmt.Errorf("block_num not a valid number, got: %q", chunks[1])
-	}
-
-	if ctx.activeBlockNum != blockNum {
-		return fmt.Errorf("saw transactions from block %d while active block is %d", blockNum, ctx.activeBlockNum)
+		return nil, fmt.Errorf("block_num not a valid number, got: %q", chunks[1])
 	}
 
 	trxTraceHex, err := hex.DecodeString(chunks[2])
 	if err != nil {
-		return fmt.Errorf("unable to decode transaction trace hex at block num %d: %w", blockNum, err)
+		return nil, fmt.Errorf("unable to decode transaction trace hex at block num %d: %w", blockNum, err)
 	}
 
 	trxTrace := &eos.TransactionTrace{}
 	err = unmarshalBinary(trxTraceHex, trxTrace)
 	if err != nil {
-		return fmt.Errorf("unmarshalling binary transaction trace: %w", err)
+		return nil, fmt.Errorf("unmarshalling binary transaction trace: %w", err)
 	}
 
-	return ctx.recordTransaction(TransactionTraceToDEOS(trxTrace))
+	return &appliedTransaction{blockNum: blockNum, trace: TransactionTraceToDEOS(trxTrace)}, nil
+}
+
+func (ctx *parseCtx) applyTransaction(applied *appliedTransaction) error {
+	if ctx.activeBlockNum != applied.blockNum {
+		return fmt.Errorf("saw transactions from block %d while active block is %d", applied.blockNum, ctx.activeBlockNum)
+	}
+
+	return ctx.recordTransaction(applied.trace)
 }
 
 // Line formats:
@@ -595,15 +672,15 @@ func (ctx *parseCtx) readCreationOp(line string) error {
 //   DB_OP INS ${action_id} ${payer} ${table_code} ${scope} ${table_name} ${primkey} ${ndata}
 //   DB_OP UPD ${action_id} ${opayer}:${npayer} ${table_code} ${scope} ${table_name} ${primkey} ${odata}:${ndata}
 //   DB_OP REM ${action_id} ${payer} ${table_code} ${scope} ${table_name} ${primkey} ${odata}
-func (ctx *parseCtx) readDBOp(line string) error {
+func decodeDBOp(line string) (*pbcodec.DBOp, error) {
 	chunks := strings.SplitN(line, " ", 9)
 	if len(chunks) != 9 {
-		return fmt.Errorf("expected 9 fields, got %d", len(chunks))
+		return nil, fmt.Errorf("expected 9 fields, got %d", len(chunks))
 	}
 
 	actionIndex, err := strconv.Atoi(chunks[2])
 	if err != nil {
-		return fmt.Errorf("action_index is not a valid number, got: %q", chunks[2])
+		return nil, fmt.Errorf("action_index is not a valid number, got: %q", chunks[2])
 	}
 
 	opString := chunks[1]
@@ -621,7 +698,7 @@ func (ctx *parseCtx) readDBOp(line string) error {
 
 		dataChunks := strings.SplitN(chunks[8], ":", 2)
 		if len(dataChunks) != 2 {
-			return fmt.Errorf("should have old and new data in field 8, found only one")
+			return nil, fmt.Errorf("should have old and new data in field 8, found only one")
 		}
 
 		oldData = dataChunks[0]
@@ -629,7 +706,7 @@ func (ctx *parseCtx) readDBOp(line string) error {
 
 		payerChunks := strings.SplitN(chunks[3], ":", 2)
 		if len(payerChunks) != 2 {
-			return fmt.Errorf("should have two payers in field 3, separated by a ':', found only one")
+			return nil, fmt.Errorf("should have two payers in field 3, separated by a ':', found only one")
 		}
 
 		oldPayer = payerChunks[0]
@@ -639,25 +716,25 @@ func (ctx *parseCtx) readDBOp(line string) error {
 		oldData = chunks[8]
 		oldPayer = chunks[3]
 	default:
-		return fmt.Errorf("unknown operation: %q", opString)
+		return nil, fmt.Errorf("unknown operation: %q", opString)
 	}
 
 	var oldBytes, newBytes []byte
 	if len(oldData) != 0 {
 		oldBytes, err = hex.DecodeString(oldData)
 		if err != nil {
-			return fmt.Errorf("couldn't decode old_data: %s", err)
+			return nil, fmt.Errorf("couldn't decode old_data: %s", err)
 		}
 	}
 
 	if len(newData) != 0 {
 		newBytes, err = hex.DecodeString(newData)
 		if err != nil {
-			return fmt.Errorf("couldn't decode new_data: %s", err)
+			return nil, fmt.Errorf("couldn't decode new_data: %s", err)
 		}
 	}
 
-	ctx.recordDBOp(&pbcodec.DBOp{
+	return &pbcodec.DBOp{
 		Operation:   op,
 		ActionIndex: uint32(actionIndex),
 		OldPayer:    oldPayer,
@@ -668,9 +745,7 @@ func (ctx *parseCtx) readDBOp(line string) error {
 		PrimaryKey:  chunks[7],
 		OldData:     oldBytes,
 		NewData:     newBytes,
-	})
-
-	return nil
+	}, nil
 }
 
 // Line formats:
@@ -679,28 +754,28 @@ func (ctx *parseCtx) readDBOp(line string) error {
 //   DTRX_OP CREATE        ${action_id} ${sender} ${sender_id} ${payer} ${published} ${delay} ${expiration} ${trx_id} ${trx}
 //   DTRX_OP CANCEL        ${action_id} ${sender} ${sender_id} ${payer} ${published} ${delay} ${expiration} ${trx_id} ${trx}
 //   DTRX_OP PUSH_CREATE   ${action_id} ${sender} ${sender_id} ${payer} ${published} ${delay} ${expiration} ${trx_id} ${trx}
-func (ctx *parseCtx) readCreateOrCancelDTrxOp(tag string, line string) error {
+func decodeCreateOrCancelDTrxOp(line string) (*pbcodec.DTrxOp, error) {
 	chunks := strings.SplitN(line, " ", 11)
 	if len(chunks) != 11 {
-		return fmt.Errorf("expected 11 fields, got %d", len(chunks))
+		return nil, fmt.Errorf("expected 11 fields, got %d", len(chunks))
 	}
 
 	opString := chunks[1]
 	rawOp, ok := pbcodec.DTrxOp_Operation_value["OPERATION_"+opString]
 	if !ok {
-		return fmt.Errorf("operation %q unknown", opString)
+		return nil, fmt.Errorf("operation %q unknown", opString)
 	}
 
 	op := pbcodec.DTrxOp_Operation(rawOp)
 
 	actionIndex, err := strconv.Atoi(chunks[2])
 	if err != nil {
-		return fmt.Errorf("action_index is not a valid number, got: %q", chunks[2])
+		return nil, fmt.Errorf("action_index is not a valid number, got: %q", chunks[2])
 	}
 
 	trxHex, err := hex.DecodeString(chunks[10])
 	if err != nil {
-		return fmt.Errorf("unable to decode signed transaction hex: %w", err)
+		return nil, fmt.Errorf("unable to decode signed transaction hex: %w", err)
 	}
 
 	var signedTrx *eos.SignedTransaction
@@ -708,13 +783,13 @@ func (ctx *parseCtx) readCreateOrCancelDTrxOp(tag string, line string) error {
 		signedTrx = new(eos.SignedTransaction)
 		err = unmarshalBinary(trxHex, signedTrx)
 		if err != nil {
-			return fmt.Errorf("unmarshal binary signed transaction: %w", err)
+			return nil, fmt.Errorf("unmarshal binary signed transaction: %w", err)
 		}
 	} else {
 		trx := &eos.Transaction{}
 		err = unmarshalBinary(trxHex, trx)
 		if err != nil {
-			return fmt.Errorf("unmarshal binary transaction: %w", err)
+			return nil, fmt.Errorf("unmarshal binary transaction: %w", err)
 		}
 
 		signedTrx = &eos.SignedTransaction{
@@ -722,7 +797,7 @@ func (ctx *parseCtx) readCreateOrCancelDTrxOp(tag string, line string) error {
 		}
 	}
 
-	ctx.recordDTrxOp(&pbcodec.DTrxOp{
+	return &pbcodec.DTrxOp{
 		Operation:     op,
 		ActionIndex:   uint32(actionIndex),
 		Sender:        chunks[3],
@@ -733,9 +808,7 @@ func (ctx *parseCtx) readCreateOrCancelDTrxOp(tag string, line string) error {
 		ExpirationAt:  chunks[8],
 		TransactionId: chunks[9],
 		Transaction:   SignedTransactionToDEOS(signedTrx),
-	})
-
-	return nil
+	}, nil
 }
 
 // Line format:
@@ -814,15 +887,15 @@ func (ctx *parseCtx) readFeatureOpPreActivate(line string) error {
 //   PERM_OP INS ${action_id} [${permission_id}] ${data}
 //   PERM_OP UPD ${action_id} [${permission_id}] ${data}
 //   PERM_OP REM ${action_id} [${permission_id}] ${data} <-- {"old": <old>, "new": <new>}
-func (ctx *parseCtx) readPermOp(line string) error {
+func decodePermOp(line string) (*pbcodec.PermOp, error) {
 	chunks, err := splitNToM(line, 4, 5)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	actionIndex, err := strconv.Atoi(chunks[2])
 	if err != nil {
-		return fmt.Errorf("action_index is not a valid number, got: %q", chunks[2])
+		return nil, fmt.Errorf("action_index is not a valid number, got: %q", chunks[2])
 	}
 
 	opString := chunks[1]
@@ -833,7 +906,7 @@ func (ctx *parseCtx) readPermOp(line string) error {
 	if len(chunks) == 5 {
 		permissionID, err = strconv.ParseUint(chunks[3], 10, 64)
 		if err != nil {
-			return fmt.Errorf("permission_id is not a valid number, got: %q", chunks[3])
+			return nil, fmt.Errorf("permission_id is not a valid number, got: %q", chunks[3])
 		}
 		dataChunk = chunks[4]
 	}
@@ -851,12 +924,12 @@ func (ctx *parseCtx) readPermOp(line string) error {
 
 		oldJSONResult := gjson.Get(dataChunk, "old")
 		if !oldJSONResult.Exists() {
-			return fmt.Errorf("a PERM_OP UPD should JSON data should have an 'old' field, found none in: %q", dataChunk)
+			return nil, fmt.Errorf("a PERM_OP UPD should JSON data should have an 'old' field, found none in: %q", dataChunk)
 		}
 
 		newJSONResult := gjson.Get(dataChunk, "new")
 		if !newJSONResult.Exists() {
-			return fmt.Errorf("a PERM_OP UPD should JSON data should have an 'new' field, found none in: %q", dataChunk)
+			return nil, fmt.Errorf("a PERM_OP UPD should JSON data should have an 'new' field, found none in: %q", dataChunk)
 		}
 
 		oldData = []byte(oldJSONResult.Raw)
@@ -868,7 +941,7 @@ func (ctx *parseCtx) readPermOp(line string) error {
 		oldData = []byte(dataChunk)
 
 	default:
-		return fmt.Errorf("unknown PERM_OP op: %q", opString)
+		return nil, fmt.Errorf("unknown PERM_OP op: %q", opString)
 	}
 
 	permOp := &pbcodec.PermOp{
@@ -880,7 +953,7 @@ func (ctx *parseCtx) readPermOp(line string) error {
 		newPerm := &permissionObject{}
 		err = json.Unmarshal(newData, &newPerm)
 		if err != nil {
-			return fmt.Errorf("unmashal new perm data: %s", err)
+			return nil, fmt.Errorf("unmashal new perm data: %s", err)
 		}
 
 		permOp.NewPerm = newPerm.ToProto()
@@ -891,7 +964,7 @@ func (ctx *parseCtx) readPermOp(line string) error {
 		oldPerm := &permissionObject{}
 		err = json.Unmarshal(oldData, &oldPerm)
 		if err != nil {
-			return fmt.Errorf("unmashal old perm data: %s", err)
+			return nil, fmt.Errorf("unmashal old perm data: %s", err)
 		}
 
 		permOp.OldPerm = oldPerm.ToProto()
@@ -899,8 +972,16 @@ func (ctx *parseCtx) readPermOp(line string) error {
 
 	}
 
-	ctx.recordPermOp(permOp)
+	return permOp, nil
+}
 
+func (ctx *parseCtx) readPermOp(line string) error {
+	op, err := decodePermOp(line)
+	if err != nil {
+		return err
+	}
+
+	ctx.recordPermOp(op)
 	return nil
 }
 
@@ -965,29 +1046,71 @@ func (ctx *parseCtx) readRAMOp(line string) error {
 //  Version 13
 //    DEEP_MIND_VERSION ${major_version} ${minor_version}
 func (ctx *parseCtx) readDeepmindVersion(line string) error {
-	chunks, err := splitNToM(line, 2, 3)
+	major, minor, err := parseDeepMindVersion(line)
 	if err != nil {
 		return err
 	}
 
-	majorVersion := chunks[1]
-	if !inSupportedVersion(majorVersion) {
-		return fmt.Errorf("deep mind reported version %s, but this reader supports only %s", majorVersion, strings.Join(supportedVersions, ", "))
+	version, found := deepMindVersions[major]
+	if !found {
+		return fmt.Errorf("deep mind reported version %d, but this reader supports only %s", major, strings.Join(supportedDeepMindVersions(), ", "))
 	}
 
-	zlog.Info("read deep mind version", zap.String("major_version", majorVersion))
+	ctx.version = version
+	ctx.deepMindMinorVersion = minor
+	ctx.deepMindReported = true
+
+	zlog.Info("read deep mind version", zap.Uint32("major_version", major), zap.Uint32("minor_version", minor))
 
 	return nil
 }
 
-func inSupportedVersion(majorVersion string) bool {
-	for _, supportedVersion := range supportedVersions {
-		if majorVersion == supportedVersion {
-			return true
+type switchFork struct {
+	fromBlockID string
+	toBlockID   string
+}
+
+// Line format:
+//   SWITCH_FORK ${from_id} ${to_id}
+func (ctx *parseCtx) readSwitchFork(line string) error {
+	chunks, err := splitNToM(line, 1, 3)
+	if err != nil {
+		return err
+	}
+
+	ctx.switchFork = &switchFork{}
+	if len(chunks) == 3 {
+		ctx.switchFork.fromBlockID = chunks[1]
+		ctx.switchFork.toBlockID = chunks[2]
+	}
+
+	zlog.Info("fork signal, restarting state accumulation from beginning", zap.String("from_block_id", ctx.switchFork.fromBlockID), zap.String("to_block_id", ctx.switchFork.toBlockID))
+	ctx.resetBlock()
+
+	return nil
+}
+
+func parseDeepMindVersion(line string) (major, minor uint32, err error) {
+	chunks, err := splitNToM(line, 2, 3)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	value, err := strconv.ParseUint(chunks[1], 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("major_version is not a valid number, got: %q", chunks[1])
+	}
+	major = uint32(value)
+
+	if len(chunks) == 3 {
+		value, err := strconv.ParseUint(chunks[2], 10, 32)
+		if err != nil {
+			return 0, 0, fmt.Errorf("minor_version is not a valid number, got: %q", chunks[2])
 		}
+		minor = uint32(value)
 	}
 
-	return false
+	return major, minor, nil
 }
 
 // Line format:
@@ -1087,10 +1210,10 @@ func (ctx *parseCtx) readRAMCorrectionOp(line string) error {
 //   RLIMIT_OP ACCOUNT_LIMITS UPD ${data}
 //   RLIMIT_OP ACCOUNT_USAGE  INS ${data}
 //   RLIMIT_OP ACCOUNT_USAGE  UPD ${data}
-func (ctx *parseCtx) readRlimitOp(line string) error {
+func decodeRlimitOp(line string) (*pbcodec.RlimitOp, error) {
 	chunks := strings.SplitN(line, " ", 4)
 	if len(chunks) != 4 {
-		return fmt.Errorf("expected 4 fields, got %d", len(chunks))
+		return nil, fmt.Errorf("expected 4 fields, got %d", len(chunks))
 	}
 
 	kindString := chunks[1]
@@ -1103,7 +1226,7 @@ func (ctx *parseCtx) readRlimitOp(line string) error {
 	case "UPD":
 		operation = pbcodec.RlimitOp_OPERATION_UPDATE
 	default:
-		return fmt.Errorf("operation %q is unknown", operationString)
+		return nil, fmt.Errorf("operation %q is unknown", operationString)
 	}
 
 	op := &pbcodec.RlimitOp{Operation: operation}
@@ -1114,7 +1237,7 @@ func (ctx *parseCtx) readRlimitOp(line string) error {
 		obj := &rlimitConfig{}
 		err := json.Unmarshal(data, &obj)
 		if err != nil {
-			return fmt.Errorf("marshaling config: %s", err)
+			return nil, fmt.Errorf("marshaling config: %s", err)
 		}
 
 		op.Kind = obj.ToProto()
@@ -1123,7 +1246,7 @@ func (ctx *parseCtx) readRlimitOp(line string) error {
 		obj := &rlimitState{}
 		err := json.Unmarshal(data, &obj)
 		if err != nil {
-			return fmt.Errorf("marshaling state: %s", err)
+			return nil, fmt.Errorf("marshaling state: %s", err)
 		}
 
 		op.Kind = obj.ToProto()
@@ -1132,7 +1255,7 @@ func (ctx *parseCtx) readRlimitOp(line string) error {
 		obj := &rlimitAccountLimits{}
 		err := json.Unmarshal(data, &obj)
 		if err != nil {
-			return fmt.Errorf("marshaling account limits: %s", err)
+			return nil, fmt.Errorf("marshaling account limits: %s", err)
 		}
 
 		op.Kind = obj.ToProto()
@@ -1141,17 +1264,25 @@ func (ctx *parseCtx) readRlimitOp(line string) error {
 		obj := &rlimitAccountUsage{}
 		err := json.Unmarshal(data, &obj)
 		if err != nil {
-			return fmt.Errorf("marshaling account usage: %s", err)
+			return nil, fmt.Errorf("marshaling account usage: %s", err)
 		}
 
 		op.Kind = obj.ToProto()
 
 	default:
-		return fmt.Errorf("unknown kind: %q", kindString)
+		return nil, fmt.Errorf("unknown kind: %q", kindString)
 	}
 
-	ctx.recordRlimitOp(op)
+	return op, nil
+}
 
+func (ctx *parseCtx) readRlimitOp(line string) error {
+	op, err := decodeRlimitOp(line)
+	if err != nil {
+		return err
+	}
+
+	ctx.recordRlimitOp(op)
 	return nil
 }
 
@@ -1194,10 +1325,10 @@ func (ctx *parseCtx) readTableOp(line string) error {
 
 // Line formats:
 //   TRX_OP CREATE onblock|onerror ${id} ${trx}
-func (ctx *parseCtx) readTrxOp(line string) error {
+func decodeTrxOp(line string) (*pbcodec.TrxOp, error) {
 	chunks := strings.SplitN(line, " ", 5)
 	if len(chunks) != 5 {
-		return fmt.Errorf("expected 5 fields, got %d", len(chunks))
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(chunks))
 	}
 
 	opString := chunks[1]
@@ -1206,7 +1337,7 @@ func (ctx *parseCtx) readTrxOp(line string) error {
 	case "CREATE":
 		op = pbcodec.TrxOp_OPERATION_CREATE
 	default:
-		return fmt.Errorf("unknown kind: %q", opString)
+		return nil, fmt.Errorf("unknown kind: %q", opString)
 	}
 
 	name := chunks[2]
@@ -1214,23 +1345,21 @@ func (ctx *parseCtx) readTrxOp(line string) error {
 
 	trxHex, err := hex.DecodeString(chunks[4])
 	if err != nil {
-		return fmt.Errorf("unable to decode signed transaction %s hex: %w", trxID, err)
+		return nil, fmt.Errorf("unable to decode signed transaction %s hex: %w", trxID, err)
 	}
 
 	trx := &eos.SignedTransaction{}
 	err = unmarshalBinary(trxHex, trx)
 	if err != nil {
-		return fmt.Errorf("unmarshal binary signed transaction %s: %w", trxID, err)
+		return nil, fmt.Errorf("unmarshal binary signed transaction %s: %w", trxID, err)
 	}
 
-	ctx.recordTrxOp(&pbcodec.TrxOp{
+	return &pbcodec.TrxOp{
 		Operation:     op,
 		Name:          name,  // "onblock" or "onerror"
 		TransactionId: trxID, // the hash of the transaction
 		Transaction:   SignedTransactionToDEOS(trx),
-	})
-
-	return nil
+	}, nil
 }
 
 func unmarshalBinary(data []byte, v interface{}) error {
@@ -0,0 +1,123 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"fmt"
+
+	"github.com/dfuse-io/bstream"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"go.uber.org/zap"
+)
+
+// ForkSwitch is returned by `ConsoleReader.Read` when fork awareness is enabled and the block
+// about to be returned does not extend the previously returned one. The blocks listed in
+// `UndoneBlocks` must be considered reverted, the blocks of the new fork following.
+type ForkSwitch struct {
+	// FromBlockID and ToBlockID are the head block ids before and after the switch as reported
+	// by nodeos `SWITCH_FORK` line, empty when nodeos did not report them.
+	FromBlockID string
+	ToBlockID   string
+
+	// ForkPoint is the last block common to the undone blocks and the new fork
+	ForkPoint bstream.BlockRef
+
+	// UndoneBlocks are the previously returned blocks that are not part of the new fork, the
+	// most recent one first.
+	UndoneBlocks []bstream.BlockRef
+}
+
+func (s *ForkSwitch) String() string {
+	return fmt.Sprintf("fork switch from %s to %s, fork point %s, %d undone block(s)", s.FromBlockID, s.ToBlockID, s.ForkPoint, len(s.UndoneBlocks))
+}
+
+// forkTracker keeps the blocks returned by the reader that are not irreversible yet, oldest
+// first, since those are the only ones that can be undone by a fork switch.
+type forkTracker struct {
+	blocks []bstream.BlockRef
+}
+
+func (l *ConsoleReader) readForkAware() (interface{}, error) {
+	if l.queued != nil {
+		out := l.queued
+		l.queued = nil
+
+		return out, nil
+	}
+
+	out, err := l.read()
+	if err != nil {
+		return nil, err
+	}
+
+	block := out.(*pbcodec.Block)
+	forkSwitch, err := l.forkTracker.track(block, l.ctx.switchFork)
+	l.ctx.switchFork = nil
+	if err != nil {
+		return nil, err
+	}
+
+	if forkSwitch != nil {
+		zlog.Info("fork switch detected", zap.Stringer("fork_switch", forkSwitch), zap.Stringer("block", block.AsRef()))
+
+		l.queued = block
+		return forkSwitch, nil
+	}
+
+	return block, nil
+}
+
+// track records the block, returning the fork switch undoing tracked blocks when the block
+// does not extend the last tracked one.
+func (t *forkTracker) track(block *pbcodec.Block, switchFork *switchFork) (forkSwitch *ForkSwitch, err error) {
+	if count := len(t.blocks); count > 0 && t.blocks[count-1].ID() != block.PreviousID() {
+		forkPointIndex := -1
+		for i := count - 1; i >= 0; i-- {
+			if t.blocks[i].ID() == block.PreviousID() {
+				forkPointIndex = i
+				break
+			}
+		}
+
+		if forkPointIndex == -1 {
+			return nil, fmt.Errorf("block %s previous block %s is not among the %d reversible blocks returned so far, unable to determine the undone blocks", block.AsRef(), block.PreviousID(), count)
+		}
+
+		forkSwitch = &ForkSwitch{ForkPoint: t.blocks[forkPointIndex]}
+		if switchFork != nil {
+			forkSwitch.FromBlockID = switchFork.fromBlockID
+			forkSwitch.ToBlockID = switchFork.toBlockID
+		}
+
+		for i := count - 1; i > forkPointIndex; i-- {
+			forkSwitch.UndoneBlocks = append(forkSwitch.UndoneBlocks, t.blocks[i])
+		}
+
+		t.blocks = t.blocks[0 : forkPointIndex+1]
+	}
+
+	t.blocks = append(t.blocks, block.AsRef())
+
+	// Blocks below the last irreversible block cannot be undone anymore, the last irreversible
+	// block itself is kept since it can be the fork point of a switch.
+	libNum := block.LIBNum()
+	firstReversible := 0
+	for firstReversible < len(t.blocks)-1 && t.blocks[firstReversible].Num() < libNum {
+		firstReversible++
+	}
+	t.blocks = t.blocks[firstReversible:]
+
+	return forkSwitch, nil
+}
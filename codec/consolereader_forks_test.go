@@ -0,0 +1,96 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dfuse-io/bstream"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkTracker(t *testing.T) {
+	tracker := &forkTracker{}
+
+	for _, block := range []*pbcodec.Block{
+		forkBlock(1, "a", "a", 0),
+		forkBlock(2, "a", "a", 1),
+		forkBlock(3, "a", "a", 1),
+		forkBlock(4, "a", "a", 1),
+	} {
+		forkSwitch, err := tracker.track(block, nil)
+		require.NoError(t, err)
+		assert.Nil(t, forkSwitch)
+	}
+
+	forkSwitch, err := tracker.track(forkBlock(3, "b", "a", 1), &switchFork{fromBlockID: forkBlockID(4, "a"), toBlockID: forkBlockID(4, "b")})
+	require.NoError(t, err)
+	assert.Equal(t, &ForkSwitch{
+		FromBlockID: forkBlockID(4, "a"),
+		ToBlockID:   forkBlockID(4, "b"),
+		ForkPoint:   bstream.NewBlockRef(forkBlockID(2, "a"), 2),
+		UndoneBlocks: []bstream.BlockRef{
+			bstream.NewBlockRef(forkBlockID(4, "a"), 4),
+			bstream.NewBlockRef(forkBlockID(3, "a"), 3),
+		},
+	}, forkSwitch)
+
+	forkSwitch, err = tracker.track(forkBlock(4, "b", "b", 1), nil)
+	require.NoError(t, err)
+	assert.Nil(t, forkSwitch)
+
+	// Fork switch detected from the previous block id only, without a `SWITCH_FORK` line
+	forkSwitch, err = tracker.track(forkBlock(4, "c", "b", 2), nil)
+	require.NoError(t, err)
+	assert.Equal(t, &ForkSwitch{
+		ForkPoint:    bstream.NewBlockRef(forkBlockID(3, "b"), 3),
+		UndoneBlocks: []bstream.BlockRef{bstream.NewBlockRef(forkBlockID(4, "b"), 4)},
+	}, forkSwitch)
+
+	// Blocks below the last irreversible block are not tracked anymore
+	_, err = tracker.track(forkBlock(5, "c", "c", 4), nil)
+	require.NoError(t, err)
+	assert.Len(t, tracker.blocks, 2)
+
+	_, err = tracker.track(forkBlock(4, "d", "b", 4), nil)
+	assert.EqualError(t, err, fmt.Sprintf("block #4 (%s) previous block %s is not among the 2 reversible blocks returned so far, unable to determine the undone blocks", forkBlockID(4, "d"), forkBlockID(3, "b")))
+}
+
+func Test_readSwitchFork(t *testing.T) {
+	ctx := newParseCtx()
+	require.NoError(t, ctx.readSwitchFork("SWITCH_FORK 00000004aa 00000004bb"))
+	assert.Equal(t, &switchFork{fromBlockID: "00000004aa", toBlockID: "00000004bb"}, ctx.switchFork)
+
+	require.NoError(t, ctx.readSwitchFork("SWITCH_FORK"))
+	assert.Equal(t, &switchFork{}, ctx.switchFork)
+
+	assert.EqualError(t, ctx.readSwitchFork("SWITCH_FORK a b c"), "expected between 1 to 3 fields (inclusively), got 4")
+}
+
+func forkBlock(num uint32, fork string, previousFork string, libNum uint32) *pbcodec.Block {
+	return &pbcodec.Block{
+		Id:                       forkBlockID(num, fork),
+		Number:                   num,
+		DposIrreversibleBlocknum: libNum,
+		Header:                   &pbcodec.BlockHeader{Previous: forkBlockID(num-1, previousFork)},
+	}
+}
+
+func forkBlockID(num uint32, fork string) string {
+	return fmt.Sprintf("%08x%s", num, fork+fork)
+}
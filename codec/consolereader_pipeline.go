@@ -0,0 +1,184 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// maxLineUnitSize bounds the number of lines of a unit so that a block with a huge amount
+// of transactions is decoded by more than one worker and is not entirely held in memory
+// before being processed.
+const maxLineUnitSize = 1000
+
+// lineUnit is a chunk of consecutive deep-mind lines, ending at the `ACCEPTED_BLOCK` line of
+// a block or when reaching `maxLineUnitSize` lines, decoded as a whole by a single worker. A
+// `DEEP_MIND_VERSION` line always starts a new unit so that all lines of a unit are decoded
+// with the handlers of the same version.
+type lineUnit struct {
+	version  *deepMindVersion
+	lines    []string
+	handlers []*lineHandler
+	decoded  []interface{}
+	errs     []error
+
+	// Closed once all lines of the unit have been decoded
+	done chan struct{}
+}
+
+func newLineUnit(version *deepMindVersion) *lineUnit {
+	return &lineUnit{version: version, done: make(chan struct{})}
+}
+
+func (u *lineUnit) decode() {
+	u.handlers = make([]*lineHandler, len(u.lines))
+	u.decoded = make([]interface{}, len(u.lines))
+	u.errs = make([]error, len(u.lines))
+
+	for i, line := range u.lines {
+		u.handlers[i], u.decoded[i], u.errs[i] = u.version.decodeLine(line)
+	}
+
+	close(u.done)
+}
+
+// setupPipeline starts the goroutine splitting the deep-mind lines in units as well as the
+// workers decoding them. Units are queued in `l.units` in the order they were read, before
+// being handed to the workers, so that `Read` can process them in order, waiting for each
+// one to be decoded. The splitter stops when the reader is closed, the workers then stopping
+// once the units already handed to them are decoded.
+func (l *ConsoleReader) setupPipeline() {
+	l.scanner = newLineScanner(l.src)
+	l.units = make(chan *lineUnit, 2*l.decodingWorkers)
+
+	jobs := make(chan *lineUnit, 2*l.decodingWorkers)
+	for i := 0; i < l.decodingWorkers; i++ {
+		go func() {
+			for unit := range jobs {
+				unit.decode()
+			}
+		}()
+	}
+
+	version := l.ctx.version
+	go func() {
+		defer func() {
+			l.recordScanError()
+			close(jobs)
+			close(l.units)
+			close(l.done)
+		}()
+
+		unit := newLineUnit(version)
+		flush := func() bool {
+			if len(unit.lines) == 0 {
+				unit.version = version
+				return true
+			}
+
+			// The unit is queued for `Read` before being decoded, `Read` also stops waiting for
+			// its decoding once the reader is closed
+			select {
+			case l.units <- unit:
+			case <-l.stop:
+				return false
+			}
+
+			select {
+			case jobs <- unit:
+			case <-l.stop:
+				return false
+			}
+
+			unit = newLineUnit(version)
+			return true
+		}
+
+		for l.scanner.Scan() {
+			line := l.scanner.Text()
+			if !strings.HasPrefix(line, "DMLOG ") {
+				continue
+			}
+
+			line = line[6:]
+			if lineVersion := versionOfLine(line); lineVersion != nil {
+				version = lineVersion
+				if !flush() {
+					return
+				}
+			}
+
+			unit.lines = append(unit.lines, line)
+
+			if strings.HasPrefix(line, "ACCEPTED_BLOCK") || len(unit.lines) >= maxLineUnitSize {
+				if !flush() {
+					return
+				}
+			}
+		}
+		flush()
+	}()
+}
+
+// readDecodedUnits is the `Read` implementation used when decoding in parallel, it processes
+// the decoded lines of each unit sequentially and in order, keeping track of its position in
+// the active unit between calls since a unit can contain more than one block.
+func (l *ConsoleReader) readDecodedUnits() (out interface{}, err error) {
+	for {
+		if l.activeUnit == nil {
+			unit, ok := <-l.units
+			if !ok {
+				return l.endOfInput()
+			}
+
+			select {
+			case <-unit.done:
+			case <-l.stop:
+				return nil, io.EOF
+			}
+
+			l.activeUnit = unit
+			l.activeUnitIndex = 0
+		}
+
+		for l.activeUnitIndex < len(l.activeUnit.lines) {
+			index := l.activeUnitIndex
+			l.activeUnitIndex++
+
+			line := l.activeUnit.lines[index]
+			if traceEnabled {
+				zlog.Debug("extracing deep mind data from line", zap.String("line", line))
+			}
+
+			if err := l.activeUnit.errs[index]; err != nil {
+				return nil, l.formatError(line, err)
+			}
+
+			block, err := l.ctx.processLine(line, l.activeUnit.handlers[index], l.activeUnit.decoded[index])
+			if err != nil {
+				return nil, l.formatError(line, err)
+			}
+
+			if block != nil {
+				return block, nil
+			}
+		}
+
+		l.activeUnit = nil
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"strings"
 	"testing"
 	"time"
 
@@ -76,14 +77,16 @@ func TestConsoleReaderPerformances(t *testing.T) {
 
 func TestParseFromFile(t *testing.T) {
 	tests := []struct {
-		deepMindFile string
+		deepMindFile    string
+		decodingWorkers int
 	}{
-		{"testdata/deep-mind.dmlog"},
+		{"testdata/deep-mind.dmlog", 0},
+		{"testdata/deep-mind.dmlog", 4},
 	}
 
 	for _, test := range tests {
-		t.Run(test.deepMindFile, func(t *testing.T) {
-			cr := testFileConsoleReader(t, test.deepMindFile)
+		t.Run(fmt.Sprintf("%s/%d workers", test.deepMindFile, test.decodingWorkers), func(t *testing.T) {
+			cr := testFileConsoleReader(t, test.deepMindFile, WithParallelDecoding(test.decodingWorkers))
 			buf := &bytes.Buffer{}
 
 			for {
@@ -167,19 +170,133 @@ func TestGeneratePBBlocks(t *testing.T) {
 	}
 }
 
-func testFileConsoleReader(t *testing.T, filename string) *ConsoleReader {
+func TestConsoleReader_ParallelDecoding(t *testing.T) {
+	content := strings.Join([]string{
+		"some nodeos log line",
+		"DMLOG START_BLOCK 5",
+		"DMLOG TRX_OP CREATE onblock abc zz",
+		`DMLOG RLIMIT_OP ACCOUNT_LIMITS INS {"owner":"eosio.ram","net_weight":-1,"cpu_weight":-1,"ram_bytes":-1}`,
+		"DMLOG APPLIED_TRANSACTION 5",
+		"DMLOG START_BLOCK 6",
+	}, "\n")
+
+	readAll := func(opts ...ConsoleReaderOption) (errs []string, ctx *parseCtx) {
+		cr := testReaderConsoleReader(t, strings.NewReader(content), func() {}, opts...)
+		for {
+			_, err := cr.Read()
+			if err == io.EOF {
+				return errs, cr.ctx
+			}
+
+			require.Error(t, err)
+			errs = append(errs, err.Error())
+		}
+	}
+
+	expectedErrs, expectedCtx := readAll()
+	require.Len(t, expectedErrs, 3)
+
+	actualErrs, actualCtx := readAll(WithParallelDecoding(4))
+	assert.Equal(t, expectedErrs, actualErrs)
+	assert.Equal(t, expectedCtx.activeBlockNum, actualCtx.activeBlockNum)
+	assert.Equal(t, int64(6), actualCtx.activeBlockNum)
+}
+
+func TestConsoleReader_ScannerError(t *testing.T) {
+	content := strings.Join([]string{
+		"DMLOG START_BLOCK 5",
+		`DMLOG RLIMIT_OP ACCOUNT_LIMITS INS {"owner":"eosio.ram","net_weight":-1,"cpu_weight":-1,"ram_bytes":-1}`,
+		"",
+	}, "\n")
+
+	for _, decodingWorkers := range []int{0, 4} {
+		t.Run(fmt.Sprintf("%d workers", decodingWorkers), func(t *testing.T) {
+			reader, writer := io.Pipe()
+			go func() {
+				writer.Write([]byte(content))
+				writer.CloseWithError(assert.AnError)
+			}()
+
+			cr := testReaderConsoleReader(t, reader, func() {}, WithParallelDecoding(decodingWorkers))
+
+			_, err := cr.Read()
+			assert.Equal(t, assert.AnError, err)
+			assert.Equal(t, int64(5), cr.ctx.activeBlockNum)
+		})
+	}
+}
+
+func TestConsoleReader_Close(t *testing.T) {
+	for _, decodingWorkers := range []int{0, 4} {
+		t.Run(fmt.Sprintf("%d workers", decodingWorkers), func(t *testing.T) {
+			cr := testFileConsoleReader(t, "testdata/deep-mind.dmlog", WithParallelDecoding(decodingWorkers))
+
+			out, err := cr.Read()
+			require.NoError(t, err)
+			require.IsType(t, &pbcodec.Block{}, out)
+
+			cr.Close()
+
+			select {
+			case <-cr.Done():
+			case <-time.After(5 * time.Second):
+				t.Fatal("line scanner still running after close")
+			}
+
+			for {
+				_, err := cr.Read()
+				if err == io.EOF {
+					break
+				}
+
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func BenchmarkConsoleReader(b *testing.B) {
+	content, err := ioutil.ReadFile("testdata/deep-mind.dmlog")
+	if err != nil {
+		b.Skipf("unable to read deep mind benchmark file: %s", err)
+		return
+	}
+
+	for _, decodingWorkers := range []int{0, 2, 4, 8} {
+		b.Run(fmt.Sprintf("%d workers", decodingWorkers), func(b *testing.B) {
+			b.SetBytes(int64(len(content)))
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				cr, err := NewConsoleReader(bytes.NewReader(content), WithParallelDecoding(decodingWorkers))
+				require.NoError(b, err)
+
+				for {
+					_, err := cr.Read()
+					if err == io.EOF {
+						break
+					}
+
+					require.NoError(b, err)
+				}
+			}
+		})
+	}
+}
+
+func testFileConsoleReader(t *testing.T, filename string, opts ...ConsoleReaderOption) *ConsoleReader {
 	t.Helper()
 
 	fl, err := os.Open(filename)
 	require.NoError(t, err)
 
-	return testReaderConsoleReader(t, fl, func() { fl.Close() })
+	return testReaderConsoleReader(t, fl, func() { fl.Close() }, opts...)
 }
 
-func testReaderConsoleReader(t *testing.T, reader io.Reader, closer func()) *ConsoleReader {
+func testReaderConsoleReader(t *testing.T, reader io.Reader, closer func(), opts ...ConsoleReaderOption) *ConsoleReader {
 	t.Helper()
 
-	consoleReader, err := NewConsoleReader(reader)
+	consoleReader, err := NewConsoleReader(reader, opts...)
 	require.NoError(t, err)
 
 	return consoleReader
@@ -534,25 +651,36 @@ func Test_readABIDump_Start(t *testing.T) {
 
 func Test_readDeepMindVersion(t *testing.T) {
 	tests := []struct {
-		name        string
-		line        string
-		expectedErr error
+		name          string
+		line          string
+		expectedMajor uint32
+		expectedMinor uint32
+		expectedErr   error
 	}{
 		{
 			"version 12",
 			`DEEP_MIND_VERSION 12`,
+			12, 0,
 			nil,
 		},
 		{
 			"version 13",
 			`DEEP_MIND_VERSION 13 0`,
+			13, 0,
 			nil,
 		},
 		{
 			"version 13, unsupported",
 			`DEEP_MIND_VERSION 14 0`,
+			0, 0,
 			errors.New("deep mind reported version 14, but this reader supports only 12, 13"),
 		},
+		{
+			"version 13, invalid minor",
+			`DEEP_MIND_VERSION 13 a`,
+			0, 0,
+			errors.New(`minor_version is not a valid number, got: "a"`),
+		},
 	}
 
 	for _, test := range tests {
@@ -561,10 +689,31 @@ func Test_readDeepMindVersion(t *testing.T) {
 			err := ctx.readDeepmindVersion(test.line)
 
 			require.Equal(t, test.expectedErr, err)
+
+			if test.expectedErr == nil {
+				assert.Equal(t, test.expectedMajor, ctx.version.major)
+				assert.Equal(t, test.expectedMinor, ctx.deepMindMinorVersion)
+				assert.True(t, ctx.deepMindReported)
+			}
 		})
 	}
 }
 
+func TestConsoleReader_StrictLineHandling(t *testing.T) {
+	content := strings.Join([]string{
+		"DMLOG DEEP_MIND_VERSION 13 0",
+		"DMLOG UNKNOWN_OP 1 2 3",
+	}, "\n")
+
+	cr := testReaderConsoleReader(t, strings.NewReader(content), func() {})
+	_, err := cr.Read()
+	assert.Equal(t, io.EOF, err)
+
+	cr = testReaderConsoleReader(t, strings.NewReader(content), func() {}, WithStrictLineHandling())
+	_, err = cr.Read()
+	assert.EqualError(t, err, `UNKNOWN_OP: unknown line for deep mind version 13 (line "UNKNOWN_OP 1 2 3")`)
+}
+
 func Test_readABIDump_ABI(t *testing.T) {
 	tests := []struct {
 		name        string
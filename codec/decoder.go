@@ -29,14 +29,24 @@ func BlockDecoder(blk *bstream.Block) (interface{}, error) {
 		return nil, fmt.Errorf("expected kind %s, got %s", pbbstream.Protocol_EOS, blk.Kind())
 	}
 
-	if blk.Version() != 1 {
-		return nil, fmt.Errorf("this decoder only knows about version 1, got %d", blk.Version())
-	}
+	var block *pbcodec.Block
+	switch blk.Version() {
+	case 1:
+		block = new(pbcodec.Block)
+		err := proto.Unmarshal(blk.Payload(), block)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode payload: %s", err)
+		}
+
+	case DeduplicatedPayloadVersion:
+		var err error
+		block, err = decodeDeduplicatedBlock(blk.Payload())
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode deduplicated payload: %s", err)
+		}
 
-	block := new(pbcodec.Block)
-	err := proto.Unmarshal(blk.Payload(), block)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode payload: %s", err)
+	default:
+		return nil, fmt.Errorf("this decoder only knows about version 1 and %d, got %d", DeduplicatedPayloadVersion, blk.Version())
 	}
 
 	// This whole BlockDecoder method is being called through the `bstream.Block.ToNative()`
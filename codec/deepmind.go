@@ -0,0 +1,214 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+)
+
+// lineHandler processes the deep mind lines identified by `tag`, which is either the first
+// word of the line (like `DB_OP`) or its first two words (like `DTRX_OP CREATE`), the latter
+// taking precedence.
+//
+// The optional `decode` function performs the part of the processing not depending on the
+// parse context, so that it can be done concurrently, its result being handed to `apply`
+// which is always called sequentially, in the order of the lines.
+type lineHandler struct {
+	tag    string
+	decode func(line string) (interface{}, error)
+	apply  func(ctx *parseCtx, line string, decoded interface{}) (*pbcodec.Block, error)
+}
+
+// deepMindVersion is the set of line handlers of a given major version of the deep mind
+// instrumentation.
+type deepMindVersion struct {
+	major    uint32
+	handlers map[string]*lineHandler
+}
+
+var deepMindVersions = map[uint32]*deepMindVersion{}
+
+// latestDeepMindVersion is used until a `DEEP_MIND_VERSION` line is seen
+var latestDeepMindVersion *deepMindVersion
+
+// registerDeepMindVersion registers the line handlers of a deep mind major version. When
+// `base` is non-zero, the new version starts from the handlers of this already registered
+// version, the given handlers adding new line types or replacing the ones of the same tag,
+// when lines gained new fields for example.
+func registerDeepMindVersion(major uint32, base uint32, handlers ...*lineHandler) {
+	if _, found := deepMindVersions[major]; found {
+		panic(fmt.Errorf("deep mind version %d is already registered", major))
+	}
+
+	version := &deepMindVersion{major: major, handlers: map[string]*lineHandler{}}
+	if base != 0 {
+		baseVersion, found := deepMindVersions[base]
+		if !found {
+			panic(fmt.Errorf("base deep mind version %d of version %d is not registered", base, major))
+		}
+
+		for tag, handler := range baseVersion.handlers {
+			version.handlers[tag] = handler
+		}
+	}
+
+	for _, handler := range handlers {
+		version.handlers[handler.tag] = handler
+	}
+
+	deepMindVersions[major] = version
+	if latestDeepMindVersion == nil || major > latestDeepMindVersion.major {
+		latestDeepMindVersion = version
+	}
+}
+
+func supportedDeepMindVersions() (out []string) {
+	majors := make([]int, 0, len(deepMindVersions))
+	for major := range deepMindVersions {
+		majors = append(majors, int(major))
+	}
+	sort.Ints(majors)
+
+	for _, major := range majors {
+		out = append(out, strconv.Itoa(major))
+	}
+	return
+}
+
+// handlerFor returns the handler of the line, `nil` when the version has none for it
+func (v *deepMindVersion) handlerFor(line string) *lineHandler {
+	chunks := strings.SplitN(line, " ", 3)
+	if len(chunks) >= 2 {
+		if handler, found := v.handlers[chunks[0]+" "+chunks[1]]; found {
+			return handler
+		}
+	}
+
+	return v.handlers[chunks[0]]
+}
+
+// decodeLine finds the handler of the line and runs its decoding step, if any. It returns a
+// `nil` handler for the lines unknown to this version.
+func (v *deepMindVersion) decodeLine(line string) (handler *lineHandler, decoded interface{}, err error) {
+	handler = v.handlerFor(line)
+	if handler == nil || handler.decode == nil {
+		return handler, nil, nil
+	}
+
+	decoded, err = handler.decode(line)
+	return handler, decoded, err
+}
+
+// versionOfLine returns the deep mind version announced by a `DEEP_MIND_VERSION` line, or
+// `nil` if the line is not one or if the version is not supported, in which case processing
+// the line reports the error.
+func versionOfLine(line string) *deepMindVersion {
+	if !strings.HasPrefix(line, "DEEP_MIND_VERSION") {
+		return nil
+	}
+
+	major, _, err := parseDeepMindVersion(line)
+	if err != nil {
+		return nil
+	}
+
+	return deepMindVersions[major]
+}
+
+func init() {
+	registerDeepMindVersion(12, 0,
+		decodedLineHandler("DB_OP", func(line string) (interface{}, error) { return decodeDBOp(line) }, func(ctx *parseCtx, decoded interface{}) error {
+			ctx.recordDBOp(decoded.(*pbcodec.DBOp))
+			return nil
+		}),
+		decodedLineHandler("RLIMIT_OP", func(line string) (interface{}, error) { return decodeRlimitOp(line) }, func(ctx *parseCtx, decoded interface{}) error {
+			ctx.recordRlimitOp(decoded.(*pbcodec.RlimitOp))
+			return nil
+		}),
+		decodedLineHandler("TRX_OP", func(line string) (interface{}, error) { return decodeTrxOp(line) }, func(ctx *parseCtx, decoded interface{}) error {
+			ctx.recordTrxOp(decoded.(*pbcodec.TrxOp))
+			return nil
+		}),
+		decodedLineHandler("APPLIED_TRANSACTION", func(line string) (interface{}, error) { return decodeAppliedTransaction(line) }, func(ctx *parseCtx, decoded interface{}) error {
+			return ctx.applyTransaction(decoded.(*appliedTransaction))
+		}),
+		decodedLineHandler("PERM_OP", func(line string) (interface{}, error) { return decodePermOp(line) }, func(ctx *parseCtx, decoded interface{}) error {
+			ctx.recordPermOp(decoded.(*pbcodec.PermOp))
+			return nil
+		}),
+		createOrCancelDTrxOpHandler("DTRX_OP CREATE"),
+		createOrCancelDTrxOpHandler("DTRX_OP MODIFY_CREATE"),
+		createOrCancelDTrxOpHandler("DTRX_OP MODIFY_CANCEL"),
+		createOrCancelDTrxOpHandler("DTRX_OP PUSH_CREATE"),
+		createOrCancelDTrxOpHandler("DTRX_OP CANCEL"),
+		&lineHandler{
+			tag:    "ACCEPTED_BLOCK",
+			decode: func(line string) (interface{}, error) { return decodeAcceptedBlock(line) },
+			apply: func(ctx *parseCtx, line string, decoded interface{}) (*pbcodec.Block, error) {
+				return ctx.applyAcceptedBlock(decoded.(*acceptedBlock))
+			},
+		},
+
+		contextLineHandler("RAM_OP", (*parseCtx).readRAMOp),
+		contextLineHandler("CREATION_OP", (*parseCtx).readCreationOp),
+		contextLineHandler("TBL_OP", (*parseCtx).readTableOp),
+		contextLineHandler("RAM_CORRECTION_OP", (*parseCtx).readRAMCorrectionOp),
+		contextLineHandler("DTRX_OP FAILED", (*parseCtx).readFailedDTrxOp),
+		contextLineHandler("START_BLOCK", (*parseCtx).readStartBlock),
+		contextLineHandler("FEATURE_OP ACTIVATE", (*parseCtx).readFeatureOpActivate),
+		contextLineHandler("FEATURE_OP PRE_ACTIVATE", (*parseCtx).readFeatureOpPreActivate),
+		contextLineHandler("SWITCH_FORK", (*parseCtx).readSwitchFork),
+		contextLineHandler("ABIDUMP START", (*parseCtx).readABIStart),
+		contextLineHandler("ABIDUMP ABI", (*parseCtx).readABIDump),
+		contextLineHandler("ABIDUMP END", func(ctx *parseCtx, line string) error { return nil }),
+		contextLineHandler("DEEP_MIND_VERSION", (*parseCtx).readDeepmindVersion),
+	)
+
+	// Version 13 added the `${minor_version}` field to `DEEP_MIND_VERSION` and the
+	// `${global_sequence_num}` fields to `ABIDUMP START`, both already handled by the
+	// version 12 parsing functions.
+	registerDeepMindVersion(13, 12)
+}
+
+func decodedLineHandler(tag string, decode func(line string) (interface{}, error), apply func(ctx *parseCtx, decoded interface{}) error) *lineHandler {
+	return &lineHandler{
+		tag:    tag,
+		decode: decode,
+		apply: func(ctx *parseCtx, line string, decoded interface{}) (*pbcodec.Block, error) {
+			return nil, apply(ctx, decoded)
+		},
+	}
+}
+
+func createOrCancelDTrxOpHandler(tag string) *lineHandler {
+	return decodedLineHandler(tag, func(line string) (interface{}, error) { return decodeCreateOrCancelDTrxOp(line) }, func(ctx *parseCtx, decoded interface{}) error {
+		ctx.recordDTrxOp(decoded.(*pbcodec.DTrxOp))
+		return nil
+	})
+}
+
+func contextLineHandler(tag string, read func(ctx *parseCtx, line string) error) *lineHandler {
+	return &lineHandler{
+		tag: tag,
+		apply: func(ctx *parseCtx, line string, decoded interface{}) (*pbcodec.Block, error) {
+			return nil, read(ctx, line)
+		},
+	}
+}
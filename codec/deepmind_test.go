@@ -0,0 +1,95 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"testing"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepMindVersion_HandlerFor(t *testing.T) {
+	version := deepMindVersions[13]
+	require.NotNil(t, version)
+
+	tests := []struct {
+		line        string
+		expectedTag string
+	}{
+		{"DB_OP INS 0 eosio eosio eosio accounts 1", "DB_OP"},
+		{"DTRX_OP CREATE 0 eosio 1", "DTRX_OP CREATE"},
+		{"DTRX_OP FAILED 0", "DTRX_OP FAILED"},
+		{"ABIDUMP END", "ABIDUMP END"},
+		{"SWITCH_FORK", "SWITCH_FORK"},
+		{"UNKNOWN_OP 1 2", ""},
+		{"DTRX_OP UNKNOWN 0", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			handler := version.handlerFor(test.line)
+			if test.expectedTag == "" {
+				assert.Nil(t, handler)
+				return
+			}
+
+			require.NotNil(t, handler)
+			assert.Equal(t, test.expectedTag, handler.tag)
+		})
+	}
+}
+
+func TestRegisterDeepMindVersion(t *testing.T) {
+	previousLatest := latestDeepMindVersion
+	defer func() {
+		delete(deepMindVersions, 99)
+		latestDeepMindVersion = previousLatest
+	}()
+
+	var applied []string
+	newOpHandler := &lineHandler{
+		tag:    "NEW_OP",
+		decode: func(line string) (interface{}, error) { return line[7:], nil },
+		apply: func(ctx *parseCtx, line string, decoded interface{}) (*pbcodec.Block, error) {
+			applied = append(applied, decoded.(string))
+			return nil, nil
+		},
+	}
+
+	registerDeepMindVersion(99, 13, newOpHandler)
+
+	assert.Equal(t, []string{"12", "13", "99"}, supportedDeepMindVersions())
+	assert.Equal(t, deepMindVersions[99], latestDeepMindVersion)
+	assert.Equal(t, deepMindVersions[99], versionOfLine("DEEP_MIND_VERSION 99 1"))
+	assert.Nil(t, versionOfLine("DEEP_MIND_VERSION 98 1"))
+
+	// Inherited from the base version
+	assert.Equal(t, deepMindVersions[13].handlerFor("TBL_OP INS 0"), deepMindVersions[99].handlerFor("TBL_OP INS 0"))
+	assert.Nil(t, deepMindVersions[13].handlerFor("NEW_OP value"))
+
+	ctx := newParseCtx()
+	require.NoError(t, ctx.readDeepmindVersion("DEEP_MIND_VERSION 99 1"))
+
+	handler, decoded, err := ctx.version.decodeLine("NEW_OP value")
+	require.NoError(t, err)
+
+	_, err = ctx.processLine("NEW_OP value", handler, decoded)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"value"}, applied)
+
+	assert.Panics(t, func() { registerDeepMindVersion(99, 13) })
+}
@@ -0,0 +1,670 @@
+package ct
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dfuse-io/bstream"
+	"github.com/dfuse-io/dfuse-eosio/codec"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dstore"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/mitchellh/go-testing-interface"
+	"github.com/stretchr/testify/require"
+)
+
+// ProducerKey is the private key signing the blocks built with `BlockBuilder.Verifiable`
+var ProducerKey = mustNewPrivateKey("5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD3")
+
+// DefaultBlockTime is the time of block #0 of blocks created through `NewBlock`, each
+// following block being 500ms later
+var DefaultBlockTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// BlockID returns the id `NewBlock` assigns to the block `num`, its first 8 characters
+// being the block number in hexadecimal like on chain.
+func BlockID(num uint32) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("block-%d", num)))
+	return fmt.Sprintf("%08x", num) + hex.EncodeToString(hash[:])[8:]
+}
+
+// BlockBuilder builds test blocks through chained calls, assigning consistent ids, receipts,
+// action ordinals, execution indexes, global sequences and creation trees:
+//
+//	block := ct.NewBlock(12).
+//	  Trx("trx1").
+//	    Action("eosio.token", "transfer", `{"from":"alice","to":"bob"}`).Auth("alice@active").Notify("alice", "bob").
+//	    DBOp(&pbcodec.DBOp{Operation: pbcodec.DBOp_OPERATION_UPDATE, Code: "eosio.token", Scope: "alice"}).
+//	  Trx("trx2").
+//	    Action("eosio", "buyrambytes", nil).Inline("eosio.token", "transfer", nil).
+//	  Build(t)
+//
+// Errors are reported when the block is built.
+//
+// The block id is `BlockID(num)` and the header has no merkle roots nor producer signature, so
+// `codec.VerifyBlock` reports issues for the block, unless `Verifiable` is called.
+type BlockBuilder struct {
+	num            uint32
+	blockTime      time.Time
+	producer       string
+	globalSequence uint64
+	abis           map[string]*eos.ABI
+	trxs           []*TrxBuilder
+	verifiable     bool
+
+	err error
+}
+
+// NewBlock starts the builder of block `num`, its global sequences starting at `num * 1000`
+func NewBlock(num uint32) *BlockBuilder {
+	return &BlockBuilder{
+		num:            num,
+		blockTime:      DefaultBlockTime.Add(time.Duration(num) * 500 * time.Millisecond),
+		producer:       "eosio",
+		globalSequence: uint64(num) * 1000,
+		abis:           map[string]*eos.ABI{},
+	}
+}
+
+func (b *BlockBuilder) Time(blockTime time.Time) *BlockBuilder {
+	b.blockTime = blockTime
+	return b
+}
+
+func (b *BlockBuilder) Producer(producer string) *BlockBuilder {
+	b.producer = producer
+	return b
+}
+
+// GlobalSequence sets the global sequence of the first executed action of the block
+func (b *BlockBuilder) GlobalSequence(globalSequence uint64) *BlockBuilder {
+	b.globalSequence = globalSequence
+	return b
+}
+
+// Verifiable makes the block pass `codec.VerifyBlock`: the header merkle roots are computed
+// from the receipts, the block id from the header and the block is signed with `ProducerKey`,
+// the key of the producer in the active schedule of the block. The transaction ids must then be
+// hexadecimal digests, like the ones assigned by `Trx("")`.
+func (b *BlockBuilder) Verifiable() *BlockBuilder {
+	b.verifiable = true
+	return b
+}
+
+// ABI registers the ABI of the `account` contract, the raw data of its actions being encoded
+// from their JSON data with it.
+func (b *BlockBuilder) ABI(account string, abi *eos.ABI) *BlockBuilder {
+	b.abis[account] = abi
+	return b
+}
+
+// Trx starts a new transaction in the block, an id is derived from the block number and
+// transaction index when `id` is empty.
+func (b *BlockBuilder) Trx(id string) *TrxBuilder {
+	if id == "" {
+		hash := sha256.Sum256([]byte(fmt.Sprintf("trx-%d-%d", b.num, len(b.trxs))))
+		id = hex.EncodeToString(hash[:])
+	}
+
+	trx := &TrxBuilder{block: b, id: id, status: pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED}
+	b.trxs = append(b.trxs, trx)
+
+	return trx
+}
+
+func (b *BlockBuilder) Build(t testing.T) *pbcodec.Block {
+	require.NoError(t, b.err, "invalid test block #%d", b.num)
+
+	timestamp, err := ptypes.TimestampProto(b.blockTime)
+	require.NoError(t, err)
+
+	previousNum := uint32(0)
+	if b.num > 0 {
+		previousNum = b.num - 1
+	}
+
+	block := &pbcodec.Block{
+		Id:                       BlockID(b.num),
+		Number:                   b.num,
+		DposIrreversibleBlocknum: previousNum,
+		Header: &pbcodec.BlockHeader{
+			Previous:  BlockID(previousNum),
+			Producer:  b.producer,
+			Timestamp: timestamp,
+		},
+	}
+
+	state := &buildState{
+		globalSequence: b.globalSequence,
+		recvSequences:  map[string]uint64{},
+		authSequences:  map[string]uint64{},
+	}
+
+	for i, trx := range b.trxs {
+		trace, err := trx.build(block, uint64(i), state)
+		require.NoError(t, err, "invalid test transaction %s", trx.id)
+
+		block.UnfilteredTransactions = append(block.UnfilteredTransactions, &pbcodec.TransactionReceipt{
+			Id:                   trace.Id,
+			Index:                trace.Index,
+			Status:               trace.Receipt.Status,
+			CpuUsageMicroSeconds: trace.Receipt.CpuUsageMicroSeconds,
+			NetUsageWords:        trace.Receipt.NetUsageWords,
+		})
+		block.UnfilteredTransactionTraces = append(block.UnfilteredTransactionTraces, trace)
+	}
+
+	if b.verifiable {
+		require.NoError(t, signBlock(block), "unable to sign test block #%d", b.num)
+	}
+
+	block.MigrateV0ToV1()
+	return block
+}
+
+// signBlock computes the header merkle roots and the id of the block, then signs it with
+// `ProducerKey`.
+func signBlock(block *pbcodec.Block) error {
+	transactionDigests := make([][]byte, len(block.UnfilteredTransactions))
+	for i, receipt := range block.UnfilteredTransactions {
+		digest, err := codec.TransactionReceiptDigest(receipt)
+		if err != nil {
+			return fmt.Errorf("transaction %s: %w", receipt.Id, err)
+		}
+
+		transactionDigests[i] = digest
+	}
+
+	// Actions are executed in global sequence order, the ones of failed transactions being
+	// rolled back
+	var actionDigests [][]byte
+	for _, trace := range block.UnfilteredTransactionTraces {
+		status := trace.Receipt.Status
+		if status != pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED && status != pbcodec.TransactionStatus_TRANSACTIONSTATUS_SOFTFAIL {
+			continue
+		}
+
+		for _, actionTrace := range trace.ActionTraces {
+			digest, err := codec.ActionReceiptDigest(actionTrace.Receipt)
+			if err != nil {
+				return fmt.Errorf("transaction %s: %w", trace.Id, err)
+			}
+
+			actionDigests = append(actionDigests, digest)
+		}
+	}
+
+	block.Header.TransactionMroot = codec.MerkleRoot(transactionDigests)
+	block.Header.ActionMroot = codec.MerkleRoot(actionDigests)
+	block.BlockrootMerkle = &pbcodec.BlockRootMerkle{NodeCount: block.Number, ActiveNodes: [][]byte{blockDigest("blockroot", block.Number)}}
+	block.PendingSchedule = &pbcodec.PendingProducerSchedule{ScheduleHash: blockDigest("schedule", block.Number)}
+	block.ActiveScheduleV1 = &pbcodec.ProducerSchedule{
+		Producers: []*pbcodec.ProducerKey{{AccountName: block.Header.Producer, BlockSigningKey: ProducerKey.PublicKey().String()}},
+	}
+
+	blockID, err := codec.BlockHeaderToEOS(block.Header).BlockID()
+	if err != nil {
+		return fmt.Errorf("block id: %w", err)
+	}
+
+	block.Id = blockID.String()
+	for _, trace := range block.UnfilteredTransactionTraces {
+		trace.ProducerBlockId = block.Id
+		for _, actionTrace := range trace.ActionTraces {
+			actionTrace.ProducerBlockId = block.Id
+		}
+	}
+
+	digest, err := codec.BlockSignatureDigest(block)
+	if err != nil {
+		return err
+	}
+
+	signature, err := ProducerKey.Sign(digest)
+	if err != nil {
+		return fmt.Errorf("sign: %w", err)
+	}
+
+	block.ProducerSignature = signature.String()
+	return nil
+}
+
+func blockDigest(tag string, num uint32) []byte {
+	digest := sha256.Sum256([]byte(fmt.Sprintf("%s-%d", tag, num)))
+	return digest[:]
+}
+
+func mustNewPrivateKey(wif string) *ecc.PrivateKey {
+	key, err := ecc.NewPrivateKey(wif)
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+}
+
+func (b *BlockBuilder) ToBstreamBlock(t testing.T) *bstream.Block {
+	return ToBstreamBlock(t, b.Build(t))
+}
+
+// TrxBuilder builds a transaction of a block, its calls applying to the last added action
+// when they relate to an action.
+type TrxBuilder struct {
+	block  *BlockBuilder
+	id     string
+	status pbcodec.TransactionStatus
+
+	roots   []*actionNode
+	current *actionNode
+}
+
+// Action adds an input action to the transaction, `data` being the JSON data of the action
+// when it's a string, its raw data when it's a `[]byte` and marshaled to JSON otherwise.
+func (t *TrxBuilder) Action(account, name string, data interface{}) *TrxBuilder {
+	t.current = t.newActionNode(account, name, data)
+	t.roots = append(t.roots, t.current)
+
+	return t
+}
+
+// Inline adds an inline action created by the last added action, it becomes the last added
+// action.
+func (t *TrxBuilder) Inline(account, name string, data interface{}) *TrxBuilder {
+	if t.mustHaveCurrent("inline action") {
+		inline := t.newActionNode(account, name, data)
+		t.current.inlines = append(t.current.inlines, inline)
+		t.current = inline
+	}
+
+	return t
+}
+
+// Auth adds `actor@permission` authorizations to the last added action
+func (t *TrxBuilder) Auth(permissionLevels ...string) *TrxBuilder {
+	if t.mustHaveCurrent("authorization") {
+		for _, permissionLevel := range permissionLevels {
+			parts := strings.Split(permissionLevel, "@")
+			if len(parts) != 2 {
+				t.block.fail(fmt.Errorf("invalid authorization %q, expected actor@permission", permissionLevel))
+				continue
+			}
+
+			t.current.action.Authorization = append(t.current.action.Authorization, &pbcodec.PermissionLevel{Actor: parts[0], Permission: parts[1]})
+		}
+	}
+
+	return t
+}
+
+// Notify adds notifications of the last added action to `receivers`
+func (t *TrxBuilder) Notify(receivers ...string) *TrxBuilder {
+	if t.mustHaveCurrent("notification") {
+		t.current.notifications = append(t.current.notifications, receivers...)
+	}
+
+	return t
+}
+
+// DBOp adds a database operation performed by the last added action, its action index
+// being assigned when the block is built.
+func (t *TrxBuilder) DBOp(op *pbcodec.DBOp) *TrxBuilder {
+	if t.mustHaveCurrent("db op") {
+		t.current.dbOps = append(t.current.dbOps, op)
+	}
+
+	return t
+}
+
+// RAMOp adds a RAM operation performed by the last added action, its action index being
+// assigned when the block is built.
+func (t *TrxBuilder) RAMOp(op *pbcodec.RAMOp) *TrxBuilder {
+	if t.mustHaveCurrent("ram op") {
+		t.current.ramOps = append(t.current.ramOps, op)
+	}
+
+	return t
+}
+
+// NotificationDBOp adds a database operation performed by the notification of the last added
+// action to `receiver`, which must have been added through `Notify` first.
+func (t *TrxBuilder) NotificationDBOp(receiver string, op *pbcodec.DBOp) *TrxBuilder {
+	if t.mustHaveNotification(receiver) {
+		t.current.notificationDBOps[receiver] = append(t.current.notificationDBOps[receiver], op)
+	}
+
+	return t
+}
+
+// NotificationRAMOp adds a RAM operation performed by the notification of the last added
+// action to `receiver`, which must have been added through `Notify` first.
+func (t *TrxBuilder) NotificationRAMOp(receiver string, op *pbcodec.RAMOp) *TrxBuilder {
+	if t.mustHaveNotification(receiver) {
+		t.current.notificationRAMOps[receiver] = append(t.current.notificationRAMOps[receiver], op)
+	}
+
+	return t
+}
+
+func (t *TrxBuilder) Status(status pbcodec.TransactionStatus) *TrxBuilder {
+	t.status = status
+	return t
+}
+
+// Trx starts a new transaction in the block of this transaction
+func (t *TrxBuilder) Trx(id string) *TrxBuilder {
+	return t.block.Trx(id)
+}
+
+func (t *TrxBuilder) Block() *BlockBuilder {
+	return t.block
+}
+
+func (t *TrxBuilder) Build(tt testing.T) *pbcodec.Block {
+	return t.block.Build(tt)
+}
+
+func (t *TrxBuilder) ToBstreamBlock(tt testing.T) *bstream.Block {
+	return t.block.ToBstreamBlock(tt)
+}
+
+func (t *TrxBuilder) newActionNode(account, name string, data interface{}) *actionNode {
+	action := &pbcodec.Action{Account: account, Name: name}
+
+	switch v := data.(type) {
+	case nil:
+	case string:
+		action.JsonData = v
+	case []byte:
+		action.RawData = v
+	default:
+		jsonData, err := json.Marshal(v)
+		if err != nil {
+			t.block.fail(fmt.Errorf("unable to marshal data of action %s:%s: %w", account, name, err))
+		}
+
+		action.JsonData = string(jsonData)
+	}
+
+	return &actionNode{
+		action:             action,
+		notificationDBOps:  map[string][]*pbcodec.DBOp{},
+		notificationRAMOps: map[string][]*pbcodec.RAMOp{},
+	}
+}
+
+func (t *TrxBuilder) mustHaveCurrent(tag string) bool {
+	if t.current == nil {
+		t.block.fail(fmt.Errorf("transaction %s has no action to add the %s to", t.id, tag))
+		return false
+	}
+
+	return true
+}
+
+func (t *TrxBuilder) mustHaveNotification(receiver string) bool {
+	if !t.mustHaveCurrent("notification op") {
+		return false
+	}
+
+	for _, notification := range t.current.notifications {
+		if notification == receiver {
+			return true
+		}
+	}
+
+	t.block.fail(fmt.Errorf("transaction %s has no notification to %s to add the op to", t.id, receiver))
+	return false
+}
+
+func (b *BlockBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+type actionNode struct {
+	action        *pbcodec.Action
+	notifications []string
+	inlines       []*actionNode
+	dbOps         []*pbcodec.DBOp
+	ramOps        []*pbcodec.RAMOp
+
+	notificationDBOps  map[string][]*pbcodec.DBOp
+	notificationRAMOps map[string][]*pbcodec.RAMOp
+}
+
+// buildState holds the sequences shared by all the transactions of a block
+type buildState struct {
+	globalSequence uint64
+	recvSequences  map[string]uint64
+	authSequences  map[string]uint64
+}
+
+// executedAction is an action trace, in execution order, along with the node it comes from
+type executedAction struct {
+	node         *actionNode
+	notification bool
+	trace        *pbcodec.ActionTrace
+}
+
+func (t *TrxBuilder) build(block *pbcodec.Block, index uint64, state *buildState) (*pbcodec.TransactionTrace, error) {
+	trace := &pbcodec.TransactionTrace{
+		Id:              t.id,
+		BlockNum:        uint64(block.Number),
+		Index:           index,
+		BlockTime:       block.Header.Timestamp,
+		ProducerBlockId: block.Id,
+		Receipt: &pbcodec.TransactionReceiptHeader{
+			Status:               t.status,
+			CpuUsageMicroSeconds: 100,
+			NetUsageWords:        16,
+		},
+	}
+
+	for _, root := range t.roots {
+		if err := t.block.encodeActions(root); err != nil {
+			return nil, err
+		}
+	}
+
+	var executed []*executedAction
+	execute := func(node *actionNode, receiver string, notification bool, actionOrdinal, creatorOrdinal uint32) {
+		action := proto.Clone(node.action).(*pbcodec.Action)
+
+		executed = append(executed, &executedAction{
+			node:         node,
+			notification: notification,
+			trace: &pbcodec.ActionTrace{
+				Receiver:                               receiver,
+				Action:                                 action,
+				Receipt:                                state.receipt(receiver, action),
+				TransactionId:                          trace.Id,
+				BlockNum:                               trace.BlockNum,
+				ProducerBlockId:                        trace.ProducerBlockId,
+				BlockTime:                              trace.BlockTime,
+				ActionOrdinal:                          actionOrdinal,
+				CreatorActionOrdinal:                   creatorOrdinal,
+				ClosestUnnotifiedAncestorActionOrdinal: creatorOrdinal,
+				ExecutionIndex:                         uint32(len(executed)),
+			},
+		})
+	}
+
+	// Input actions are scheduled first, notifications and inline actions being scheduled
+	// when their creator action executes, notifications first, and executed in that order.
+	ordinal := uint32(len(t.roots))
+
+	var executeAction func(node *actionNode, actionOrdinal, creatorOrdinal uint32)
+	executeAction = func(node *actionNode, actionOrdinal, creatorOrdinal uint32) {
+		execute(node, node.action.Account, false, actionOrdinal, creatorOrdinal)
+
+		firstNotificationOrdinal := ordinal + 1
+		firstInlineOrdinal := firstNotificationOrdinal + uint32(len(node.notifications))
+		ordinal += uint32(len(node.notifications) + len(node.inlines))
+
+		for i, receiver := range node.notifications {
+			execute(node, receiver, true, firstNotificationOrdinal+uint32(i), actionOrdinal)
+		}
+
+		for i, inline := range node.inlines {
+			executeAction(inline, firstInlineOrdinal+uint32(i), actionOrdinal)
+		}
+	}
+
+	for i, root := range t.roots {
+		executeAction(root, uint32(i+1), 0)
+	}
+
+	for _, execution := range executed {
+		trace.ActionTraces = append(trace.ActionTraces, execution.trace)
+
+		dbOps, ramOps := execution.node.dbOps, execution.node.ramOps
+		if execution.notification {
+			dbOps = execution.node.notificationDBOps[execution.trace.Receiver]
+			ramOps = execution.node.notificationRAMOps[execution.trace.Receiver]
+		}
+
+		for _, op := range dbOps {
+			op = proto.Clone(op).(*pbcodec.DBOp)
+			op.ActionIndex = execution.trace.ExecutionIndex
+			trace.DbOps = append(trace.DbOps, op)
+		}
+
+		for _, op := range ramOps {
+			op = proto.Clone(op).(*pbcodec.RAMOp)
+			op.ActionIndex = execution.trace.ExecutionIndex
+			trace.RamOps = append(trace.RamOps, op)
+		}
+	}
+
+	trace.CreationTree = creationTree(t.roots, executed)
+	return trace, nil
+}
+
+// creationTree returns the creation tree, in its flat form, of the executed actions, walking
+// it depth-first with notifications before inline actions.
+func creationTree(roots []*actionNode, executed []*executedAction) (out []*pbcodec.CreationFlatNode) {
+	executionIndexes := map[*actionNode]uint32{}
+	notificationIndexes := map[*actionNode][]uint32{}
+	for _, execution := range executed {
+		if execution.notification {
+			notificationIndexes[execution.node] = append(notificationIndexes[execution.node], execution.trace.ExecutionIndex)
+		} else {
+			executionIndexes[execution.node] = execution.trace.ExecutionIndex
+		}
+	}
+
+	var walk func(node *actionNode, parentWalkIndex int32)
+	walk = func(node *actionNode, parentWalkIndex int32) {
+		walkIndex := int32(len(out))
+		out = append(out, &pbcodec.CreationFlatNode{CreatorActionIndex: parentWalkIndex, ExecutionActionIndex: executionIndexes[node]})
+
+		for _, executionIndex := range notificationIndexes[node] {
+			out = append(out, &pbcodec.CreationFlatNode{CreatorActionIndex: walkIndex, ExecutionActionIndex: executionIndex})
+		}
+
+		for _, inline := range node.inlines {
+			walk(inline, walkIndex)
+		}
+	}
+
+	for _, root := range roots {
+		walk(root, -1)
+	}
+
+	return out
+}
+
+func (s *buildState) receipt(receiver string, action *pbcodec.Action) *pbcodec.ActionReceipt {
+	s.recvSequences[receiver]++
+
+	receipt := &pbcodec.ActionReceipt{
+		Receiver:       receiver,
+		Digest:         actionDigest(action),
+		GlobalSequence: s.globalSequence,
+		RecvSequence:   s.recvSequences[receiver],
+		CodeSequence:   1,
+		AbiSequence:    1,
+	}
+
+	for _, authorization := range action.Authorization {
+		s.authSequences[authorization.Actor]++
+		receipt.AuthSequence = append(receipt.AuthSequence, &pbcodec.AuthSequence{AccountName: authorization.Actor, Sequence: s.authSequences[authorization.Actor]})
+	}
+
+	s.globalSequence++
+
+	return receipt
+}
+
+// encodeActions encodes the raw data of the action and of its inline actions from their JSON
+// data when the ABI of their account is known and they do not have raw data yet.
+func (b *BlockBuilder) encodeActions(node *actionNode) error {
+	action := node.action
+	if abi := b.abis[action.Account]; abi != nil && action.JsonData != "" && len(action.RawData) == 0 {
+		rawData, err := abi.EncodeAction(eos.ActionName(action.Name), []byte(action.JsonData))
+		if err != nil {
+			return fmt.Errorf("unable to encode data of action %s:%s: %w", action.Account, action.Name, err)
+		}
+
+		action.RawData = rawData
+	}
+
+	for _, inline := range node.inlines {
+		if err := b.encodeActions(inline); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func actionDigest(action *pbcodec.Action) string {
+	hasher := sha256.New()
+	hasher.Write([]byte(action.Account))
+	hasher.Write([]byte(action.Name))
+	for _, authorization := range action.Authorization {
+		hasher.Write([]byte(authorization.Actor))
+		hasher.Write([]byte(authorization.Permission))
+	}
+
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], uint64(len(action.RawData)))
+	hasher.Write(length[:])
+	hasher.Write(action.RawData)
+
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// MergedBlocksFile returns the content of a merged blocks file containing `blocks`
+func MergedBlocksFile(t testing.T, blocks ...*pbcodec.Block) []byte {
+	buffer := bytes.NewBuffer(nil)
+	writer, err := codec.NewBlockWriter(buffer)
+	require.NoError(t, err)
+
+	for _, block := range blocks {
+		require.NoError(t, writer.Write(ToBstreamBlock(t, block)))
+	}
+
+	return buffer.Bytes()
+}
+
+// WriteMergedBlocksFile writes `blocks` to the merged blocks file of `store` named after the
+// 100-blocks boundary of the first block.
+func WriteMergedBlocksFile(t testing.T, store dstore.Store, blocks ...*pbcodec.Block) {
+	require.NotEmpty(t, blocks, "at least one block is required to write a merged blocks file")
+
+	baseNum := blocks[0].Number - (blocks[0].Number % 100)
+	content := MergedBlocksFile(t, blocks...)
+
+	require.NoError(t, store.WriteObject(context.Background(), fmt.Sprintf("%010d", baseNum), bytes.NewReader(content)))
+}
@@ -0,0 +1,163 @@
+package ct
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/dfuse-io/dfuse-eosio/codec"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockBuilder(t *testing.T) {
+	block := NewBlock(12).
+		Trx("trx1").
+		Action("eosio.token", "transfer", `{"from":"alice","to":"bob"}`).Auth("alice@active").Notify("alice", "bob").
+		DBOp(&pbcodec.DBOp{Operation: pbcodec.DBOp_OPERATION_UPDATE, Code: "eosio.token", Scope: "alice", TableName: "accounts"}).
+		Inline("eosio.token", "issue", map[string]string{"to": "bob"}).
+		RAMOp(&pbcodec.RAMOp{Operation: pbcodec.RAMOp_OPERATION_CREATE_TABLE, Payer: "bob"}).
+		Action("eosio", "noop", nil).
+		Trx("").
+		Action("eosio", "onblock", []byte{0x01}).
+		Build(t)
+
+	assert.Equal(t, BlockID(12), block.Id)
+	assert.Equal(t, BlockID(11), block.Header.Previous)
+	assert.Equal(t, uint64(12), block.Num())
+	assert.Equal(t, uint32(2), block.UnfilteredTransactionCount)
+	assert.Equal(t, uint32(3), block.UnfilteredExecutedInputActionCount)
+	assert.Equal(t, uint32(6), block.UnfilteredExecutedTotalActionCount)
+
+	trace := block.UnfilteredTransactionTraces[0]
+	assert.Equal(t, "trx1", trace.Id)
+	assert.Equal(t, block.Id, trace.ProducerBlockId)
+	assert.Equal(t, "trx1", block.UnfilteredTransactions[0].Id)
+
+	type actionTrace struct {
+		Receiver       string
+		Name           string
+		Ordinal        uint32
+		CreatorOrdinal uint32
+		GlobalSequence uint64
+	}
+
+	var actionTraces []actionTrace
+	for i, act := range trace.ActionTraces {
+		assert.Equal(t, uint32(i), act.ExecutionIndex)
+		assert.Equal(t, act.Receiver, act.Receipt.Receiver)
+		actionTraces = append(actionTraces, actionTrace{act.Receiver, act.Action.Name, act.ActionOrdinal, act.CreatorActionOrdinal, act.Receipt.GlobalSequence})
+	}
+
+	assert.Equal(t, []actionTrace{
+		{"eosio.token", "transfer", 1, 0, 12000},
+		{"alice", "transfer", 3, 1, 12001},
+		{"bob", "transfer", 4, 1, 12002},
+		{"eosio.token", "issue", 5, 1, 12003},
+		{"eosio", "noop", 2, 0, 12004},
+	}, actionTraces)
+
+	assert.Equal(t, trace.ActionTraces[0].Receipt.Digest, trace.ActionTraces[1].Receipt.Digest)
+	assert.Equal(t, `{"to":"bob"}`, trace.ActionTraces[3].Action.JsonData)
+	assert.Equal(t, []*pbcodec.AuthSequence{{AccountName: "alice", Sequence: 1}}, trace.ActionTraces[0].Receipt.AuthSequence)
+
+	assert.Equal(t, []*pbcodec.CreationFlatNode{
+		{CreatorActionIndex: -1, ExecutionActionIndex: 0},
+		{CreatorActionIndex: 0, ExecutionActionIndex: 1},
+		{CreatorActionIndex: 0, ExecutionActionIndex: 2},
+		{CreatorActionIndex: 0, ExecutionActionIndex: 3},
+		{CreatorActionIndex: -1, ExecutionActionIndex: 4},
+	}, trace.CreationTree)
+	require.NoError(t, trace.ValidateCreationTree())
+
+	require.Len(t, trace.DbOps, 1)
+	assert.Equal(t, uint32(0), trace.DbOps[0].ActionIndex)
+	require.Len(t, trace.RamOps, 1)
+	assert.Equal(t, uint32(3), trace.RamOps[0].ActionIndex)
+
+	second := block.UnfilteredTransactionTraces[1]
+	assert.Len(t, second.Id, 64)
+	assert.Equal(t, uint64(1), second.Index)
+	assert.Equal(t, uint64(12005), second.ActionTraces[0].Receipt.GlobalSequence)
+}
+
+func TestBlockBuilder_NotificationOps(t *testing.T) {
+	block := NewBlock(12).
+		Trx("trx1").
+		Action("eosio.token", "transfer", nil).Notify("alice", "bob").
+		DBOp(&pbcodec.DBOp{Code: "eosio.token", Scope: "alice"}).
+		NotificationDBOp("bob", &pbcodec.DBOp{Code: "bob", Scope: "bob"}).
+		NotificationRAMOp("alice", &pbcodec.RAMOp{Payer: "alice"}).
+		Build(t)
+
+	trace := block.UnfilteredTransactionTraces[0]
+	require.Len(t, trace.DbOps, 2)
+	assert.Equal(t, "eosio.token", trace.DbOps[0].Code)
+	assert.Equal(t, uint32(0), trace.DbOps[0].ActionIndex)
+	assert.Equal(t, "bob", trace.DbOps[1].Code)
+	assert.Equal(t, uint32(2), trace.DbOps[1].ActionIndex)
+	assert.Equal(t, "bob", trace.ActionTraces[2].Receiver)
+
+	require.Len(t, trace.RamOps, 1)
+	assert.Equal(t, uint32(1), trace.RamOps[0].ActionIndex)
+	assert.Equal(t, "alice", trace.ActionTraces[1].Receiver)
+}
+
+func TestBlockBuilder_Verifiable(t *testing.T) {
+	builder := NewBlock(12).Producer("bp1").
+		Trx("").Action("eosio", "onblock", []byte{0x01}).
+		Trx("").Action("eosio.token", "transfer", nil).Auth("alice@active").Notify("alice").Inline("eosio", "noop", nil).
+		Trx("").Status(pbcodec.TransactionStatus_TRANSACTIONSTATUS_HARDFAIL).Action("eosio", "noop", nil).
+		Block().Verifiable()
+
+	block := builder.Build(t)
+	assert.Empty(t, codec.VerifyBlock(block))
+	assert.NotEqual(t, BlockID(12), block.Id)
+	assert.Equal(t, uint64(12), block.Num())
+	assert.Equal(t, block.Id, block.UnfilteredTransactionTraces[1].ActionTraces[1].ProducerBlockId)
+
+	block.UnfilteredTransactionTraces[1].ActionTraces[1].Receipt.RecvSequence++
+	assert.Len(t, codec.VerifyBlock(block), 1)
+
+	err := signBlock(NewBlock(12).Trx("trx1").Action("eosio", "noop", nil).Build(t))
+	assert.Error(t, err, "transaction ids must be hexadecimal to be signed")
+}
+
+func TestBlockBuilder_Errors(t *testing.T) {
+	builder := NewBlock(12)
+	builder.Trx("trx1").Auth("alice@active")
+	assert.EqualError(t, builder.err, "transaction trx1 has no action to add the authorization to")
+
+	builder = NewBlock(12)
+	builder.Trx("trx1").Action("eosio", "noop", nil).Auth("alice")
+	assert.EqualError(t, builder.err, `invalid authorization "alice", expected actor@permission`)
+
+	builder = NewBlock(12)
+	builder.Trx("trx1").Action("eosio", "noop", nil).Notify("alice").NotificationDBOp("bob", &pbcodec.DBOp{})
+	assert.EqualError(t, builder.err, "transaction trx1 has no notification to bob to add the op to")
+}
+
+func TestMergedBlocksFile(t *testing.T) {
+	content := MergedBlocksFile(t,
+		NewBlock(100).Trx("trx1").Action("eosio", "onblock", nil).Build(t),
+		NewBlock(101).Build(t),
+	)
+
+	reader, err := codec.NewBlockReader(bytes.NewReader(content))
+	require.NoError(t, err)
+
+	var ids []string
+	for {
+		blk, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		assert.Equal(t, blk.Id, blk.ToNative().(*pbcodec.Block).Id)
+		ids = append(ids, blk.Id)
+	}
+
+	assert.Equal(t, []string{BlockID(100), BlockID(101)}, ids)
+}
@@ -171,8 +171,12 @@ func TrxTrace(t testing.T, components ...interface{}) *pbcodec.TransactionTrace
 			trace.DbOps = append(trace.DbOps, v)
 		case *pbcodec.DTrxOp:
 			trace.DtrxOps = append(trace.DtrxOps, v)
+		case *pbcodec.RAMOp:
+			trace.RamOps = append(trace.RamOps, v)
 		case *pbcodec.TableOp:
 			trace.TableOps = append(trace.TableOps, v)
+		case *pbcodec.PermOp:
+			trace.PermOps = append(trace.PermOps, v)
 		case pbcodec.TransactionStatus:
 			trace.Receipt.Status = v
 		default:
@@ -0,0 +1,480 @@
+package codec
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+)
+
+const (
+	IntegrityCheckBlockID            = "block_id"
+	IntegrityCheckTransactionMroot   = "transaction_mroot"
+	IntegrityCheckActionMroot        = "action_mroot"
+	IntegrityCheckProducerSignature  = "producer_signature"
+	IntegrityCheckTransactionReceipt = "transaction_receipt"
+	IntegrityCheckActionReceipt      = "action_receipt"
+	IntegrityCheckCreationTree       = "creation_tree"
+)
+
+// BlockIntegrityIssue is an inconsistency found by `VerifyBlock`, the `TransactionID` being set
+// when the issue could be tied to a given transaction.
+type BlockIntegrityIssue struct {
+	Check         string
+	TransactionID string
+	Message       string
+}
+
+func (i *BlockIntegrityIssue) String() string {
+	if i.TransactionID == "" {
+		return fmt.Sprintf("%s: %s", i.Check, i.Message)
+	}
+
+	return fmt.Sprintf("%s: transaction %s: %s", i.Check, i.TransactionID, i.Message)
+}
+
+// VerifyBlock recomputes the block ID, the `transaction_mroot` and the `action_mroot` of the
+// block and checks them against the block header, then verifies that the producer signature
+// was made by one of the keys of the producer in the active schedule and that the creation
+// tree of each transaction trace matches its action traces. It returns the issues found, none
+// meaning the block is sound.
+//
+// The merkle roots cannot be recomputed once filtering has been applied on a block, only the
+// block ID and the producer signature are verified for such blocks.
+func VerifyBlock(block *pbcodec.Block) (issues []*BlockIntegrityIssue) {
+	if block.Header == nil {
+		return []*BlockIntegrityIssue{{Check: IntegrityCheckBlockID, Message: "block has no header"}}
+	}
+
+	header := BlockHeaderToEOS(block.Header)
+	issues = append(issues, verifyBlockID(block, header)...)
+	issues = append(issues, verifyProducerSignature(block, header)...)
+	issues = append(issues, verifyCreationTrees(block)...)
+
+	if block.FilteringApplied {
+		return issues
+	}
+
+	issues = append(issues, verifyTransactionMroot(block)...)
+	issues = append(issues, verifyActionMroot(block)...)
+
+	return issues
+}
+
+func verifyCreationTrees(block *pbcodec.Block) (issues []*BlockIntegrityIssue) {
+	for _, trace := range block.TransactionTraces() {
+		if err := trace.ValidateCreationTree(); err != nil {
+			issues = append(issues, &BlockIntegrityIssue{Check: IntegrityCheckCreationTree, TransactionID: trace.Id, Message: err.Error()})
+		}
+	}
+
+	return issues
+}
+
+func verifyBlockID(block *pbcodec.Block, header *eos.BlockHeader) []*BlockIntegrityIssue {
+	blockID, err := header.BlockID()
+	if err != nil {
+		return []*BlockIntegrityIssue{{Check: IntegrityCheckBlockID, Message: fmt.Sprintf("unable to compute block id: %s", err)}}
+	}
+
+	if blockID.String() != block.Id {
+		return []*BlockIntegrityIssue{{Check: IntegrityCheckBlockID, Message: fmt.Sprintf("computed block id %s does not match block id %s", blockID, block.Id)}}
+	}
+
+	return nil
+}
+
+func verifyTransactionMroot(block *pbcodec.Block) (issues []*BlockIntegrityIssue) {
+	// A failed deferred transaction has a trace for the failed attempt, sharing the receipt's
+	// transaction id, while the receipt comes from the trace of its `onerror` handling, whose
+	// id differs, so receipts are only cross-checked against traces that did not fail.
+	traces := map[string]*pbcodec.TransactionTrace{}
+	for _, trace := range block.UnfilteredTransactionTraces {
+		if trace.Exception == nil {
+			traces[trace.Id] = trace
+		}
+	}
+
+	digests := make([][]byte, len(block.UnfilteredTransactions))
+	for i, receipt := range block.UnfilteredTransactions {
+		digest, err := TransactionReceiptDigest(receipt)
+		if err != nil {
+			issues = append(issues, &BlockIntegrityIssue{IntegrityCheckTransactionReceipt, receipt.Id, err.Error()})
+			continue
+		}
+
+		digests[i] = digest
+		issues = append(issues, verifyTransactionReceipt(receipt, traces[receipt.Id])...)
+	}
+
+	if len(issues) > 0 {
+		return issues
+	}
+
+	mroot := MerkleRoot(digests)
+	if !bytes.Equal(mroot, block.Header.TransactionMroot) {
+		issues = append(issues, &BlockIntegrityIssue{
+			Check:   IntegrityCheckTransactionMroot,
+			Message: fmt.Sprintf("computed root %x from %d receipts does not match header root %x", mroot, len(digests), block.Header.TransactionMroot),
+		})
+	}
+
+	return issues
+}
+
+// verifyTransactionReceipt cross-checks the receipt against the transaction trace and its
+// packed transaction, so that a merkle root mismatch can be pinned to a given transaction.
+func verifyTransactionReceipt(receipt *pbcodec.TransactionReceipt, trace *pbcodec.TransactionTrace) (issues []*BlockIntegrityIssue) {
+	if trace != nil && trace.Receipt != nil {
+		if trace.Receipt.Status != receipt.Status {
+			issues = append(issues, &BlockIntegrityIssue{IntegrityCheckTransactionReceipt, receipt.Id, fmt.Sprintf("receipt status %s does not match trace status %s", receipt.Status, trace.Receipt.Status)})
+		}
+
+		if trace.Receipt.CpuUsageMicroSeconds != receipt.CpuUsageMicroSeconds {
+			issues = append(issues, &BlockIntegrityIssue{IntegrityCheckTransactionReceipt, receipt.Id, fmt.Sprintf("receipt cpu usage %d does not match trace cpu usage %d", receipt.CpuUsageMicroSeconds, trace.Receipt.CpuUsageMicroSeconds)})
+		}
+
+		if trace.Receipt.NetUsageWords != receipt.NetUsageWords {
+			issues = append(issues, &BlockIntegrityIssue{IntegrityCheckTransactionReceipt, receipt.Id, fmt.Sprintf("receipt net usage %d does not match trace net usage %d", receipt.NetUsageWords, trace.Receipt.NetUsageWords)})
+		}
+	}
+
+	// The transaction id is the digest of the packed transaction only when it's not compressed
+	packed := receipt.PackedTransaction
+	if packed != nil && eos.CompressionType(packed.Compression) == eos.CompressionNone {
+		id := sha256.Sum256(packed.PackedTransaction)
+		if hex.EncodeToString(id[:]) != receipt.Id {
+			issues = append(issues, &BlockIntegrityIssue{IntegrityCheckTransactionReceipt, receipt.Id, fmt.Sprintf("packed transaction digest %x does not match receipt id", id)})
+		}
+	}
+
+	return issues
+}
+
+// TransactionReceiptDigest returns the digest of the receipt as it's merkelized in the block
+// `transaction_mroot`.
+func TransactionReceiptDigest(receipt *pbcodec.TransactionReceipt) ([]byte, error) {
+	var trxDigest []byte
+	if receipt.PackedTransaction == nil {
+		id, err := hex.DecodeString(receipt.Id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction id: %w", err)
+		}
+
+		trxDigest = id
+	} else {
+		digest, err := packedTransactionDigest(receipt.PackedTransaction)
+		if err != nil {
+			return nil, err
+		}
+
+		trxDigest = digest
+	}
+
+	return digestOf(
+		uint8(TransactionStatusToEOS(receipt.Status)),
+		receipt.CpuUsageMicroSeconds,
+		eos.Varuint32(receipt.NetUsageWords),
+		eos.Checksum256(trxDigest),
+	)
+}
+
+func packedTransactionDigest(packed *pbcodec.PackedTransaction) ([]byte, error) {
+	signatures := make([]ecc.Signature, len(packed.Signatures))
+	for i, signature := range packed.Signatures {
+		sig, err := ecc.NewSignature(signature)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature %q: %w", signature, err)
+		}
+
+		signatures[i] = sig
+	}
+
+	prunableDigest, err := digestOf(signatures, eos.HexBytes(packed.PackedContextFreeData))
+	if err != nil {
+		return nil, fmt.Errorf("prunable digest: %w", err)
+	}
+
+	return digestOf(uint8(packed.Compression), eos.HexBytes(packed.PackedTransaction), eos.Checksum256(prunableDigest))
+}
+
+func verifyActionMroot(block *pbcodec.Block) (issues []*BlockIntegrityIssue) {
+	type executedAction struct {
+		trxID   string
+		receipt *pbcodec.ActionReceipt
+	}
+
+	// The receipts of actions from failed transactions, like hard failed or expired ones and the
+	// failed attempt of a deferred transaction handled by `onerror`, are rolled back by nodeos,
+	// they are not part of the action merkle root.
+	var actions []executedAction
+	for _, trace := range block.UnfilteredTransactionTraces {
+		if trace.Receipt == nil || trace.Exception != nil {
+			continue
+		}
+
+		status := trace.Receipt.Status
+		if status != pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED && status != pbcodec.TransactionStatus_TRANSACTIONSTATUS_SOFTFAIL {
+			continue
+		}
+
+		for _, actionTrace := range trace.ActionTraces {
+			if actionTrace.Receipt != nil {
+				actions = append(actions, executedAction{trace.Id, actionTrace.Receipt})
+			}
+		}
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].receipt.GlobalSequence < actions[j].receipt.GlobalSequence
+	})
+
+	digests := make([][]byte, len(actions))
+	for i, action := range actions {
+		if i > 0 && action.receipt.GlobalSequence != actions[i-1].receipt.GlobalSequence+1 {
+			issues = append(issues, &BlockIntegrityIssue{IntegrityCheckActionReceipt, action.trxID, fmt.Sprintf("global sequence %d does not follow previous global sequence %d", action.receipt.GlobalSequence, actions[i-1].receipt.GlobalSequence)})
+		}
+
+		digest, err := ActionReceiptDigest(action.receipt)
+		if err != nil {
+			issues = append(issues, &BlockIntegrityIssue{IntegrityCheckActionReceipt, action.trxID, fmt.Sprintf("action with global sequence %d: %s", action.receipt.GlobalSequence, err)})
+			continue
+		}
+
+		digests[i] = digest
+	}
+
+	if len(issues) > 0 {
+		return issues
+	}
+
+	mroot := MerkleRoot(digests)
+	if !bytes.Equal(mroot, block.Header.ActionMroot) {
+		issues = append(issues, &BlockIntegrityIssue{
+			Check:   IntegrityCheckActionMroot,
+			Message: fmt.Sprintf("computed root %x from %d action receipts does not match header root %x", mroot, len(digests), block.Header.ActionMroot),
+		})
+	}
+
+	return issues
+}
+
+type authSequenceDigestable struct {
+	Account  eos.AccountName
+	Sequence uint64
+}
+
+// ActionReceiptDigest returns the digest of the receipt as it's merkelized in the block
+// `action_mroot`.
+func ActionReceiptDigest(receipt *pbcodec.ActionReceipt) ([]byte, error) {
+	actionDigest, err := hex.DecodeString(receipt.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid action digest: %w", err)
+	}
+
+	// Auth sequences are packed as a map ordered by account name value
+	authSequences := make([]authSequenceDigestable, len(receipt.AuthSequence))
+	authSequenceKeys := make([]uint64, len(receipt.AuthSequence))
+	for i, authSequence := range receipt.AuthSequence {
+		key, err := eos.StringToName(authSequence.AccountName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth sequence account %q: %w", authSequence.AccountName, err)
+		}
+
+		authSequences[i] = authSequenceDigestable{eos.AccountName(authSequence.AccountName), authSequence.Sequence}
+		authSequenceKeys[i] = key
+	}
+
+	sort.Sort(authSequencesByName{authSequences, authSequenceKeys})
+
+	return digestOf(
+		eos.AccountName(receipt.Receiver),
+		eos.Checksum256(actionDigest),
+		receipt.GlobalSequence,
+		receipt.RecvSequence,
+		authSequences,
+		eos.Varuint32(receipt.CodeSequence),
+		eos.Varuint32(receipt.AbiSequence),
+	)
+}
+
+type authSequencesByName struct {
+	sequences []authSequenceDigestable
+	keys      []uint64
+}
+
+func (s authSequencesByName) Len() int           { return len(s.sequences) }
+func (s authSequencesByName) Less(i, j int) bool { return s.keys[i] < s.keys[j] }
+func (s authSequencesByName) Swap(i, j int) {
+	s.sequences[i], s.sequences[j] = s.sequences[j], s.sequences[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+func verifyProducerSignature(block *pbcodec.Block, header *eos.BlockHeader) []*BlockIntegrityIssue {
+	issue := func(format string, args ...interface{}) []*BlockIntegrityIssue {
+		return []*BlockIntegrityIssue{{Check: IntegrityCheckProducerSignature, Message: fmt.Sprintf(format, args...)}}
+	}
+
+	if block.BlockrootMerkle == nil || block.PendingSchedule == nil {
+		return issue("block has no block root merkle or pending schedule, unable to compute signature digest")
+	}
+
+	signature, err := ecc.NewSignature(block.ProducerSignature)
+	if err != nil {
+		return issue("invalid producer signature %q: %s", block.ProducerSignature, err)
+	}
+
+	digest, err := blockSignatureDigest(block, header)
+	if err != nil {
+		return issue("unable to compute signature digest: %s", err)
+	}
+
+	signingKey, err := signature.PublicKey(digest)
+	if err != nil {
+		return issue("unable to recover signing key: %s", err)
+	}
+
+	expectedKeys := producerSigningKeys(block)
+	if len(expectedKeys) == 0 {
+		return issue("producer %s not found in active schedule", block.Header.Producer)
+	}
+
+	for _, expectedKey := range expectedKeys {
+		key, err := ecc.NewPublicKey(expectedKey)
+		if err != nil {
+			continue
+		}
+
+		if key.Curve == signingKey.Curve && bytes.Equal(key.Content, signingKey.Content) {
+			return nil
+		}
+	}
+
+	return issue("block signed by key %s which is not a signing key of producer %s", signingKey, block.Header.Producer)
+}
+
+// BlockSignatureDigest returns the digest signed by the producer of the block, its block root
+// merkle and pending schedule being required.
+func BlockSignatureDigest(block *pbcodec.Block) ([]byte, error) {
+	if block.Header == nil || block.BlockrootMerkle == nil || block.PendingSchedule == nil {
+		return nil, fmt.Errorf("block has no header, block root merkle or pending schedule")
+	}
+
+	return blockSignatureDigest(block, BlockHeaderToEOS(block.Header))
+}
+
+// blockSignatureDigest follows nodeos `block_header_state::sig_digest`, which hashes the header
+// digest with the block root merkle root, then the result with the pending schedule hash.
+func blockSignatureDigest(block *pbcodec.Block, header *eos.BlockHeader) ([]byte, error) {
+	headerDigest, err := digestOf(header)
+	if err != nil {
+		return nil, fmt.Errorf("header digest: %w", err)
+	}
+
+	blockrootMerkleRoot := make([]byte, sha256.Size)
+	if block.BlockrootMerkle.NodeCount > 0 && len(block.BlockrootMerkle.ActiveNodes) > 0 {
+		blockrootMerkleRoot = block.BlockrootMerkle.ActiveNodes[len(block.BlockrootMerkle.ActiveNodes)-1]
+	}
+
+	headerBmroot := sha256.Sum256(append(headerDigest, blockrootMerkleRoot...))
+	digest := sha256.Sum256(append(headerBmroot[:], block.PendingSchedule.ScheduleHash...))
+
+	return digest[:], nil
+}
+
+// producerSigningKeys returns the keys the producer of the block can sign with according to
+// the active schedule. The block signing authority validated by nodeos is also accepted since
+// the producer of a block promoting a new schedule is picked from the previous one.
+func producerSigningKeys(block *pbcodec.Block) (out []string) {
+	producer := block.Header.Producer
+
+	if block.ActiveScheduleV2 != nil {
+		for _, producerAuthority := range block.ActiveScheduleV2.Producers {
+			if producerAuthority.AccountName == producer {
+				out = append(out, blockSigningAuthorityKeys(producerAuthority.BlockSigningAuthority)...)
+			}
+		}
+	}
+
+	if block.ActiveScheduleV1 != nil {
+		for _, producerKey := range block.ActiveScheduleV1.Producers {
+			if producerKey.AccountName == producer {
+				out = append(out, producerKey.BlockSigningKey)
+			}
+		}
+	}
+
+	if block.BlockSigningKey != "" {
+		out = append(out, block.BlockSigningKey)
+	}
+
+	return append(out, blockSigningAuthorityKeys(block.ValidBlockSigningAuthorityV2)...)
+}
+
+func blockSigningAuthorityKeys(authority *pbcodec.BlockSigningAuthority) (out []string) {
+	if authority == nil {
+		return nil
+	}
+
+	if v0 := authority.GetV0(); v0 != nil {
+		for _, key := range v0.Keys {
+			out = append(out, key.PublicKey)
+		}
+	}
+
+	return out
+}
+
+// MerkleRoot follows nodeos `merkle` implementation, pairs being made canonical by clearing
+// the high bit of the left digest first byte and setting it on the right one.
+func MerkleRoot(digests [][]byte) []byte {
+	if len(digests) == 0 {
+		return make([]byte, sha256.Size)
+	}
+
+	nodes := make([][]byte, len(digests))
+	copy(nodes, digests)
+
+	for len(nodes) > 1 {
+		if len(nodes)%2 != 0 {
+			nodes = append(nodes, nodes[len(nodes)-1])
+		}
+
+		for i := 0; i < len(nodes)/2; i++ {
+			left := make([]byte, sha256.Size)
+			right := make([]byte, sha256.Size)
+			copy(left, nodes[2*i])
+			copy(right, nodes[2*i+1])
+
+			left[0] &= 0x7f
+			right[0] |= 0x80
+
+			node := sha256.Sum256(append(left, right...))
+			nodes[i] = node[:]
+		}
+
+		nodes = nodes[:len(nodes)/2]
+	}
+
+	return nodes[0]
+}
+
+// digestOf returns the sha256 digest of the EOSIO binary packing of the values, one after the other.
+func digestOf(values ...interface{}) ([]byte, error) {
+	hasher := sha256.New()
+	for _, value := range values {
+		data, err := eos.MarshalBinary(value)
+		if err != nil {
+			return nil, err
+		}
+
+		hasher.Write(data)
+	}
+
+	return hasher.Sum(nil), nil
+}
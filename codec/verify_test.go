@@ -0,0 +1,258 @@
+package codec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+	"time"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/eoscanada/eos-go/ecc"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBlock(t *testing.T) {
+	tests := []struct {
+		name           string
+		tamper         func(block *pbcodec.Block)
+		expectedChecks []string
+	}{
+		{
+			name:   "sound block",
+			tamper: func(block *pbcodec.Block) {},
+		},
+		{
+			name: "receipt cpu usage changed",
+			tamper: func(block *pbcodec.Block) {
+				block.UnfilteredTransactions[0].CpuUsageMicroSeconds++
+			},
+			expectedChecks: []string{IntegrityCheckTransactionReceipt},
+		},
+		{
+			name: "packed transaction changed",
+			tamper: func(block *pbcodec.Block) {
+				block.UnfilteredTransactions[0].PackedTransaction.PackedTransaction = []byte{0xff}
+			},
+			expectedChecks: []string{IntegrityCheckTransactionReceipt},
+		},
+		{
+			name: "receipt and trace changed",
+			tamper: func(block *pbcodec.Block) {
+				block.UnfilteredTransactions[0].NetUsageWords++
+				block.UnfilteredTransactionTraces[1].Receipt.NetUsageWords++
+			},
+			expectedChecks: []string{IntegrityCheckTransactionMroot},
+		},
+		{
+			name: "action receipt changed",
+			tamper: func(block *pbcodec.Block) {
+				block.UnfilteredTransactionTraces[1].ActionTraces[0].Receipt.RecvSequence++
+			},
+			expectedChecks: []string{IntegrityCheckActionMroot},
+		},
+		{
+			name: "action missing",
+			tamper: func(block *pbcodec.Block) {
+				trace := block.UnfilteredTransactionTraces[1]
+				trace.ActionTraces = trace.ActionTraces[1:]
+			},
+			expectedChecks: []string{IntegrityCheckActionReceipt},
+		},
+		{
+			name: "header changed",
+			tamper: func(block *pbcodec.Block) {
+				block.Header.Confirmed++
+			},
+			expectedChecks: []string{IntegrityCheckBlockID, IntegrityCheckProducerSignature},
+		},
+		{
+			name: "producer not in active schedule",
+			tamper: func(block *pbcodec.Block) {
+				block.ActiveScheduleV1.Producers[0].AccountName = "other"
+			},
+			expectedChecks: []string{IntegrityCheckProducerSignature},
+		},
+		{
+			name: "creation tree inconsistent",
+			tamper: func(block *pbcodec.Block) {
+				block.UnfilteredTransactionTraces[1].CreationTree = []*pbcodec.CreationFlatNode{{CreatorActionIndex: -1, ExecutionActionIndex: 9}}
+			},
+			expectedChecks: []string{IntegrityCheckCreationTree},
+		},
+		{
+			name: "filtered block",
+			tamper: func(block *pbcodec.Block) {
+				block.FilteringApplied = true
+				block.UnfilteredTransactions = nil
+				block.UnfilteredTransactionTraces = nil
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			block := verifiableBlock(t)
+			test.tamper(block)
+
+			var checks []string
+			for _, issue := range VerifyBlock(block) {
+				checks = append(checks, issue.Check)
+			}
+
+			assert.Equal(t, test.expectedChecks, checks)
+		})
+	}
+}
+
+func TestVerifyBlock_DeepMindLog(t *testing.T) {
+	cr := testFileConsoleReader(t, "testdata/deep-mind.dmlog")
+
+	blockCount := 0
+	for {
+		out, err := cr.Read()
+		if out != nil && out.(*pbcodec.Block) != nil {
+			block := out.(*pbcodec.Block)
+			blockCount++
+
+			assert.Empty(t, VerifyBlock(block), "block #%d", block.Number)
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+	}
+
+	assert.NotZero(t, blockCount)
+}
+
+func TestMerkleRoot(t *testing.T) {
+	left := sha256.Sum256([]byte("left"))
+	right := sha256.Sum256([]byte("right"))
+
+	assert.Equal(t, make([]byte, 32), MerkleRoot(nil))
+	assert.Equal(t, left[:], MerkleRoot([][]byte{left[:]}))
+
+	canonicalLeft := append([]byte{}, left[:]...)
+	canonicalLeft[0] &= 0x7f
+	canonicalRight := append([]byte{}, right[:]...)
+	canonicalRight[0] |= 0x80
+
+	pair := sha256.Sum256(append(canonicalLeft, canonicalRight...))
+	assert.Equal(t, pair[:], MerkleRoot([][]byte{left[:], right[:]}))
+
+	// An odd node is paired with itself
+	canonicalSelf := append([]byte{}, left[:]...)
+	canonicalSelf[0] |= 0x80
+	self := sha256.Sum256(append(canonicalLeft, canonicalSelf...))
+	assert.Equal(t, MerkleRoot([][]byte{pair[:], self[:]}), MerkleRoot([][]byte{left[:], right[:], left[:]}))
+}
+
+// verifiableBlock returns a block whose identifiers, merkle roots and signature are consistent
+// with its content, signed by the well-known EOSIO development key.
+func verifiableBlock(t *testing.T) *pbcodec.Block {
+	privateKey, err := ecc.NewPrivateKey("5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD3")
+	require.NoError(t, err)
+
+	blockTime, err := ptypes.TimestampProto(time.Date(2020, 7, 14, 13, 49, 4, 500000000, time.UTC))
+	require.NoError(t, err)
+
+	packedTrx := []byte{0x01, 0x02, 0x03}
+	trxID := sha256.Sum256(packedTrx)
+
+	block := &pbcodec.Block{
+		Number: 2,
+		Header: &pbcodec.BlockHeader{
+			Timestamp: blockTime,
+			Producer:  "eosio",
+			Previous:  "00000001" + hex.EncodeToString(make([]byte, 28)),
+		},
+		BlockrootMerkle: &pbcodec.BlockRootMerkle{NodeCount: 1, ActiveNodes: [][]byte{testDigest("blockroot")}},
+		PendingSchedule: &pbcodec.PendingProducerSchedule{ScheduleHash: testDigest("schedule")},
+		ActiveScheduleV1: &pbcodec.ProducerSchedule{
+			Producers: []*pbcodec.ProducerKey{{AccountName: "eosio", BlockSigningKey: privateKey.PublicKey().String()}},
+		},
+		UnfilteredTransactions: []*pbcodec.TransactionReceipt{
+			{
+				Id:                   hex.EncodeToString(trxID[:]),
+				Status:               pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED,
+				CpuUsageMicroSeconds: 100,
+				NetUsageWords:        12,
+				PackedTransaction:    &pbcodec.PackedTransaction{PackedTransaction: packedTrx},
+			},
+		},
+		UnfilteredTransactionTraces: []*pbcodec.TransactionTrace{
+			{
+				Id:           "onblock",
+				Receipt:      &pbcodec.TransactionReceiptHeader{Status: pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED},
+				ActionTraces: []*pbcodec.ActionTrace{verifiableActionTrace("eosio", 9)},
+			},
+			{
+				Id: hex.EncodeToString(trxID[:]),
+				Receipt: &pbcodec.TransactionReceiptHeader{
+					Status:               pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED,
+					CpuUsageMicroSeconds: 100,
+					NetUsageWords:        12,
+				},
+				ActionTraces: []*pbcodec.ActionTrace{
+					verifiableActionTrace("eosio.token", 10),
+					verifiableActionTrace("alice", 11),
+				},
+			},
+		},
+	}
+
+	trxDigest, err := TransactionReceiptDigest(block.UnfilteredTransactions[0])
+	require.NoError(t, err)
+	block.Header.TransactionMroot = MerkleRoot([][]byte{trxDigest})
+
+	var actionDigests [][]byte
+	for _, trace := range block.UnfilteredTransactionTraces {
+		for _, actionTrace := range trace.ActionTraces {
+			digest, err := ActionReceiptDigest(actionTrace.Receipt)
+			require.NoError(t, err)
+
+			actionDigests = append(actionDigests, digest)
+		}
+	}
+	block.Header.ActionMroot = MerkleRoot(actionDigests)
+
+	header := BlockHeaderToEOS(block.Header)
+	blockID, err := header.BlockID()
+	require.NoError(t, err)
+	block.Id = blockID.String()
+
+	digest, err := blockSignatureDigest(block, header)
+	require.NoError(t, err)
+
+	signature, err := privateKey.Sign(digest)
+	require.NoError(t, err)
+	block.ProducerSignature = signature.String()
+
+	return block
+}
+
+func verifiableActionTrace(receiver string, globalSequence uint64) *pbcodec.ActionTrace {
+	return &pbcodec.ActionTrace{
+		Receiver: receiver,
+		Receipt: &pbcodec.ActionReceipt{
+			Receiver:       receiver,
+			Digest:         hex.EncodeToString(testDigest(receiver)),
+			GlobalSequence: globalSequence,
+			RecvSequence:   globalSequence,
+			AuthSequence: []*pbcodec.AuthSequence{
+				{AccountName: "eosio", Sequence: globalSequence},
+				{AccountName: "alice", Sequence: 1},
+			},
+		},
+	}
+}
+
+func testDigest(content string) []byte {
+	digest := sha256.Sum256([]byte(content))
+	return digest[:]
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dstore"
+	"go.uber.org/zap"
+)
+
+// Exporter streams blocks into the normalized tables files of each requested format. Files
+// are partitioned by block range, the rows of blocks `[start, start + partitionSize)` being
+// written to `<table>/<start>-<stop>.<format>`, `stop` being inclusive, each file being
+// streamed to the output store while blocks are processed.
+//
+// Blocks must be processed in increasing block number order, a partition being completed
+// when the first block of the next one is processed or when the exporter is closed.
+type Exporter struct {
+	store         dstore.Store
+	formats       []Format
+	partitionSize uint64
+
+	partition *partition
+}
+
+func NewExporter(store dstore.Store, formats []Format, partitionSize uint64) *Exporter {
+	return &Exporter{
+		store:         store,
+		formats:       formats,
+		partitionSize: partitionSize,
+	}
+}
+
+func (e *Exporter) ProcessBlock(ctx context.Context, block *pbcodec.Block) error {
+	rows, err := BlockRows(block)
+	if err != nil {
+		return err
+	}
+
+	startBlock := block.Num() - (block.Num() % e.partitionSize)
+	if e.partition != nil && e.partition.startBlock != startBlock {
+		if err := e.closePartition(); err != nil {
+			return err
+		}
+	}
+
+	if e.partition == nil {
+		if e.partition, err = e.openPartition(ctx, startBlock); err != nil {
+			return err
+		}
+	}
+
+	return e.partition.write(rows)
+}
+
+// Close completes the partition being written, if any
+func (e *Exporter) Close() error {
+	if e.partition == nil {
+		return nil
+	}
+
+	return e.closePartition()
+}
+
+func (e *Exporter) openPartition(ctx context.Context, startBlock uint64) (*partition, error) {
+	p := &partition{startBlock: startBlock}
+	for _, table := range Tables {
+		for _, format := range e.formats {
+			filename := PartitionFilename(table, format, startBlock, startBlock+e.partitionSize-1)
+
+			file, err := openPartitionFile(ctx, e.store, filename, format, table)
+			if err != nil {
+				p.abort(err)
+				return nil, fmt.Errorf("unable to open partition file %s: %w", filename, err)
+			}
+
+			p.files = append(p.files, file)
+		}
+	}
+
+	zlog.Debug("opened export partition", zap.Uint64("start_block", startBlock), zap.Int("file_count", len(p.files)))
+	return p, nil
+}
+
+func (e *Exporter) closePartition() error {
+	p := e.partition
+	e.partition = nil
+
+	if err := p.close(); err != nil {
+		return fmt.Errorf("unable to complete partition starting at block #%d: %w", p.startBlock, err)
+	}
+
+	zlog.Info("exported partition", zap.Uint64("start_block", p.startBlock), zap.Uint64("block_count", p.blockCount))
+	return nil
+}
+
+// PartitionFilename returns the name, relative to the output store, of the file containing
+// the rows of the table for blocks `[startBlock, stopBlock]`.
+func PartitionFilename(table Table, format Format, startBlock, stopBlock uint64) string {
+	return fmt.Sprintf("%s/%010d-%010d.%s", table, startBlock, stopBlock, format)
+}
+
+type partition struct {
+	startBlock uint64
+	blockCount uint64
+	files      []*partitionFile
+}
+
+func (p *partition) write(rows *Rows) error {
+	p.blockCount++
+
+	for _, file := range p.files {
+		for _, row := range rows.Of(file.table) {
+			if err := file.writer.Write(row); err != nil {
+				return fmt.Errorf("unable to write %s row: %w", file.table, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *partition) close() error {
+	for i, file := range p.files {
+		if err := file.close(); err != nil {
+			p.files = p.files[i+1:]
+			p.abort(err)
+			return fmt.Errorf("file %s: %w", file.filename, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *partition) abort(err error) {
+	for _, file := range p.files {
+		file.abort(err)
+	}
+}
+
+// partitionFile is a table file being streamed to the output store through a pipe
+type partitionFile struct {
+	filename string
+	table    Table
+	writer   tableWriter
+
+	pipe            *io.PipeWriter
+	writeObjectDone chan error
+}
+
+func openPartitionFile(ctx context.Context, store dstore.Store, filename string, format Format, table Table) (*partitionFile, error) {
+	readPipe, writePipe := io.Pipe()
+
+	file := &partitionFile{
+		filename:        filename,
+		table:           table,
+		pipe:            writePipe,
+		writeObjectDone: make(chan error, 1),
+	}
+
+	go func() {
+		err := store.WriteObject(ctx, filename, readPipe)
+
+		// Unblocks the writer if the store stopped reading before the end
+		readPipe.CloseWithError(fmt.Errorf("store stopped reading: %v", err))
+		file.writeObjectDone <- err
+	}()
+
+	writer, err := newTableWriter(format, table, writePipe)
+	if err != nil {
+		file.abort(err)
+		return nil, err
+	}
+
+	file.writer = writer
+	return file, nil
+}
+
+func (f *partitionFile) close() error {
+	if err := f.writer.Close(); err != nil {
+		f.abort(err)
+		return err
+	}
+
+	f.pipe.Close()
+	return <-f.writeObjectDone
+}
+
+func (f *partitionFile) abort(err error) {
+	f.pipe.CloseWithError(err)
+	<-f.writeObjectDone
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dfuse-io/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporter(t *testing.T) {
+	var lock sync.Mutex
+	files := map[string][]byte{}
+	store := dstore.NewMockStore(func(base string, f io.Reader) error {
+		content, err := ioutil.ReadAll(f)
+		if err != nil {
+			return err
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		files[base] = content
+		return nil
+	})
+
+	exporter := NewExporter(store, []Format{FormatJSONLines, FormatParquet}, 10)
+
+	ctx := context.Background()
+	for _, blockNum := range []uint32{8, 9, 10, 11} {
+		require.NoError(t, exporter.ProcessBlock(ctx, testBlock(t, blockNum)))
+	}
+	require.NoError(t, exporter.Close())
+
+	assert.Len(t, files, 2*2*len(Tables))
+
+	blocks := files["blocks/0000000000-0000000009.jsonl"]
+	lines := strings.Split(strings.TrimSpace(string(blocks)), "\n")
+	require.Len(t, lines, 2)
+
+	row := &BlockRow{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), row))
+	assert.Equal(t, uint64(9), row.BlockNum)
+
+	actionTraces := files["action_traces/0000000010-0000000019.jsonl"]
+	assert.Len(t, strings.Split(strings.TrimSpace(string(actionTraces)), "\n"), 4)
+
+	for _, table := range Tables {
+		content := files[PartitionFilename(table, FormatParquet, 10, 19)]
+		assert.True(t, bytes.HasPrefix(content, []byte("PAR1")), "parquet file of table %s", table)
+		assert.True(t, bytes.HasSuffix(content, []byte("PAR1")), "parquet file of table %s", table)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("parquet")
+	require.NoError(t, err)
+	assert.Equal(t, FormatParquet, format)
+
+	_, err = ParseFormat("csv")
+	assert.EqualError(t, err, `unknown export format "csv", valid formats are "jsonl" and "parquet"`)
+}
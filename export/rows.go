@@ -0,0 +1,381 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// Table is the name of a normalized table produced by the exporter
+type Table string
+
+const (
+	TableBlocks       Table = "blocks"
+	TableTransactions Table = "transactions"
+	TableActionTraces Table = "action_traces"
+	TableDBOps        Table = "db_ops"
+	TableRAMOps       Table = "ram_ops"
+	TablePermOps      Table = "perm_ops"
+)
+
+// Tables lists all the tables produced by the exporter, in the order their files are written
+var Tables = []Table{TableBlocks, TableTransactions, TableActionTraces, TableDBOps, TableRAMOps, TablePermOps}
+
+type BlockRow struct {
+	BlockNum                 uint64 `json:"block_num" parquet:"name=block_num, type=UINT_64"`
+	BlockID                  string `json:"block_id" parquet:"name=block_id, type=UTF8"`
+	PreviousID               string `json:"previous_id" parquet:"name=previous_id, type=UTF8"`
+	BlockTime                int64  `json:"block_time" parquet:"name=block_time, type=TIMESTAMP_MILLIS"`
+	Producer                 string `json:"producer" parquet:"name=producer, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	DposIrreversibleBlockNum uint32 `json:"dpos_irreversible_block_num" parquet:"name=dpos_irreversible_block_num, type=UINT_32"`
+	TransactionCount         uint32 `json:"transaction_count" parquet:"name=transaction_count, type=UINT_32"`
+	TransactionTraceCount    uint32 `json:"transaction_trace_count" parquet:"name=transaction_trace_count, type=UINT_32"`
+	InputActionCount         uint32 `json:"input_action_count" parquet:"name=input_action_count, type=UINT_32"`
+	TotalActionCount         uint32 `json:"total_action_count" parquet:"name=total_action_count, type=UINT_32"`
+	FilteringApplied         bool   `json:"filtering_applied" parquet:"name=filtering_applied, type=BOOLEAN"`
+}
+
+type TransactionRow struct {
+	BlockNum             uint64 `json:"block_num" parquet:"name=block_num, type=UINT_64"`
+	BlockTime            int64  `json:"block_time" parquet:"name=block_time, type=TIMESTAMP_MILLIS"`
+	TransactionID        string `json:"trx_id" parquet:"name=trx_id, type=UTF8"`
+	Index                uint64 `json:"index" parquet:"name=index, type=UINT_64"`
+	Status               string `json:"status" parquet:"name=status, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	CPUUsageMicroSeconds uint32 `json:"cpu_usage_micro_seconds" parquet:"name=cpu_usage_micro_seconds, type=UINT_32"`
+	NetUsageWords        uint32 `json:"net_usage_words" parquet:"name=net_usage_words, type=UINT_32"`
+	Elapsed              int64  `json:"elapsed" parquet:"name=elapsed, type=INT64"`
+	Scheduled            bool   `json:"scheduled" parquet:"name=scheduled, type=BOOLEAN"`
+	ActionTraceCount     uint32 `json:"action_trace_count" parquet:"name=action_trace_count, type=UINT_32"`
+	Exception            string `json:"exception" parquet:"name=exception, type=UTF8"`
+}
+
+type ActionTraceRow struct {
+	BlockNum                               uint64 `json:"block_num" parquet:"name=block_num, type=UINT_64"`
+	BlockTime                              int64  `json:"block_time" parquet:"name=block_time, type=TIMESTAMP_MILLIS"`
+	TransactionID                          string `json:"trx_id" parquet:"name=trx_id, type=UTF8"`
+	ExecutionIndex                         uint32 `json:"execution_index" parquet:"name=execution_index, type=UINT_32"`
+	ActionOrdinal                          uint32 `json:"action_ordinal" parquet:"name=action_ordinal, type=UINT_32"`
+	CreatorActionOrdinal                   uint32 `json:"creator_action_ordinal" parquet:"name=creator_action_ordinal, type=UINT_32"`
+	ClosestUnnotifiedAncestorActionOrdinal uint32 `json:"closest_unnotified_ancestor_action_ordinal" parquet:"name=closest_unnotified_ancestor_action_ordinal, type=UINT_32"`
+	GlobalSequence                         uint64 `json:"global_sequence" parquet:"name=global_sequence, type=UINT_64"`
+	Receiver                               string `json:"receiver" parquet:"name=receiver, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Account                                string `json:"account" parquet:"name=account, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Name                                   string `json:"name" parquet:"name=name, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Authorizations                         string `json:"authorizations" parquet:"name=authorizations, type=UTF8"`
+	JSONData                               string `json:"json_data" parquet:"name=json_data, type=UTF8"`
+	HexData                                string `json:"hex_data" parquet:"name=hex_data, type=UTF8"`
+	Console                                string `json:"console" parquet:"name=console, type=UTF8"`
+	Elapsed                                int64  `json:"elapsed" parquet:"name=elapsed, type=INT64"`
+	IsInput                                bool   `json:"is_input" parquet:"name=is_input, type=BOOLEAN"`
+}
+
+type DBOpRow struct {
+	BlockNum      uint64 `json:"block_num" parquet:"name=block_num, type=UINT_64"`
+	BlockTime     int64  `json:"block_time" parquet:"name=block_time, type=TIMESTAMP_MILLIS"`
+	TransactionID string `json:"trx_id" parquet:"name=trx_id, type=UTF8"`
+	ActionIndex   uint32 `json:"action_index" parquet:"name=action_index, type=UINT_32"`
+	Operation     string `json:"operation" parquet:"name=operation, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Code          string `json:"code" parquet:"name=code, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Scope         string `json:"scope" parquet:"name=scope, type=UTF8"`
+	TableName     string `json:"table_name" parquet:"name=table_name, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	PrimaryKey    string `json:"primary_key" parquet:"name=primary_key, type=UTF8"`
+	OldPayer      string `json:"old_payer" parquet:"name=old_payer, type=UTF8"`
+	NewPayer      string `json:"new_payer" parquet:"name=new_payer, type=UTF8"`
+	OldHexData    string `json:"old_hex_data" parquet:"name=old_hex_data, type=UTF8"`
+	NewHexData    string `json:"new_hex_data" parquet:"name=new_hex_data, type=UTF8"`
+	OldJSONData   string `json:"old_json_data" parquet:"name=old_json_data, type=UTF8"`
+	NewJSONData   string `json:"new_json_data" parquet:"name=new_json_data, type=UTF8"`
+}
+
+type RAMOpRow struct {
+	BlockNum      uint64 `json:"block_num" parquet:"name=block_num, type=UINT_64"`
+	BlockTime     int64  `json:"block_time" parquet:"name=block_time, type=TIMESTAMP_MILLIS"`
+	TransactionID string `json:"trx_id" parquet:"name=trx_id, type=UTF8"`
+	ActionIndex   uint32 `json:"action_index" parquet:"name=action_index, type=UINT_32"`
+	Operation     string `json:"operation" parquet:"name=operation, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Namespace     string `json:"namespace" parquet:"name=namespace, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Action        string `json:"action" parquet:"name=action, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	UniqueKey     string `json:"unique_key" parquet:"name=unique_key, type=UTF8"`
+	Payer         string `json:"payer" parquet:"name=payer, type=UTF8"`
+	Delta         int64  `json:"delta" parquet:"name=delta, type=INT64"`
+	Usage         uint64 `json:"usage" parquet:"name=usage, type=UINT_64"`
+}
+
+type PermOpRow struct {
+	BlockNum      uint64 `json:"block_num" parquet:"name=block_num, type=UINT_64"`
+	BlockTime     int64  `json:"block_time" parquet:"name=block_time, type=TIMESTAMP_MILLIS"`
+	TransactionID string `json:"trx_id" parquet:"name=trx_id, type=UTF8"`
+	ActionIndex   uint32 `json:"action_index" parquet:"name=action_index, type=UINT_32"`
+	Operation     string `json:"operation" parquet:"name=operation, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	PermissionID  uint64 `json:"permission_id" parquet:"name=permission_id, type=UINT_64"`
+	Owner         string `json:"owner" parquet:"name=owner, type=UTF8"`
+	Name          string `json:"name" parquet:"name=name, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	OldParentID   uint64 `json:"old_parent_id" parquet:"name=old_parent_id, type=UINT_64"`
+	NewParentID   uint64 `json:"new_parent_id" parquet:"name=new_parent_id, type=UINT_64"`
+	OldAuthority  string `json:"old_authority" parquet:"name=old_authority, type=UTF8"`
+	NewAuthority  string `json:"new_authority" parquet:"name=new_authority, type=UTF8"`
+}
+
+// Rows are the normalized rows of a single block, by table
+type Rows struct {
+	Blocks       []*BlockRow
+	Transactions []*TransactionRow
+	ActionTraces []*ActionTraceRow
+	DBOps        []*DBOpRow
+	RAMOps       []*RAMOpRow
+	PermOps      []*PermOpRow
+}
+
+// Of returns the rows of the given table
+func (r *Rows) Of(table Table) (out []interface{}) {
+	switch table {
+	case TableBlocks:
+		for _, row := range r.Blocks {
+			out = append(out, row)
+		}
+	case TableTransactions:
+		for _, row := range r.Transactions {
+			out = append(out, row)
+		}
+	case TableActionTraces:
+		for _, row := range r.ActionTraces {
+			out = append(out, row)
+		}
+	case TableDBOps:
+		for _, row := range r.DBOps {
+			out = append(out, row)
+		}
+	case TableRAMOps:
+		for _, row := range r.RAMOps {
+			out = append(out, row)
+		}
+	case TablePermOps:
+		for _, row := range r.PermOps {
+			out = append(out, row)
+		}
+	}
+
+	return
+}
+
+func newRowOf(table Table) interface{} {
+	switch table {
+	case TableBlocks:
+		return new(BlockRow)
+	case TableTransactions:
+		return new(TransactionRow)
+	case TableActionTraces:
+		return new(ActionTraceRow)
+	case TableDBOps:
+		return new(DBOpRow)
+	case TableRAMOps:
+		return new(RAMOpRow)
+	case TablePermOps:
+		return new(PermOpRow)
+	}
+
+	panic(fmt.Errorf("unknown table %q", table))
+}
+
+// BlockRows normalizes the block into the rows of each table. Only the transaction traces
+// kept by filtering are considered when it has been applied on the block. The JSON form of
+// actions and database rows is included when it was decoded by the ABI decoder.
+func BlockRows(block *pbcodec.Block) (*Rows, error) {
+	blockTime, err := timestampMillis(block.Header.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block %s time: %w", block.AsRef(), err)
+	}
+
+	traces := block.TransactionTraces()
+	rows := &Rows{
+		Blocks: []*BlockRow{{
+			BlockNum:                 block.Num(),
+			BlockID:                  block.Id,
+			PreviousID:               block.PreviousID(),
+			BlockTime:                blockTime,
+			Producer:                 block.Header.Producer,
+			DposIrreversibleBlockNum: block.DposIrreversibleBlocknum,
+			TransactionCount:         uint32(len(block.Transactions())),
+			TransactionTraceCount:    uint32(len(traces)),
+			FilteringApplied:         block.FilteringApplied,
+		}},
+	}
+
+	for _, trace := range traces {
+		rows.Transactions = append(rows.Transactions, &TransactionRow{
+			BlockNum:             block.Num(),
+			BlockTime:            blockTime,
+			TransactionID:        trace.Id,
+			Index:                trace.Index,
+			Status:               enumName(trace.Receipt.GetStatus(), "TRANSACTIONSTATUS_"),
+			CPUUsageMicroSeconds: trace.Receipt.GetCpuUsageMicroSeconds(),
+			NetUsageWords:        trace.Receipt.GetNetUsageWords(),
+			Elapsed:              trace.Elapsed,
+			Scheduled:            trace.Scheduled,
+			ActionTraceCount:     uint32(len(trace.ActionTraces)),
+			Exception:            trace.Exception.GetMessage(),
+		})
+
+		for _, actionTrace := range trace.ActionTraces {
+			rows.Blocks[0].TotalActionCount++
+			if actionTrace.IsInput() {
+				rows.Blocks[0].InputActionCount++
+			}
+
+			rows.ActionTraces = append(rows.ActionTraces, actionTraceRow(block.Num(), blockTime, trace.Id, actionTrace))
+		}
+
+		for _, op := range trace.DbOps {
+			rows.DBOps = append(rows.DBOps, &DBOpRow{
+				BlockNum:      block.Num(),
+				BlockTime:     blockTime,
+				TransactionID: trace.Id,
+				ActionIndex:   op.ActionIndex,
+				Operation:     enumName(op.Operation, "OPERATION_"),
+				Code:          op.Code,
+				Scope:         op.Scope,
+				TableName:     op.TableName,
+				PrimaryKey:    op.PrimaryKey,
+				OldPayer:      op.OldPayer,
+				NewPayer:      op.NewPayer,
+				OldHexData:    hex.EncodeToString(op.OldData),
+				NewHexData:    hex.EncodeToString(op.NewData),
+				OldJSONData:   op.OldDataJson,
+				NewJSONData:   op.NewDataJson,
+			})
+		}
+
+		for _, op := range trace.RamOps {
+			rows.RAMOps = append(rows.RAMOps, &RAMOpRow{
+				BlockNum:      block.Num(),
+				BlockTime:     blockTime,
+				TransactionID: trace.Id,
+				ActionIndex:   op.ActionIndex,
+				Operation:     enumName(op.Operation, "OPERATION_"),
+				Namespace:     enumName(op.Namespace, "NAMESPACE_"),
+				Action:        enumName(op.Action, "ACTION_"),
+				UniqueKey:     op.UniqueKey,
+				Payer:         op.Payer,
+				Delta:         op.Delta,
+				Usage:         op.Usage,
+			})
+		}
+
+		for _, op := range trace.PermOps {
+			row, err := permOpRow(block.Num(), blockTime, trace.Id, op)
+			if err != nil {
+				return nil, fmt.Errorf("perm op of transaction %s: %w", trace.Id, err)
+			}
+
+			rows.PermOps = append(rows.PermOps, row)
+		}
+	}
+
+	return rows, nil
+}
+
+func actionTraceRow(blockNum uint64, blockTime int64, trxID string, actionTrace *pbcodec.ActionTrace) *ActionTraceRow {
+	action := actionTrace.Action
+	authorizations := make([]string, len(action.GetAuthorization()))
+	for i, authorization := range action.GetAuthorization() {
+		authorizations[i] = authorization.Authorization()
+	}
+
+	return &ActionTraceRow{
+		BlockNum:                               blockNum,
+		BlockTime:                              blockTime,
+		TransactionID:                          trxID,
+		ExecutionIndex:                         actionTrace.ExecutionIndex,
+		ActionOrdinal:                          actionTrace.ActionOrdinal,
+		CreatorActionOrdinal:                   actionTrace.CreatorActionOrdinal,
+		ClosestUnnotifiedAncestorActionOrdinal: actionTrace.ClosestUnnotifiedAncestorActionOrdinal,
+		GlobalSequence:                         actionTrace.Receipt.GetGlobalSequence(),
+		Receiver:                               actionTrace.Receiver,
+		Account:                                action.GetAccount(),
+		Name:                                   action.GetName(),
+		Authorizations:                         strings.Join(authorizations, ","),
+		JSONData:                               action.GetJsonData(),
+		HexData:                                hex.EncodeToString(action.GetRawData()),
+		Console:                                actionTrace.Console,
+		Elapsed:                                actionTrace.Elapsed,
+		IsInput:                                actionTrace.IsInput(),
+	}
+}
+
+func permOpRow(blockNum uint64, blockTime int64, trxID string, op *pbcodec.PermOp) (*PermOpRow, error) {
+	row := &PermOpRow{
+		BlockNum:      blockNum,
+		BlockTime:     blockTime,
+		TransactionID: trxID,
+		ActionIndex:   op.ActionIndex,
+		Operation:     enumName(op.Operation, "OPERATION_"),
+	}
+
+	var err error
+	if perm := op.OldPerm; perm != nil {
+		row.PermissionID, row.Owner, row.Name = perm.Id, perm.Owner, perm.Name
+		row.OldParentID = perm.ParentId
+		if row.OldAuthority, err = authorityJSON(perm.Authority); err != nil {
+			return nil, err
+		}
+	}
+
+	if perm := op.NewPerm; perm != nil {
+		row.PermissionID, row.Owner, row.Name = perm.Id, perm.Owner, perm.Name
+		row.NewParentID = perm.ParentId
+		if row.NewAuthority, err = authorityJSON(perm.Authority); err != nil {
+			return nil, err
+		}
+	}
+
+	return row, nil
+}
+
+func authorityJSON(authority *pbcodec.Authority) (string, error) {
+	if authority == nil {
+		return "", nil
+	}
+
+	data, err := json.Marshal(authority)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal authority: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func timestampMillis(value *timestamp.Timestamp) (int64, error) {
+	if value == nil {
+		return 0, nil
+	}
+
+	t, err := ptypes.Timestamp(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.UnixNano() / 1e6, nil
+}
+
+func enumName(value fmt.Stringer, prefix string) string {
+	return strings.TrimPrefix(value.String(), prefix)
+}
@@ -0,0 +1,209 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockRows(t *testing.T) {
+	block := testBlock(t, 12)
+
+	rows, err := BlockRows(block)
+	require.NoError(t, err)
+
+	blockTime := int64(1594734544500)
+
+	assert.Equal(t, []*BlockRow{{
+		BlockNum:              12,
+		BlockID:               "0000000c" + "aa",
+		PreviousID:            "0000000b" + "aa",
+		BlockTime:             blockTime,
+		Producer:              "eosio",
+		TransactionCount:      0,
+		TransactionTraceCount: 1,
+		InputActionCount:      1,
+		TotalActionCount:      2,
+	}}, rows.Blocks)
+
+	assert.Equal(t, []*TransactionRow{{
+		BlockNum:             12,
+		BlockTime:            blockTime,
+		TransactionID:        "trx1",
+		Status:               "EXECUTED",
+		CPUUsageMicroSeconds: 100,
+		NetUsageWords:        12,
+		ActionTraceCount:     2,
+	}}, rows.Transactions)
+
+	require.Len(t, rows.ActionTraces, 2)
+	assert.Equal(t, &ActionTraceRow{
+		BlockNum:       12,
+		BlockTime:      blockTime,
+		TransactionID:  "trx1",
+		ActionOrdinal:  1,
+		GlobalSequence: 10,
+		Receiver:       "eosio.token",
+		Account:        "eosio.token",
+		Name:           "transfer",
+		Authorizations: "alice@active",
+		JSONData:       `{"from":"alice","to":"bob"}`,
+		HexData:        "0102",
+		IsInput:        true,
+	}, rows.ActionTraces[0])
+	assert.Equal(t, "bob", rows.ActionTraces[1].Receiver)
+	assert.False(t, rows.ActionTraces[1].IsInput)
+
+	assert.Equal(t, []*DBOpRow{{
+		BlockNum:      12,
+		BlockTime:     blockTime,
+		TransactionID: "trx1",
+		Operation:     "UPDATE",
+		Code:          "eosio.token",
+		Scope:         "alice",
+		TableName:     "accounts",
+		PrimaryKey:    "EOS",
+		OldPayer:      "alice",
+		NewPayer:      "alice",
+		OldHexData:    "01",
+		NewHexData:    "02",
+		OldJSONData:   `{"balance":"1.0000 EOS"}`,
+		NewJSONData:   `{"balance":"0.5000 EOS"}`,
+	}}, rows.DBOps)
+
+	assert.Equal(t, []*RAMOpRow{{
+		BlockNum:      12,
+		BlockTime:     blockTime,
+		TransactionID: "trx1",
+		Operation:     "CREATE_TABLE",
+		Namespace:     "TABLE",
+		Action:        "ADD",
+		UniqueKey:     "eosio.token:alice:accounts",
+		Payer:         "alice",
+		Delta:         112,
+		Usage:         1024,
+	}}, rows.RAMOps)
+
+	assert.Equal(t, []*PermOpRow{{
+		BlockNum:      12,
+		BlockTime:     blockTime,
+		TransactionID: "trx1",
+		Operation:     "INSERT",
+		PermissionID:  4,
+		Owner:         "alice",
+		Name:          "active",
+		NewParentID:   3,
+		NewAuthority:  `{"threshold":1}`,
+	}}, rows.PermOps)
+
+	assert.Len(t, rows.Of(TableActionTraces), 2)
+	assert.Len(t, rows.Of(TableBlocks), 1)
+}
+
+func testBlock(t *testing.T, blockNum uint32) *pbcodec.Block {
+	blockTime, err := ptypes.TimestampProto(time.Date(2020, 7, 14, 13, 49, 4, 500000000, time.UTC))
+	require.NoError(t, err)
+
+	return &pbcodec.Block{
+		Id:     blockID(blockNum),
+		Number: blockNum,
+		Header: &pbcodec.BlockHeader{
+			Timestamp: blockTime,
+			Producer:  "eosio",
+			Previous:  blockID(blockNum - 1),
+		},
+		UnfilteredTransactionTraces: []*pbcodec.TransactionTrace{
+			{
+				Id: "trx1",
+				Receipt: &pbcodec.TransactionReceiptHeader{
+					Status:               pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED,
+					CpuUsageMicroSeconds: 100,
+					NetUsageWords:        12,
+				},
+				ActionTraces: []*pbcodec.ActionTrace{
+					{
+						Receiver:      "eosio.token",
+						Receipt:       &pbcodec.ActionReceipt{GlobalSequence: 10},
+						ActionOrdinal: 1,
+						Action: &pbcodec.Action{
+							Account:       "eosio.token",
+							Name:          "transfer",
+							Authorization: []*pbcodec.PermissionLevel{{Actor: "alice", Permission: "active"}},
+							JsonData:      `{"from":"alice","to":"bob"}`,
+							RawData:       []byte{0x01, 0x02},
+						},
+					},
+					{
+						Receiver:             "bob",
+						Receipt:              &pbcodec.ActionReceipt{GlobalSequence: 11},
+						ActionOrdinal:        2,
+						CreatorActionOrdinal: 1,
+						ExecutionIndex:       1,
+						Action:               &pbcodec.Action{Account: "eosio.token", Name: "transfer"},
+					},
+				},
+				DbOps: []*pbcodec.DBOp{
+					{
+						Operation:   pbcodec.DBOp_OPERATION_UPDATE,
+						Code:        "eosio.token",
+						Scope:       "alice",
+						TableName:   "accounts",
+						PrimaryKey:  "EOS",
+						OldPayer:    "alice",
+						NewPayer:    "alice",
+						OldData:     []byte{0x01},
+						NewData:     []byte{0x02},
+						OldDataJson: `{"balance":"1.0000 EOS"}`,
+						NewDataJson: `{"balance":"0.5000 EOS"}`,
+					},
+				},
+				RamOps: []*pbcodec.RAMOp{
+					{
+						Operation: pbcodec.RAMOp_OPERATION_CREATE_TABLE,
+						Namespace: pbcodec.RAMOp_NAMESPACE_TABLE,
+						Action:    pbcodec.RAMOp_ACTION_ADD,
+						UniqueKey: "eosio.token:alice:accounts",
+						Payer:     "alice",
+						Delta:     112,
+						Usage:     1024,
+					},
+				},
+				PermOps: []*pbcodec.PermOp{
+					{
+						Operation: pbcodec.PermOp_OPERATION_INSERT,
+						NewPerm: &pbcodec.PermissionObject{
+							Id:        4,
+							ParentId:  3,
+							Owner:     "alice",
+							Name:      "active",
+							Authority: &pbcodec.Authority{Threshold: 1},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func blockID(blockNum uint32) string {
+	return fmt.Sprintf("%08x", blockNum) + "aa"
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// Format is an output file format of the exporter
+type Format string
+
+const (
+	FormatJSONLines Format = "jsonl"
+	FormatParquet   Format = "parquet"
+)
+
+func ParseFormat(in string) (Format, error) {
+	switch Format(in) {
+	case FormatJSONLines, FormatParquet:
+		return Format(in), nil
+	}
+
+	return "", fmt.Errorf("unknown export format %q, valid formats are %q and %q", in, FormatJSONLines, FormatParquet)
+}
+
+// tableWriter writes the rows of a single table to an output file
+type tableWriter interface {
+	Write(row interface{}) error
+
+	// Close flushes remaining rows and the format footer, if any, it does not close the
+	// underlying writer.
+	Close() error
+}
+
+func newTableWriter(format Format, table Table, w io.Writer) (tableWriter, error) {
+	switch format {
+	case FormatJSONLines:
+		return &jsonLinesWriter{encoder: json.NewEncoder(w)}, nil
+
+	case FormatParquet:
+		parquetWriter, err := writer.NewParquetWriter(&parquetStreamFile{Writer: w}, newRowOf(table), 1)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create parquet writer: %w", err)
+		}
+		parquetWriter.CompressionType = parquet.CompressionCodec_SNAPPY
+
+		return &parquetTableWriter{writer: parquetWriter}, nil
+	}
+
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+type jsonLinesWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *jsonLinesWriter) Write(row interface{}) error {
+	return w.encoder.Encode(row)
+}
+
+func (w *jsonLinesWriter) Close() error {
+	return nil
+}
+
+// parquetStreamFile adapts an `io.Writer` to the `source.ParquetFile` interface required by
+// the parquet writer, which only ever writes sequentially to its file.
+type parquetStreamFile struct {
+	io.Writer
+}
+
+func (f *parquetStreamFile) Read(p []byte) (int, error) {
+	return 0, errors.New("parquet stream file is write only")
+}
+
+func (f *parquetStreamFile) Seek(offset int64, whence int) (int64, error) {
+	return 0, errors.New("parquet stream file is not seekable")
+}
+
+func (f *parquetStreamFile) Open(name string) (source.ParquetFile, error) {
+	return nil, errors.New("parquet stream file cannot be re-opened")
+}
+
+func (f *parquetStreamFile) Create(name string) (source.ParquetFile, error) {
+	return nil, errors.New("parquet stream file cannot be re-created")
+}
+
+// Close does nothing, the underlying writer is owned by the caller
+func (f *parquetStreamFile) Close() error {
+	return nil
+}
+
+type parquetTableWriter struct {
+	writer *writer.ParquetWriter
+}
+
+func (w *parquetTableWriter) Write(row interface{}) error {
+	return w.writer.Write(row)
+}
+
+func (w *parquetTableWriter) Close() error {
+	return w.writer.WriteStop()
+}
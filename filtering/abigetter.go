@@ -0,0 +1,56 @@
+package filtering
+
+import (
+	"context"
+	"net/http"
+
+	fluxdb "github.com/dfuse-io/dfuse-eosio/fluxdb-client"
+	"github.com/eoscanada/eos-go"
+	"go.uber.org/zap"
+)
+
+// NewFluxDBABIGetter returns an ABIGetter retrieving the ABI of an account, as of the head block,
+// from the fluxdb instance serving HTTP requests on `fluxdbAddr`, like `http://localhost:13029`.
+func NewFluxDBABIGetter(fluxdbAddr string) ABIGetter {
+	client := fluxdb.NewClient(fluxdbAddr, http.DefaultTransport)
+
+	return func(account string) (*eos.ABI, error) {
+		zlog.Debug("fetching abi from fluxdb", zap.String("fluxdb_addr", fluxdbAddr), zap.String("account", account))
+		response, err := client.GetABI(context.Background(), 0, eos.AccountName(account))
+		if err != nil {
+			return nil, err
+		}
+
+		return response.ABI, nil
+	}
+}
+
+// FirstABIGetter returns an ABIGetter trying each of the `getters` in order, returning the first
+// ABI found. A `nil` getter is skipped, and `nil` is returned when none of them is set.
+func FirstABIGetter(getters ...ABIGetter) ABIGetter {
+	var actualGetters []ABIGetter
+	for _, getter := range getters {
+		if getter != nil {
+			actualGetters = append(actualGetters, getter)
+		}
+	}
+
+	if len(actualGetters) == 0 {
+		return nil
+	}
+
+	return func(account string) (*eos.ABI, error) {
+		for _, getter := range actualGetters {
+			abi, err := getter(account)
+			if err != nil {
+				return nil, err
+			}
+
+			if abi != nil {
+				return abi, nil
+			}
+		}
+
+		return nil, nil
+	}
+}
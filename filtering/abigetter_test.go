@@ -0,0 +1,50 @@
+package filtering
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eoscanada/eos-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstABIGetter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v0/state/abi", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("json"))
+
+		if r.URL.Query().Get("account") == "eosio.token" {
+			w.Write([]byte(`{"block_num":10,"account":"eosio.token","abi":{"version":"eosio::abi/1.1","actions":[{"name":"transfer","type":"transfer"}]}}`))
+			return
+		}
+
+		w.Write([]byte(`{"block_num":10,"account":"unknown"}`))
+	}))
+	defer server.Close()
+
+	cacheABI := &eos.ABI{Version: "cache"}
+	getABI := FirstABIGetter(nil, func(account string) (*eos.ABI, error) {
+		if account == "eosio" {
+			return cacheABI, nil
+		}
+
+		return nil, nil
+	}, NewFluxDBABIGetter(server.URL))
+
+	abi, err := getABI("eosio")
+	require.NoError(t, err)
+	assert.Equal(t, cacheABI, abi)
+
+	abi, err = getABI("eosio.token")
+	require.NoError(t, err)
+	require.NotNil(t, abi)
+	assert.Equal(t, eos.ActionName("transfer"), abi.Actions[0].Name)
+
+	abi, err = getABI("unknown")
+	require.NoError(t, err)
+	assert.Nil(t, abi)
+
+	assert.Nil(t, FirstABIGetter(nil, nil))
+}
@@ -20,11 +20,14 @@ import (
 	"strings"
 
 	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/proto"
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/operators"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/interpreter"
 	"go.uber.org/zap"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
 )
 
 type CELFilter struct {
@@ -32,48 +35,44 @@ type CELFilter struct {
 	code          string
 	program       cel.Program
 	valueWhenNoop bool
+
+	// usesDB is set when the program references `db`, in which case the program is evaluated
+	// once per database operation of the action, see `matchDBOps`
+	usesDB bool
+
+	// usesSigners is set when the program references `trx.signers`
+	usesSigners bool
+
+	// fastPath is used instead of `program` when set, see `fastPathFilter`
+	fastPath *fastPathFilter
 }
 
 func (f *CELFilter) IsNoop() bool {
 	return f.program == nil
 }
 
+var includeNoopPrograms = []string{"", "true", "*"}
+var excludeNoopPrograms = []string{"", "false"}
+
 func newCELFilterInclude(code string) (*CELFilter, error) {
-	return newCELFilter("inclusion", code, []string{"", "true", "*"}, true)
+	return newCELFilter("inclusion", code, includeNoopPrograms, true)
 }
 
 func newCELFilterExclude(code string) (*CELFilter, error) {
-	return newCELFilter("exclusion", code, []string{"", "false"}, false)
+	return newCELFilter("exclusion", code, excludeNoopPrograms, false)
 }
 
 func newCELFilter(name string, code string, noopPrograms []string, valueWhenNoop bool) (*CELFilter, error) {
 	stripped := strings.TrimSpace(code)
-	for _, noopProgram := range noopPrograms {
-		if stripped == noopProgram {
-			return &CELFilter{
-				name:          name,
-				code:          stripped,
-				valueWhenNoop: valueWhenNoop,
-			}, nil
-		}
+	if isNoopProgram(stripped, noopPrograms) {
+		return &CELFilter{
+			name:          name,
+			code:          stripped,
+			valueWhenNoop: valueWhenNoop,
+		}, nil
 	}
 
-	env, err := cel.NewEnv(
-		cel.Declarations(
-			decls.NewIdent("receiver", decls.String, nil),
-			decls.NewIdent("account", decls.String, nil),
-			decls.NewIdent("action", decls.String, nil),
-			decls.NewIdent("data", decls.NewMapType(decls.String, decls.Any), nil),
-			decls.NewIdent("auth", decls.NewListType(decls.String), nil),
-			decls.NewIdent("input", decls.Bool, nil),
-			decls.NewIdent("notif", decls.Bool, nil),
-			decls.NewIdent("scheduled", decls.Bool, nil),
-
-			// Those are not supported right now, so they are commented out for now to generate an error when using them
-			// decls.NewIdent("db", decls.NewMapType(decls.String, decls.String), nil),
-			// decls.NewIdent("ram", decls.NewMapType(decls.String, decls.String), nil),
-		),
-	)
+	env, err := newFilterEnv()
 	if err != nil {
 		return nil, fmt.Errorf("new env: %w", err)
 	}
@@ -83,28 +82,132 @@ func newCELFilter(name string, code string, noopPrograms []string, valueWhenNoop
 		return nil, fmt.Errorf("parse filter: %w", issues.Err())
 	}
 
-	if exprAst.ResultType() != decls.Bool {
-		return nil, fmt.Errorf("invalid return type %q", exprAst.ResultType())
+	// Programs using dynamically typed values, like `data.to` or `trx.scheduled`, are typed `dyn`,
+	// their result is then checked to be a boolean when evaluated
+	if resultType := exprAst.ResultType(); !proto.Equal(resultType, decls.Bool) && !proto.Equal(resultType, decls.Dyn) {
+		return nil, fmt.Errorf("invalid return type %q", resultType)
 	}
 
-	prg, err := env.Program(exprAst)
+	parsedExpr, err := cel.AstToParsedExpr(exprAst)
+	if err != nil {
+		return nil, fmt.Errorf("to parsed expr: %w", err)
+	}
+
+	sets, err := resolveNamedSets(parsedExpr.Expr)
+	if err != nil {
+		return nil, err
+	}
+
+	overloads := functionOverloads(sets)
+	prg, err := env.Program(exprAst, cel.Functions(overloads...))
 	if err != nil {
 		return nil, fmt.Errorf("program: %w", err)
 	}
 
+	// The fast path is an optimization only, the full program is always valid to use
+	fastPath, err := newFastPathFilter(env, exprAst, overloads)
+	if err != nil {
+		zlog.Debug("unable to create fast path filter, using full program", zap.String("name", name), zap.Error(err))
+		fastPath = nil
+	}
+
 	return &CELFilter{
 		name:          name,
 		code:          code,
 		program:       prg,
 		valueWhenNoop: valueWhenNoop,
+		usesDB:        exprReferences(parsedExpr.Expr, isIdentifier("db")),
+		usesSigners:   exprReferences(parsedExpr.Expr, isFieldSelection("trx", "signers")),
+		fastPath:      fastPath,
 	}, nil
 }
 
+// newFilterEnv creates the CEL environment filter programs are compiled in, declaring all
+// identifiers resolved by `actionTraceActivation` and our EOSIO specific functions.
+func newFilterEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Declarations(
+			decls.NewIdent("receiver", decls.String, nil),
+			decls.NewIdent("account", decls.String, nil),
+			decls.NewIdent("action", decls.String, nil),
+			decls.NewIdent("data", decls.NewMapType(decls.String, decls.Any), nil),
+			decls.NewIdent("auth", decls.NewListType(decls.String), nil),
+			decls.NewIdent("input", decls.Bool, nil),
+			decls.NewIdent("notif", decls.Bool, nil),
+			decls.NewIdent("scheduled", decls.Bool, nil),
+			decls.NewIdent("db", decls.NewMapType(decls.String, decls.String), nil),
+			decls.NewIdent("ram", decls.NewMapType(decls.String, decls.Int), nil),
+			decls.NewIdent("trx", decls.NewMapType(decls.String, decls.Dyn), nil),
+			decls.NewIdent("block", decls.NewMapType(decls.String, decls.Dyn), nil),
+		),
+		cel.Declarations(functionDeclarations...),
+	)
+}
+
+func isNoopProgram(code string, noopPrograms []string) bool {
+	stripped := strings.TrimSpace(code)
+	for _, noopProgram := range noopPrograms {
+		if stripped == noopProgram {
+			return true
+		}
+	}
+
+	return false
+}
+
+// combineFilterPrograms returns the program equivalent to applying `previous` and then
+// `next` using the received boolean operator, which is `&&` for inclusion programs and
+// `||` for exclusion programs. A no-op program is neutral and is not part of the result.
+func combineFilterPrograms(previous, next string, operator string, noopPrograms []string) string {
+	if isNoopProgram(previous, noopPrograms) {
+		return next
+	}
+
+	if isNoopProgram(next, noopPrograms) || previous == next {
+		return previous
+	}
+
+	return fmt.Sprintf("(%s) %s (%s)", previous, operator, next)
+}
+
 func (f *CELFilter) match(activation interpreter.Activation) (matched bool) {
 	if f.IsNoop() {
 		return f.valueWhenNoop
 	}
 
+	if actionActivation, ok := activation.(*actionTraceActivation); ok && f.usesDB {
+		return f.matchDBOps(actionActivation)
+	}
+
+	return f.matchOnce(activation)
+}
+
+// matchDBOps evaluates the program once per database operation of the action, `db` being
+// bound to the operation, the action matching as soon as one evaluation matches. Actions
+// without any database operation are evaluated once with all `db` fields being empty.
+func (f *CELFilter) matchDBOps(activation *actionTraceActivation) bool {
+	defer func() { activation.dbOp = nil }()
+
+	dbOps := activation.trx.trace.DBOpsForAction(activation.trace.ExecutionIndex)
+	if len(dbOps) == 0 {
+		return f.matchOnce(activation)
+	}
+
+	for _, dbOp := range dbOps {
+		activation.dbOp = dbOp
+		if f.matchOnce(activation) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *CELFilter) matchOnce(activation interpreter.Activation) (matched bool) {
+	if f.fastPath != nil {
+		return f.matchFastPath(activation)
+	}
+
 	res, _, err := f.program.Eval(activation)
 	if err != nil {
 		if traceEnabled {
@@ -115,7 +218,9 @@ func (f *CELFilter) match(activation interpreter.Activation) (matched bool) {
 
 	retval, valid := res.(types.Bool)
 	if !valid {
-		zlog.Error("return value of our cel program isn't of type bool, this should never happen since we've checked the return value type already")
+		if traceEnabled {
+			zlog.Debug("filter program did not return a boolean", zap.String("name", f.name), zap.String("type", res.Type().TypeName()))
+		}
 		return f.valueWhenNoop
 	}
 
@@ -126,11 +231,112 @@ func (f *CELFilter) match(activation interpreter.Activation) (matched bool) {
 	return bool(retval)
 }
 
+// transactionActivation holds the transaction level identifiers, `trx` and `block`, shared by
+// all the actions of a transaction so that they are computed at most once per transaction.
+type transactionActivation struct {
+	trace      *pbcodec.TransactionTrace
+	failedDtrx bool
+
+	// signers are the public keys that signed the transaction, `nil` when they could not be
+	// determined, in which case `trx.signers` is not defined.
+	signers []string
+
+	cachedTrx   map[string]interface{}
+	cachedBlock map[string]interface{}
+}
+
+func newTransactionActivation(trace *pbcodec.TransactionTrace, failedDtrx bool, signers []string) *transactionActivation {
+	return &transactionActivation{trace: trace, failedDtrx: failedDtrx, signers: signers}
+}
+
+func (t *transactionActivation) trx() map[string]interface{} {
+	if t.cachedTrx != nil {
+		return t.cachedTrx
+	}
+
+	cpuUsage := int64(0)
+	status := pbcodec.TransactionStatus_TRANSACTIONSTATUS_UNKNOWN
+	if t.trace.Receipt != nil {
+		cpuUsage = int64(t.trace.Receipt.CpuUsageMicroSeconds)
+		status = t.trace.Receipt.Status
+	}
+
+	t.cachedTrx = map[string]interface{}{
+		"id":           t.trace.Id,
+		"cpu_usage":    cpuUsage,
+		"net_usage":    int64(t.trace.NetUsage),
+		"action_count": int64(len(t.trace.ActionTraces)),
+		"status":       transactionStatusName(status),
+		"scheduled":    t.trace.Scheduled,
+		"failed_dtrx":  t.failedDtrx,
+	}
+
+	if t.signers != nil {
+		t.cachedTrx["signers"] = t.signers
+	}
+
+	return t.cachedTrx
+}
+
+func (t *transactionActivation) block() map[string]interface{} {
+	if t.cachedBlock != nil {
+		return t.cachedBlock
+	}
+
+	t.cachedBlock = map[string]interface{}{
+		"num": int64(t.trace.BlockNum),
+	}
+
+	if t.trace.BlockTime != nil {
+		t.cachedBlock["time"] = t.trace.BlockTime
+	}
+
+	return t.cachedBlock
+}
+
+// transactionStatusName returns the EOSIO name of the status, like `executed` or `hard_fail`.
+func transactionStatusName(status pbcodec.TransactionStatus) string {
+	switch status {
+	case pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED:
+		return "executed"
+	case pbcodec.TransactionStatus_TRANSACTIONSTATUS_SOFTFAIL:
+		return "soft_fail"
+	case pbcodec.TransactionStatus_TRANSACTIONSTATUS_HARDFAIL:
+		return "hard_fail"
+	case pbcodec.TransactionStatus_TRANSACTIONSTATUS_DELAYED:
+		return "delayed"
+	case pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXPIRED:
+		return "expired"
+	case pbcodec.TransactionStatus_TRANSACTIONSTATUS_CANCELED:
+		return "canceled"
+	default:
+		return "unknown"
+	}
+}
+
+func (f *CELFilter) matchFastPath(activation interpreter.Activation) (matched bool) {
+	matched, err := f.fastPath.match(activation)
+	if err != nil {
+		if traceEnabled {
+			zlog.Debug("filter program failed", zap.String("name", f.name), zap.Error(err))
+		}
+		return f.valueWhenNoop
+	}
+
+	if traceEnabled {
+		zlog.Debug("filter program executed correctly using fast path", zap.String("name", f.name), zap.Bool("matched", matched))
+	}
+
+	return matched
+}
+
 type actionTraceActivation struct {
 	trace      *pbcodec.ActionTrace
+	trx        *transactionActivation
 	cachedData map[string]interface{}
 
-	trxScheduled bool
+	// dbOp is the database operation `db` resolves to, set by `CELFilter.matchDBOps`
+	dbOp *pbcodec.DBOp
 }
 
 func (a *actionTraceActivation) Parent() interpreter.Activation {
@@ -186,16 +392,17 @@ func (a *actionTraceActivation) ResolveName(name string) (interface{}, bool) {
 		}
 		return a.trace.Account() != receiver, true
 	case "scheduled":
-		return a.trxScheduled, true
+		return a.trx.trace.Scheduled, true
 	case "input":
 		return a.trace.IsInput(), true
-
-	// Those are actually commented out from the valid list of names that is possible to use in our CEL program,
-	// so it's not a big deal to have them currently panicking here as it no code path can reach this point.
-	case "ram":
-		panic("CEL filtering does not yet support ram.consumed nor ram.released")
 	case "db":
-		panic("CEL filtering does not yet support db.table, db.key, etc..")
+		return tokenizeDBOp(a.dbOp), true
+	case "ram":
+		return tokenizeRAMOps(a.trx.trace.RAMOpsForAction(a.trace.ExecutionIndex)), true
+	case "trx":
+		return a.trx.trx(), true
+	case "block":
+		return a.trx.block(), true
 	}
 
 	return nil, false
@@ -204,12 +411,119 @@ func (a *actionTraceActivation) ResolveName(name string) (interface{}, bool) {
 // This must follow rules taken in `search/tokenization.go`, ideally we would share this, maybe would be a good idea to
 // put the logic in an helper method on type `pbcodec.PermissionLevel` directly.
 func tokenizeEOSAuthority(authorizations []*pbcodec.PermissionLevel) (out []string) {
-	out = make([]string, len(authorizations))
+	out = make([]string, len(authorizations)*2)
 	for i, auth := range authorizations {
-
 		out[i*2] = auth.Actor
 		out[i*2+1] = auth.Authorization()
 	}
 
 	return
 }
+
+// tokenizeDBOp turns a database operation performed by the action into the `db` identifier.
+// All fields are present and empty when `dbOp` is `nil`, so that expressions like
+// `db.table == 'accounts'` evaluate to `false` instead of failing for actions that did not
+// perform any database operation.
+func tokenizeDBOp(dbOp *pbcodec.DBOp) map[string]string {
+	if dbOp == nil {
+		return map[string]string{"code": "", "scope": "", "table": "", "key": ""}
+	}
+
+	return map[string]string{
+		"code":  dbOp.Code,
+		"scope": dbOp.Scope,
+		"table": dbOp.TableName,
+		"key":   dbOp.PrimaryKey,
+	}
+}
+
+// tokenizeRAMOps turns the RAM operations performed by a single action into the `ram`
+// identifier, `consumed` being the sum of all positive deltas and `released` the sum of
+// all negative deltas, expressed as a positive number of bytes.
+func tokenizeRAMOps(ramOps []*pbcodec.RAMOp) map[string]int64 {
+	out := map[string]int64{
+		"consumed": 0,
+		"released": 0,
+	}
+
+	for _, op := range ramOps {
+		if op.Delta > 0 {
+			out["consumed"] += op.Delta
+		} else {
+			out["released"] -= op.Delta
+		}
+	}
+
+	return out
+}
+
+// exprReferences returns whether `matches` is true for the expression or any of its
+// sub-expressions.
+func exprReferences(expr *exprpb.Expr, matches func(expr *exprpb.Expr) bool) bool {
+	if expr == nil {
+		return false
+	}
+
+	if matches(expr) {
+		return true
+	}
+
+	switch kind := expr.ExprKind.(type) {
+	case *exprpb.Expr_SelectExpr:
+		return exprReferences(kind.SelectExpr.Operand, matches)
+	case *exprpb.Expr_CallExpr:
+		if exprReferences(kind.CallExpr.Target, matches) {
+			return true
+		}
+
+		for _, arg := range kind.CallExpr.Args {
+			if exprReferences(arg, matches) {
+				return true
+			}
+		}
+	case *exprpb.Expr_ListExpr:
+		for _, element := range kind.ListExpr.Elements {
+			if exprReferences(element, matches) {
+				return true
+			}
+		}
+	case *exprpb.Expr_StructExpr:
+		for _, entry := range kind.StructExpr.Entries {
+			if exprReferences(entry.GetMapKey(), matches) || exprReferences(entry.Value, matches) {
+				return true
+			}
+		}
+	case *exprpb.Expr_ComprehensionExpr:
+		comprehension := kind.ComprehensionExpr
+		return exprReferences(comprehension.IterRange, matches) ||
+			exprReferences(comprehension.AccuInit, matches) ||
+			exprReferences(comprehension.LoopCondition, matches) ||
+			exprReferences(comprehension.LoopStep, matches) ||
+			exprReferences(comprehension.Result, matches)
+	}
+
+	return false
+}
+
+func isIdentifier(name string) func(expr *exprpb.Expr) bool {
+	return func(expr *exprpb.Expr) bool {
+		return identifierName(expr) == name
+	}
+}
+
+// isFieldSelection matches the selection of `field` on the `ident` identifier, either as
+// `ident.field` or as `ident['field']`.
+func isFieldSelection(ident, field string) func(expr *exprpb.Expr) bool {
+	return func(expr *exprpb.Expr) bool {
+		if selection := expr.GetSelectExpr(); selection != nil {
+			return selection.Field == field && identifierName(selection.Operand) == ident
+		}
+
+		if call := expr.GetCallExpr(); call != nil && call.Function == operators.Index && len(call.Args) == 2 {
+			literal, ok := stringLiteral(call.Args[1])
+			return ok && literal == field && identifierName(call.Args[0]) == ident
+		}
+
+		return false
+	}
+}
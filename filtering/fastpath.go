@@ -0,0 +1,236 @@
+package filtering
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/interpreter"
+	"github.com/google/cel-go/interpreter/functions"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// fastPathFilter is the optimized form of a filter program made of a disjunction (`||`) of
+// terms. The terms testing `receiver`, `account` or `action` against string literals, like
+// `receiver == 'eidosonecoin'` or `account in ['a', 'b']`, and the ones testing membership of
+// a string literal in `auth`, like `'bob@active' in auth`, are turned into hash set lookups.
+// The remaining terms, the residual ones, are still evaluated by CEL, but only when none of
+// the lookups matched.
+type fastPathFilter struct {
+	receivers map[string]bool
+	accounts  map[string]bool
+	actions   map[string]bool
+	auths     map[string]bool
+
+	residuals []cel.Program
+}
+
+// newFastPathFilter analyzes the checked program and returns its fast path form, or `nil` when
+// the program has no term that can be turned into a lookup, in which case the full CEL program
+// should be used as is.
+func newFastPathFilter(env *cel.Env, checkedAst *cel.Ast, overloads []*functions.Overload) (*fastPathFilter, error) {
+	parsedExpr, err := cel.AstToParsedExpr(checkedAst)
+	if err != nil {
+		return nil, fmt.Errorf("to parsed expr: %w", err)
+	}
+
+	filter := &fastPathFilter{
+		receivers: map[string]bool{},
+		accounts:  map[string]bool{},
+		actions:   map[string]bool{},
+		auths:     map[string]bool{},
+	}
+
+	terms := flattenDisjunction(parsedExpr.Expr)
+
+	var residualTerms []*exprpb.Expr
+	for _, term := range terms {
+		if !filter.addLookupTerm(term) {
+			residualTerms = append(residualTerms, term)
+		}
+	}
+
+	if len(residualTerms) == len(terms) {
+		return nil, nil
+	}
+
+	for _, term := range residualTerms {
+		termAst, issues := env.Check(cel.ParsedExprToAst(&exprpb.ParsedExpr{Expr: term, SourceInfo: parsedExpr.SourceInfo}))
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("check residual term: %w", issues.Err())
+		}
+
+		prg, err := env.Program(termAst, cel.Functions(overloads...))
+		if err != nil {
+			return nil, fmt.Errorf("residual term program: %w", err)
+		}
+
+		filter.residuals = append(filter.residuals, prg)
+	}
+
+	return filter, nil
+}
+
+// flattenDisjunction returns the terms of `a || b || ...`, or the expression itself when it's
+// not a disjunction.
+func flattenDisjunction(expr *exprpb.Expr) (out []*exprpb.Expr) {
+	call := expr.GetCallExpr()
+	if call == nil || call.Function != operators.LogicalOr {
+		return []*exprpb.Expr{expr}
+	}
+
+	for _, arg := range call.Args {
+		out = append(out, flattenDisjunction(arg)...)
+	}
+
+	return out
+}
+
+// addLookupTerm adds the string literals of the term to the matching lookup set, returning
+// `false` when the term is not one of the recognized forms.
+func (f *fastPathFilter) addLookupTerm(term *exprpb.Expr) bool {
+	call := term.GetCallExpr()
+	if call == nil || call.Target != nil || len(call.Args) != 2 {
+		return false
+	}
+
+	left, right := call.Args[0], call.Args[1]
+
+	switch call.Function {
+	case operators.Equals:
+		if set := f.identifierSet(left); set != nil {
+			return addStringLiterals(set, right)
+		}
+
+		if set := f.identifierSet(right); set != nil {
+			return addStringLiterals(set, left)
+		}
+	case operators.In:
+		if identifierName(right) == "auth" {
+			return addStringLiterals(f.auths, left)
+		}
+
+		if set := f.identifierSet(left); set != nil && right.GetListExpr() != nil {
+			return addStringLiterals(set, right)
+		}
+	}
+
+	return false
+}
+
+func (f *fastPathFilter) identifierSet(expr *exprpb.Expr) map[string]bool {
+	switch identifierName(expr) {
+	case "receiver":
+		return f.receivers
+	case "account":
+		return f.accounts
+	case "action":
+		return f.actions
+	}
+
+	return nil
+}
+
+func identifierName(expr *exprpb.Expr) string {
+	if ident := expr.GetIdentExpr(); ident != nil {
+		return ident.Name
+	}
+
+	return ""
+}
+
+// addStringLiterals adds to the set the string literal, or all the elements of the list of
+// string literals, returning `false` without modifying the set otherwise.
+func addStringLiterals(set map[string]bool, expr *exprpb.Expr) bool {
+	var literals []string
+	if list := expr.GetListExpr(); list != nil {
+		for _, element := range list.Elements {
+			literal, ok := stringLiteral(element)
+			if !ok {
+				return false
+			}
+
+			literals = append(literals, literal)
+		}
+	} else {
+		literal, ok := stringLiteral(expr)
+		if !ok {
+			return false
+		}
+
+		literals = append(literals, literal)
+	}
+
+	for _, literal := range literals {
+		set[literal] = true
+	}
+
+	return true
+}
+
+func stringLiteral(expr *exprpb.Expr) (string, bool) {
+	constant := expr.GetConstExpr()
+	if constant == nil {
+		return "", false
+	}
+
+	value, ok := constant.ConstantKind.(*exprpb.Constant_StringValue)
+	if !ok {
+		return "", false
+	}
+
+	return value.StringValue, true
+}
+
+// match follows CEL's semantics of `||`, a matching term wins over a term failing to evaluate,
+// an error being returned only when no term matched and at least one failed.
+func (f *fastPathFilter) match(activation interpreter.Activation) (bool, error) {
+	if lookup(f.receivers, activation, "receiver") || lookup(f.accounts, activation, "account") || lookup(f.actions, activation, "action") {
+		return true, nil
+	}
+
+	if len(f.auths) > 0 {
+		if value, found := activation.ResolveName("auth"); found {
+			for _, auth := range value.([]string) {
+				if f.auths[auth] {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	var evalErr error
+	for _, residual := range f.residuals {
+		res, _, err := residual.Eval(activation)
+		if err != nil {
+			evalErr = err
+			continue
+		}
+
+		retval, valid := res.(types.Bool)
+		if !valid {
+			evalErr = fmt.Errorf("residual term returned a non boolean value of type %s", res.Type().TypeName())
+			continue
+		}
+
+		if retval {
+			return true, nil
+		}
+	}
+
+	return false, evalErr
+}
+
+func lookup(set map[string]bool, activation interpreter.Activation, name string) bool {
+	if len(set) == 0 {
+		return false
+	}
+
+	value, found := activation.ResolveName(name)
+	if !found {
+		return false
+	}
+
+	return set[value.(string)]
+}
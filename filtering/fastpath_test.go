@@ -0,0 +1,128 @@
+package filtering
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFastPathFilter(t *testing.T) {
+	authorized := func(trace *pbcodec.TransactionTrace, actor, permission string) *pbcodec.TransactionTrace {
+		trace.ActionTraces[0].Action.Authorization = []*pbcodec.PermissionLevel{{Actor: actor, Permission: permission}}
+		return trace
+	}
+
+	traces := []*pbcodec.TransactionTrace{
+		ct.TrxTrace(t, ct.ActionTrace(t, "spamcoin:spamcoin:transfer")),
+		ct.TrxTrace(t, ct.ActionTrace(t, "bob:eosio.token:transfer", ct.ActionData(`{"from":"alice","to":"bob"}`))),
+		ct.TrxTrace(t, ct.ActionTrace(t, "eidosonecoin:eosio.token:transfer", ct.ActionData(`{"from":"eidosonecoin","to":"bob"}`))),
+		authorized(ct.TrxTrace(t, ct.ActionTrace(t, "eosio:eosio:buyram")), "bob", "active"),
+		ct.TrxTrace(t, ct.ActionTrace(t, "eosio:eosio:delegatebw", ct.ActionData(`{}`))),
+	}
+
+	tests := []struct {
+		name             string
+		code             string
+		expectedFastPath bool
+	}{
+		{"receiver equality", `receiver == 'spamcoin'`, true},
+		{"reversed equality", `'spamcoin' == receiver || 'eosio' == account`, true},
+		{"disjunction", `receiver == 'spamcoin' || account == 'eosio' || action == 'delegatebw'`, true},
+		{"in list", `receiver in ['spamcoin', 'eidosonecoin']`, true},
+		{"auth", `'bob@active' in auth || 'alice' in auth`, true},
+		{"with residual", `receiver == 'spamcoin' || (account == 'eosio.token' && data.from == 'eidosonecoin')`, true},
+		{"with failing residual", `receiver == 'spamcoin' || data.from == 'eidosonecoin'`, true},
+		{"no lookup term", `account == 'eosio.token' && data.to == 'bob'`, false},
+		{"not an identifier", `data.from == 'alice'`, false},
+		{"not a literal", `receiver == account`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := newCELFilterExclude(test.code)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedFastPath, filter.fastPath != nil)
+
+			celFilter := *filter
+			celFilter.fastPath = nil
+
+			for i, trace := range traces {
+				activation := &actionTraceActivation{trace: trace.ActionTraces[0], trx: newTransactionActivation(trace, false, nil)}
+				assert.Equal(t, celFilter.match(activation), filter.match(activation), "trace #%d", i)
+			}
+		})
+	}
+}
+
+func BenchmarkFilterMatch(b *testing.B) {
+	actions := loadBenchmarkActions(b)
+
+	var receivers []string
+	for i := 0; i < 300; i++ {
+		receivers = append(receivers, fmt.Sprintf("receiver == 'spam%d'", i))
+	}
+
+	programs := map[string]string{
+		"lookups":       strings.Join(receivers, " || "),
+		"with_residual": strings.Join(receivers, " || ") + " || (account == 'eosio.token' && data.to == 'spam')",
+	}
+
+	for name, code := range programs {
+		filter, err := newCELFilterExclude(code)
+		require.NoError(b, err)
+		require.NotNil(b, filter.fastPath)
+
+		celFilter := *filter
+		celFilter.fastPath = nil
+
+		b.Run(name+"/fast_path", func(b *testing.B) { benchmarkFilterMatch(b, filter, actions) })
+		b.Run(name+"/cel", func(b *testing.B) { benchmarkFilterMatch(b, &celFilter, actions) })
+	}
+}
+
+func benchmarkFilterMatch(b *testing.B, filter *CELFilter, actions []*actionTraceActivation) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		for _, activation := range actions {
+			activation.cachedData = nil
+			filter.match(activation)
+		}
+	}
+}
+
+// loadBenchmarkActions reads the blocks of `testdata/blocks`, a subset of the ones generated in
+// `codec/testdata/pbblocks` by codec's `TestGeneratePBBlocks` test, keeping those with the most
+// actions while leaving out the big `setcode` ones.
+func loadBenchmarkActions(b *testing.B) (out []*actionTraceActivation) {
+	files, err := filepath.Glob(filepath.Join("testdata", "blocks", "*.pb"))
+	require.NoError(b, err)
+	require.NotEmpty(b, files, "no blocks found in testdata/blocks")
+
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		require.NoError(b, err)
+
+		block := &pbcodec.Block{}
+		require.NoError(b, proto.Unmarshal(content, block))
+		block.MigrateV0ToV1()
+
+		for _, trxTrace := range block.TransactionTraces() {
+			trx := newTransactionActivation(trxTrace, false, nil)
+			for _, actTrace := range trxTrace.ActionTraces {
+				out = append(out, &actionTraceActivation{trace: actTrace, trx: trx})
+			}
+		}
+	}
+
+	return out
+}
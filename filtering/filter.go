@@ -4,77 +4,272 @@ import (
 	"fmt"
 
 	"github.com/dfuse-io/bstream"
+	"github.com/dfuse-io/dfuse-eosio/codec"
 	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/eoscanada/eos-go"
 	"go.uber.org/zap"
 )
 
 type BlockFilter struct {
-	IncludeProgram *CELFilter
-	ExcludeProgram *CELFilter
+	// versions is sorted by ascending start block, the first one always starting at block 0
+	versions []*filterVersion
 }
 
-func NewBlockFilter(includeProgramCode, excludeProgramCode string) (*BlockFilter, error) {
-	includeFilter, err := newCELFilterInclude(includeProgramCode)
-	if err != nil {
-		return nil, fmt.Errorf("include filter: %w", err)
+// filterVersion holds the programs applied to blocks starting at `startBlockNum` up to
+// the start block of the next version of the filter schedule.
+type filterVersion struct {
+	startBlockNum  uint64
+	includeProgram *CELFilter
+	excludeProgram *CELFilter
+
+	// chainID is required to recover the signers of transactions, `trx.signers` is not
+	// defined when it's not set
+	chainID eos.Checksum256
+
+	// usesSigners is set when one of the programs references `trx.signers`, recovering the
+	// public keys out of signatures is costly so we only do it when it's actually needed
+	usesSigners bool
+
+	// strict removes, from the kept transactions, the state operations performed by actions
+	// that did not match the filter
+	strict bool
+
+	// alwaysIncluded are the `<account>:<action>` actions included before the programs are
+	// evaluated, see `WithAlwaysIncludedActions`
+	alwaysIncluded map[string]bool
+
+	// includeExpr and excludeExpr are the expressions recorded in the blocks filtered by this
+	// version, the programs' code along with the always included actions, see `recordedExprs`
+	includeExpr string
+	excludeExpr string
+}
+
+func (v *filterVersion) IsNoop() bool {
+	return v.includeProgram.IsNoop() && v.excludeProgram.IsNoop()
+}
+
+// BlockFilterOption configures a block filter when it's created.
+type BlockFilterOption func(o *blockFilterOptions) error
+
+type blockFilterOptions struct {
+	alwaysIncluded map[string]bool
+}
+
+func NewBlockFilter(includeProgramCode, excludeProgramCode string, opts ...BlockFilterOption) (*BlockFilter, error) {
+	return NewScheduledBlockFilter(FilterSchedule{
+		{StartBlock: 0, Include: includeProgramCode, Exclude: excludeProgramCode},
+	}, opts...)
+}
+
+// NewScheduledBlockFilter creates a block filter that applies the include and exclude programs
+// of the schedule entry covering the number of each block. Blocks before the first entry of the
+// schedule are not filtered.
+func NewScheduledBlockFilter(schedule FilterSchedule, opts ...BlockFilterOption) (*BlockFilter, error) {
+	if err := schedule.validate(); err != nil {
+		return nil, err
 	}
 
-	excludeFilter, err := newCELFilterExclude(excludeProgramCode)
-	if err != nil {
-		return nil, fmt.Errorf("exclude filter: %w", err)
+	options := &blockFilterOptions{
+		alwaysIncluded: mustNewActionSet(DefaultAlwaysIncludedActions),
+	}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	var versions []*filterVersion
+	if schedule[0].StartBlock > 0 {
+		version, err := newFilterVersion(&FilterScheduleEntry{StartBlock: 0}, options)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	for _, entry := range schedule {
+		version, err := newFilterVersion(entry, options)
+		if err != nil {
+			return nil, err
+		}
+
+		version.warnExcludedAlwaysIncludedActions()
+		versions = append(versions, version)
 	}
 
 	return &BlockFilter{
-		IncludeProgram: includeFilter,
-		ExcludeProgram: excludeFilter,
+		versions: versions,
 	}, nil
 }
 
+func newFilterVersion(entry *FilterScheduleEntry, options *blockFilterOptions) (*filterVersion, error) {
+	includeFilter, err := newCELFilterInclude(entry.Include)
+	if err != nil {
+		return nil, fmt.Errorf("include filter (starting at block #%d): %w", entry.StartBlock, err)
+	}
+
+	excludeFilter, err := newCELFilterExclude(entry.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("exclude filter (starting at block #%d): %w", entry.StartBlock, err)
+	}
+
+	version := &filterVersion{
+		startBlockNum:  entry.StartBlock,
+		includeProgram: includeFilter,
+		excludeProgram: excludeFilter,
+		usesSigners:    includeFilter.usesSigners || excludeFilter.usesSigners,
+		alwaysIncluded: options.alwaysIncluded,
+	}
+	version.includeExpr, version.excludeExpr = version.recordedExprs()
+
+	return version, nil
+}
+
+// SetChainID sets the chain ID used to recover the public keys that signed a transaction from
+// its signatures, which are then available to programs as `trx.signers`.
+func (f *BlockFilter) SetChainID(chainID eos.Checksum256) {
+	for _, version := range f.versions {
+		version.chainID = chainID
+	}
+}
+
+// SetStrict enables or disables the strict mode of the filter. In strict mode, the DBOps, RAMOps,
+// TableOps and PermOps of a kept transaction that were performed by an action that did not match
+// the filter are removed from the transaction, so that only state changes tied to matched
+// actions remain in the filtered block.
+func (f *BlockFilter) SetStrict(strict bool) {
+	for _, version := range f.versions {
+		version.strict = strict
+	}
+}
+
+// IsNoop returns `true` when all versions of the filter are no-op filters, in which case
+// the filter never modifies any block.
+func (f *BlockFilter) IsNoop() bool {
+	for _, version := range f.versions {
+		if !version.IsNoop() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *BlockFilter) versionFor(blockNum uint64) *filterVersion {
+	for i := len(f.versions) - 1; i > 0; i-- {
+		if f.versions[i].startBlockNum <= blockNum {
+			return f.versions[i]
+		}
+	}
+
+	return f.versions[0]
+}
+
 // TransformInPlace received a `bstream.Block` pointer, unpack it's native counterpart, a `pbcodec.Block` pointer
 // in our case and transforms it in place, modifiying the pointed object. This means that future `ToNative()` calls
 // on the bstream block will return a filtered version of this block.
 //
+// The programs used are the ones of the filter version covering the block's number, as
+// defined by the schedule the filter was created with.
+//
+// When the block was already filtered with different programs, for example by the relayer
+// or by `merged-filter` using the common filter while a component uses its own filter, the
+// already filtered data is filtered once more. The resulting block contains only the actions
+// that matched both filters and the recorded include/exclude expressions are the combination
+// of both, keeping the whole filtering lineage of the block.
+//
 // *Important* This method expect that the caller will peform the transformation in lock step, there is no lock
 //             performed by this method. It's the caller responsibility to deal with concurrency issues.
 func (f *BlockFilter) TransformInPlace(blk *bstream.Block) error {
 	// Don't decode the bstream block at all so we save a costly unpacking when both filters are no-op filters
-	if f.IncludeProgram.IsNoop() && f.ExcludeProgram.IsNoop() {
+	version := f.versionFor(blk.Num())
+	if version.IsNoop() {
 		return nil
 	}
 
 	block := blk.ToNative().(*pbcodec.Block)
 	if !block.FilteringApplied {
-		f.transfromInPlace(block)
+		version.transfromInPlace(block)
+		return nil
+	}
+
+	if block.FilteringIncludeFilterExpr == version.includeExpr &&
+		block.FilteringExcludeFilterExpr == version.excludeExpr {
+		// The block might have been filtered in non-strict mode, pruning is idempotent so we
+		// can safely apply it on the already filtered transactions
+		if version.strict {
+			for _, trxTrace := range block.FilteredTransactionTraces {
+				pruneUnmatchedActionOps(trxTrace)
+			}
+		}
+
 		return nil
 	}
 
-	if block.FilteringIncludeFilterExpr != f.IncludeProgram.code ||
-		block.FilteringExcludeFilterExpr != f.ExcludeProgram.code {
-		panic(fmt.Sprintf("different block filter already applied: (inc) %s, (exc): %s", f.IncludeProgram.code, f.ExcludeProgram.code))
+	if traceEnabled {
+		zlog.Debug("block already filtered with different programs, filtering it further",
+			zap.Uint64("block_num", block.Num()),
+			zap.String("block_include_expr", block.FilteringIncludeFilterExpr),
+			zap.String("block_exclude_expr", block.FilteringExcludeFilterExpr),
+			zap.String("include_expr", version.includeExpr),
+			zap.String("exclude_expr", version.excludeExpr),
+		)
 	}
-	return nil
 
+	version.transfromInPlace(block)
+	return nil
 }
 
-func (f *BlockFilter) transfromInPlace(block *pbcodec.Block) {
+func (v *filterVersion) transfromInPlace(block *pbcodec.Block) {
+	refiltering := block.FilteringApplied
+
+	transactions := block.UnfilteredTransactions
+	transactionTraces := block.UnfilteredTransactionTraces
+	implicitTransactionOps := block.UnfilteredImplicitTransactionOps
+	includeExpr := v.includeExpr
+	excludeExpr := v.excludeExpr
+
+	if refiltering {
+		transactions = block.FilteredTransactions
+		transactionTraces = block.FilteredTransactionTraces
+		implicitTransactionOps = block.FilteredImplicitTransactionOps
+		includeExpr = combineFilterPrograms(block.FilteringIncludeFilterExpr, includeExpr, "&&", includeNoopPrograms)
+		excludeExpr = combineFilterPrograms(block.FilteringExcludeFilterExpr, excludeExpr, "||", excludeNoopPrograms)
+	}
+
 	block.FilteringApplied = true
-	block.FilteringIncludeFilterExpr = f.IncludeProgram.code
-	block.FilteringExcludeFilterExpr = f.ExcludeProgram.code
+	block.FilteringIncludeFilterExpr = includeExpr
+	block.FilteringExcludeFilterExpr = excludeExpr
 
 	var filteredTrxTrace []*pbcodec.TransactionTrace
 	filteredExecutedInputActionCount := uint32(0)
 	filteredExecutedTotalActionCount := uint32(0)
 
+	// When filtering an already filtered block, an action must have been matched by the previous filter to be kept
+	shouldProcess := func(trx *transactionActivation, actTrace *pbcodec.ActionTrace) bool {
+		if refiltering && !actTrace.FilteringMatched {
+			return false
+		}
+
+		matched := v.shouldProcess(trx, actTrace)
+		actTrace.FilteringMatched = matched
+
+		return matched
+	}
+
+	signersFor := v.newSignersResolver(transactions)
+
 	excludedTransactionIds := map[string]bool{}
-	for _, trxTrace := range block.UnfilteredTransactionTraces {
+	for _, trxTrace := range transactionTraces {
 		trxTraceAddedToFiltered := false
 		trxTraceExcluded := true
+		trx := newTransactionActivation(trxTrace, false, signersFor(trxTrace.Id))
 		for _, actTrace := range trxTrace.ActionTraces {
-			if !f.shouldProcess(trxTrace, actTrace) {
+			if !shouldProcess(trx, actTrace) {
 				continue
 			}
 
-			actTrace.FilteringMatched = true
 			filteredExecutedTotalActionCount++
 			if actTrace.IsInput() {
 				filteredExecutedInputActionCount++
@@ -88,12 +283,12 @@ func (f *BlockFilter) transfromInPlace(block *pbcodec.Block) {
 		}
 
 		if trxTrace.FailedDtrxTrace != nil {
+			failedDtrx := newTransactionActivation(trxTrace.FailedDtrxTrace, true, signersFor(trxTrace.FailedDtrxTrace.Id))
 			for _, actTrace := range trxTrace.FailedDtrxTrace.ActionTraces {
-				if !f.shouldProcess(trxTrace.FailedDtrxTrace, actTrace) {
+				if !shouldProcess(failedDtrx, actTrace) {
 					continue
 				}
 
-				actTrace.FilteringMatched = true
 				if !trxTraceAddedToFiltered {
 					filteredTrxTrace = append(filteredTrxTrace, trxTrace)
 					trxTraceAddedToFiltered = true
@@ -104,6 +299,11 @@ func (f *BlockFilter) transfromInPlace(block *pbcodec.Block) {
 
 		if trxTraceExcluded {
 			excludedTransactionIds[trxTrace.Id] = true
+			continue
+		}
+
+		if v.strict {
+			pruneUnmatchedActionOps(trxTrace)
 		}
 	}
 
@@ -116,13 +316,13 @@ func (f *BlockFilter) transfromInPlace(block *pbcodec.Block) {
 			zlog.Debug("filtering excluded transaction traces, let's filter out excluded one from transaction arrays", zap.Int("excluded_count", len(excludedTransactionIds)))
 		}
 
-		for _, trx := range block.UnfilteredTransactions {
+		for _, trx := range transactions {
 			if _, isExcluded := excludedTransactionIds[trx.Id]; !isExcluded {
 				filteredTrx = append(filteredTrx, trx)
 			}
 		}
 
-		for _, trxOp := range block.UnfilteredImplicitTransactionOps {
+		for _, trxOp := range implicitTransactionOps {
 			if _, isExcluded := excludedTransactionIds[trxOp.TransactionId]; !isExcluded {
 				filteredImplicitTrxOp = append(filteredImplicitTrxOp, trxOp)
 			}
@@ -130,15 +330,15 @@ func (f *BlockFilter) transfromInPlace(block *pbcodec.Block) {
 
 		if traceEnabled {
 			zlog.Debug("filtered transactions",
-				zap.Int("original_trx", len(block.UnfilteredTransactions)),
-				zap.Int("original_implicit_trx", len(block.UnfilteredImplicitTransactionOps)),
+				zap.Int("original_trx", len(transactions)),
+				zap.Int("original_implicit_trx", len(implicitTransactionOps)),
 				zap.Int("filtered_trx", len(filteredTrx)),
 				zap.Int("filtered_implicit_trx", len(filteredImplicitTrxOp)),
 			)
 		}
 	} else {
-		filteredTrx = block.UnfilteredTransactions
-		filteredImplicitTrxOp = block.UnfilteredImplicitTransactionOps
+		filteredTrx = transactions
+		filteredImplicitTrxOp = implicitTransactionOps
 	}
 
 	block.UnfilteredTransactions = nil
@@ -156,15 +356,120 @@ func (f *BlockFilter) transfromInPlace(block *pbcodec.Block) {
 	block.FilteredImplicitTransactionOps = filteredImplicitTrxOp
 }
 
-func (f *BlockFilter) shouldProcess(trxTrace *pbcodec.TransactionTrace, actTrace *pbcodec.ActionTrace) bool {
-	activation := actionTraceActivation{trace: actTrace, trxScheduled: trxTrace.Scheduled}
+// pruneUnmatchedActionOps removes the DBOps, RAMOps, TableOps and PermOps of the transaction
+// trace, and of its failed deferred transaction trace if any, that were performed by an action
+// that did not match the filter. The action traces must have been flagged by the filter already.
+func pruneUnmatchedActionOps(trxTrace *pbcodec.TransactionTrace) {
+	matched := make(map[uint32]bool, len(trxTrace.ActionTraces))
+	for _, actTrace := range trxTrace.ActionTraces {
+		if actTrace.FilteringMatched {
+			matched[actTrace.ExecutionIndex] = true
+		}
+	}
+
+	var dbOps []*pbcodec.DBOp
+	for _, op := range trxTrace.DbOps {
+		if matched[op.ActionIndex] {
+			dbOps = append(dbOps, op)
+		}
+	}
+
+	var ramOps []*pbcodec.RAMOp
+	for _, op := range trxTrace.RamOps {
+		if matched[op.ActionIndex] {
+			ramOps = append(ramOps, op)
+		}
+	}
+
+	var tableOps []*pbcodec.TableOp
+	for _, op := range trxTrace.TableOps {
+		if matched[op.ActionIndex] {
+			tableOps = append(tableOps, op)
+		}
+	}
+
+	var permOps []*pbcodec.PermOp
+	for _, op := range trxTrace.PermOps {
+		if matched[op.ActionIndex] {
+			permOps = append(permOps, op)
+		}
+	}
+
+	if traceEnabled {
+		zlog.Debug("pruned state operations of unmatched actions",
+			zap.String("trx_id", trxTrace.Id),
+			zap.Int("db_ops_pruned", len(trxTrace.DbOps)-len(dbOps)),
+			zap.Int("ram_ops_pruned", len(trxTrace.RamOps)-len(ramOps)),
+			zap.Int("table_ops_pruned", len(trxTrace.TableOps)-len(tableOps)),
+			zap.Int("perm_ops_pruned", len(trxTrace.PermOps)-len(permOps)),
+		)
+	}
+
+	trxTrace.DbOps = dbOps
+	trxTrace.RamOps = ramOps
+	trxTrace.TableOps = tableOps
+	trxTrace.PermOps = permOps
+
+	if trxTrace.FailedDtrxTrace != nil {
+		pruneUnmatchedActionOps(trxTrace.FailedDtrxTrace)
+	}
+}
+
+// newSignersResolver returns a function resolving the public keys that signed a transaction
+// from its receipt in `transactions`. The function returns `nil` when the signers cannot be
+// determined, when no program uses them, when the chain ID is not set or when the transaction
+// has no receipt in the block, like implicit transactions.
+func (v *filterVersion) newSignersResolver(transactions []*pbcodec.TransactionReceipt) func(trxID string) []string {
+	if !v.usesSigners || len(v.chainID) == 0 {
+		return func(trxID string) []string { return nil }
+	}
+
+	receipts := make(map[string]*pbcodec.TransactionReceipt, len(transactions))
+	for _, trx := range transactions {
+		receipts[trx.Id] = trx
+	}
+
+	return func(trxID string) []string {
+		receipt, found := receipts[trxID]
+		if !found || receipt.PackedTransaction == nil {
+			return nil
+		}
+
+		signedTrx, err := codec.ExtractEOSSignedTransactionFromReceipt(receipt)
+		if err != nil {
+			if traceEnabled {
+				zlog.Debug("unable to extract signed transaction from receipt", zap.String("trx_id", trxID), zap.Error(err))
+			}
+			return nil
+		}
+
+		signers := codec.GetPublicKeysFromSignedTransaction(v.chainID, signedTrx)
+		if signers == nil {
+			signers = []string{}
+		}
+
+		return signers
+	}
+}
+
+func (v *filterVersion) shouldProcess(trx *transactionActivation, actTrace *pbcodec.ActionTrace) bool {
+	// System actions the rest of the stack depends on are included whatever the programs say
+	if v.isAlwaysIncluded(actTrace) {
+		return true
+	}
+
+	return v.matchesPrograms(trx, actTrace)
+}
+
+func (v *filterVersion) matchesPrograms(trx *transactionActivation, actTrace *pbcodec.ActionTrace) bool {
+	activation := actionTraceActivation{trace: actTrace, trx: trx}
 	// If the include program does not match, there is nothing more to do here
-	if !f.IncludeProgram.match(&activation) {
+	if !v.includeProgram.match(&activation) {
 		return false
 	}
 
 	// At this point, the inclusion expr matched, let's check it was included but should be now excluded based on the exclusion filter
-	if f.ExcludeProgram.match(&activation) {
+	if v.excludeProgram.match(&activation) {
 		return false
 	}
 
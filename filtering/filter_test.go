@@ -1,6 +1,7 @@
 package filtering
 
 import (
+	"fmt"
 	"testing"
 
 	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
@@ -98,6 +99,62 @@ func TestBlockFilter(t *testing.T) {
 			ct.TrxTrace(t, ct.ActionTrace(t, "badguy:any:any", ct.ActionData(`{}`))),
 			filterDidNotMatch,
 		},
+		{
+			"db table and key of the action are matched",
+			"",
+			`db.table == 'accounts' && db.key == 'bob'`,
+			ct.TrxTrace(t, ct.ActionTrace(t, "eosio.token:transfer"),
+				&pbcodec.DBOp{ActionIndex: 0, Code: "eosio.token", Scope: "alice", TableName: "accounts", PrimaryKey: "alice"},
+				&pbcodec.DBOp{ActionIndex: 0, Code: "eosio.token", Scope: "bob", TableName: "accounts", PrimaryKey: "bob"},
+			),
+			filterDidNotMatch,
+		},
+		{
+			"db fields are matched against the same operation",
+			"",
+			`db.table == 'accounts' && db.key == 'bob'`,
+			ct.TrxTrace(t, ct.ActionTrace(t, "eosio.token:transfer"),
+				&pbcodec.DBOp{ActionIndex: 0, Code: "eosio.token", Scope: "bob", TableName: "accounts", PrimaryKey: "alice"},
+				&pbcodec.DBOp{ActionIndex: 0, Code: "eosio.token", Scope: "bob", TableName: "stat", PrimaryKey: "bob"},
+			),
+			filterMatched,
+		},
+		{
+			"db ops of other actions are not considered",
+			"",
+			`db.table == 'accounts'`,
+			ct.TrxTrace(t, ct.ActionTrace(t, "eosio.token:transfer"),
+				&pbcodec.DBOp{ActionIndex: 1, Code: "eosio.token", Scope: "bob", TableName: "accounts", PrimaryKey: "bob"},
+			),
+			filterMatched,
+		},
+		{
+			"db without any operation is empty",
+			"",
+			`db.table == '' && db.key == ''`,
+			ct.TrxTrace(t, ct.ActionTrace(t, "eosio.token:transfer")),
+			filterDidNotMatch,
+		},
+		{
+			"ram consumed by action",
+			"",
+			`ram.consumed > 0`,
+			ct.TrxTrace(t, ct.ActionTrace(t, "eosio.token:transfer"),
+				&pbcodec.RAMOp{ActionIndex: 0, Payer: "bob", Delta: 112},
+			),
+			filterDidNotMatch,
+		},
+		{
+			"ram released by action",
+			"",
+			`ram.released == 240 && ram.consumed == 0`,
+			ct.TrxTrace(t, ct.ActionTrace(t, "eosio.token:transfer"),
+				&pbcodec.RAMOp{ActionIndex: 0, Payer: "bob", Delta: -112},
+				&pbcodec.RAMOp{ActionIndex: 0, Payer: "alice", Delta: -128},
+				&pbcodec.RAMOp{ActionIndex: 1, Payer: "alice", Delta: 128},
+			),
+			filterDidNotMatch,
+		},
 		{
 			"prevent a failure on evaluation, so matches because blacklist fails",
 			"",
@@ -115,9 +172,9 @@ func TestBlockFilter(t *testing.T) {
 			require.NoError(t, err)
 
 			if test.expectedPass {
-				assert.True(t, filter.shouldProcess(test.trace, test.trace.ActionTraces[0]), "Expected action trace to match filter (include %s, exclude %s) but it did not", test.include, test.exclude)
+				assert.True(t, filter.versionFor(0).shouldProcess(newTransactionActivation(test.trace, false, nil), test.trace.ActionTraces[0]), "Expected action trace to match filter (include %s, exclude %s) but it did not", test.include, test.exclude)
 			} else {
-				assert.False(t, filter.shouldProcess(test.trace, test.trace.ActionTraces[0]), "Expected action trace to NOT match filter (include %s, exclude %s) but it did", test.include, test.exclude)
+				assert.False(t, filter.versionFor(0).shouldProcess(newTransactionActivation(test.trace, false, nil), test.trace.ActionTraces[0]), "Expected action trace to NOT match filter (include %s, exclude %s) but it did", test.include, test.exclude)
 			}
 		})
 	}
@@ -130,3 +187,238 @@ func TestCompileCELPrograms(t *testing.T) {
 	_, err = NewBlockFilter("", "ken")
 	require.Error(t, err)
 }
+
+func TestScheduledBlockFilter(t *testing.T) {
+	schedule, err := parseFilterSchedule([]byte(`
+- start_block: 10
+  exclude: "receiver == 'spamcoin'"
+- start_block: 120
+  include: "*"
+  exclude: "receiver == 'spamcoin' || receiver == 'eidosonecoin'"
+`))
+	require.NoError(t, err)
+
+	filter, err := NewScheduledBlockFilter(schedule)
+	require.NoError(t, err)
+
+	spamcoin := ct.TrxTrace(t, ct.ActionTrace(t, "spamcoin:spamcoin:transfer"))
+	eidosonecoin := ct.TrxTrace(t, ct.ActionTrace(t, "eidosonecoin:eidosonecoin:transfer"))
+
+	tests := []struct {
+		blockNum        uint64
+		expectedNoop    bool
+		expectedExclude string
+		trace           *pbcodec.TransactionTrace
+		expectedPass    bool
+	}{
+		{9, true, "", spamcoin, true},
+		{10, false, "receiver == 'spamcoin'", spamcoin, false},
+		{119, false, "receiver == 'spamcoin'", eidosonecoin, true},
+		{120, false, "receiver == 'spamcoin' || receiver == 'eidosonecoin'", eidosonecoin, false},
+		{1000, false, "receiver == 'spamcoin' || receiver == 'eidosonecoin'", spamcoin, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("block #%d", test.blockNum), func(t *testing.T) {
+			version := filter.versionFor(test.blockNum)
+
+			assert.Equal(t, test.expectedNoop, version.IsNoop())
+			assert.Equal(t, test.expectedExclude, version.excludeProgram.code)
+			assert.Equal(t, test.expectedPass, version.shouldProcess(newTransactionActivation(test.trace, false, nil), test.trace.ActionTraces[0]))
+		})
+	}
+}
+
+func TestParseFilterSchedule(t *testing.T) {
+	_, err := parseFilterSchedule([]byte(``))
+	require.Error(t, err)
+
+	_, err = parseFilterSchedule([]byte(`
+- start_block: 10
+- start_block: 10
+`))
+	require.Error(t, err)
+
+	_, err = parseFilterSchedule([]byte(`
+- start_block: 10
+  unknown: "field"
+`))
+	require.Error(t, err)
+}
+
+func TestFilterScheduleOverride(t *testing.T) {
+	schedule := FilterSchedule{
+		{StartBlock: 10, Include: "receiver == 'a'", Exclude: "receiver == 'b'"},
+		{StartBlock: 20, Include: "receiver == 'c'"},
+	}
+
+	overridden := schedule.Override("", "receiver == 'spam'")
+	require.Len(t, overridden, 3)
+	assert.Equal(t, &FilterScheduleEntry{StartBlock: 0, Exclude: "receiver == 'spam'"}, overridden[0])
+	assert.Equal(t, &FilterScheduleEntry{StartBlock: 10, Include: "receiver == 'a'", Exclude: "receiver == 'spam'"}, overridden[1])
+	assert.Equal(t, &FilterScheduleEntry{StartBlock: 20, Include: "receiver == 'c'", Exclude: "receiver == 'spam'"}, overridden[2])
+
+	assert.Equal(t, "receiver == 'b'", schedule[0].Exclude, "original schedule must be left untouched")
+}
+
+func TestBlockFilterFunctions(t *testing.T) {
+	RegisterNamedSet("spam_list", []string{"spamcoin", "eidosonecoin"})
+
+	authorized := func(trace *pbcodec.TransactionTrace, actor, permission string) *pbcodec.TransactionTrace {
+		trace.ActionTraces[0].Action.Authorization = []*pbcodec.PermissionLevel{{Actor: actor, Permission: permission}}
+		return trace
+	}
+
+	transfer := func(quantity string) *pbcodec.TransactionTrace {
+		return ct.TrxTrace(t, ct.ActionTrace(t, "eosio.token:transfer", ct.ActionData(`{"from":"bob.eos","to":"alice","quantity":"`+quantity+`"}`)))
+	}
+
+	tests := []struct {
+		name         string
+		exclude      string
+		trace        *pbcodec.TransactionTrace
+		expectedPass bool
+	}{
+		{"asset_amount above", `asset_amount(data.quantity) > 100.0`, transfer("100.0001 EOS"), false},
+		{"asset_amount below", `asset_amount(data.quantity) > 100.0`, transfer("99.9999 EOS"), true},
+		{"asset_amount invalid", `asset_amount(data.quantity) > 100.0`, transfer("invalid"), true},
+		{"asset_symbol", `asset_symbol(data.quantity) == 'EOS'`, transfer("1.0000 EOS"), false},
+		{"asset_symbol other", `asset_symbol(data.quantity) == 'EOS'`, transfer("1.0000 WAX"), true},
+		{"name_suffix", `name_suffix(data.from) == 'eos'`, transfer("1.0000 EOS"), false},
+		{"name_suffix without dot", `name_suffix(data.to) == 'alice'`, transfer("1.0000 EOS"), false},
+		{"name_suffix no match", `name_suffix(receiver) == 'eosio'`, transfer("1.0000 EOS"), true},
+		{"starts_with_any string", `starts_with_any(data.to, ['ali', 'bo'])`, transfer("1.0000 EOS"), false},
+		{"starts_with_any list", `starts_with_any(auth, ['eosio@'])`, authorized(ct.TrxTrace(t, ct.ActionTrace(t, "eosio:buyram")), "eosio", "active"), false},
+		{"starts_with_any none", `starts_with_any(receiver, ['bob', 'alice'])`, transfer("1.0000 EOS"), true},
+		{"in_set", `in_set(receiver, 'spam_list')`, ct.TrxTrace(t, ct.ActionTrace(t, "spamcoin:transfer")), false},
+		{"in_set not in set", `in_set(receiver, 'spam_list')`, transfer("1.0000 EOS"), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := NewBlockFilter("", test.exclude)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedPass, filter.versionFor(0).shouldProcess(newTransactionActivation(test.trace, false, nil), test.trace.ActionTraces[0]))
+		})
+	}
+}
+
+func TestBlockFilterInSetErrors(t *testing.T) {
+	RegisterNamedSet("spam_list", []string{"spamcoin"})
+
+	_, err := NewBlockFilter("", `in_set(receiver, 'unknown')`)
+	assert.EqualError(t, err, `exclude filter (starting at block #0): in_set: unknown set "unknown"`)
+
+	_, err = NewBlockFilter("", `in_set(receiver, receiver)`)
+	assert.EqualError(t, err, `exclude filter (starting at block #0): in_set: set name must be a string literal`)
+
+	filter, err := NewBlockFilter("", `in_set(receiver, 'spam_list')`)
+	require.NoError(t, err)
+
+	// Sets are resolved when the filter is created, registering the set again does not affect it
+	RegisterNamedSet("spam_list", []string{"eidosonecoin"})
+	trace := ct.TrxTrace(t, ct.ActionTrace(t, "spamcoin:transfer"))
+	assert.False(t, filter.versionFor(0).shouldProcess(newTransactionActivation(trace, false, nil), trace.ActionTraces[0]))
+}
+
+func TestBlockFilterUsesSigners(t *testing.T) {
+	tests := []struct {
+		exclude     string
+		expectUsage bool
+	}{
+		{`'EOS5MHPYyhjBjnQZejzZHqHewPWhGTfQWSVTWYEhDmJu4SXkzgweP' in trx.signers`, true},
+		{`size(trx['signers']) > 1`, true},
+		{`data.signers == 'alice'`, false},
+		{`receiver == 'signers'`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.exclude, func(t *testing.T) {
+			filter, err := NewBlockFilter("", test.exclude)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectUsage, filter.versionFor(0).usesSigners)
+		})
+	}
+}
+
+func TestBlockFilterTransactionIdentifiers(t *testing.T) {
+	block := ct.Block(t, "00000010aa", ct.BlockTime("2020-06-01T12:00:00Z"),
+		ct.TrxTrace(t, ct.TrxID("trx1"), ct.ActionTrace(t, "eosio.token:transfer"), ct.ActionTrace(t, "alice:eosio.token:transfer")),
+		ct.TrxTrace(t, ct.TrxID("trx2"), pbcodec.TransactionStatus_TRANSACTIONSTATUS_HARDFAIL, ct.ActionTrace(t, "eosio.token:transfer")),
+	)
+
+	executed := block.UnfilteredTransactionTraces[0]
+	executed.Receipt.CpuUsageMicroSeconds = 1200
+	executed.NetUsage = 128
+
+	hardFailed := block.UnfilteredTransactionTraces[1]
+
+	tests := []struct {
+		name         string
+		exclude      string
+		trx          *transactionActivation
+		expectedPass bool
+	}{
+		{"trx.id", `trx.id == 'trx1'`, newTransactionActivation(executed, false, nil), false},
+		{"trx.id other", `trx.id == 'trx1'`, newTransactionActivation(hardFailed, false, nil), true},
+		{"trx.cpu_usage", `trx.cpu_usage > 1000`, newTransactionActivation(executed, false, nil), false},
+		{"trx.net_usage", `trx.net_usage >= 128`, newTransactionActivation(executed, false, nil), false},
+		{"trx.action_count", `trx.action_count == 2`, newTransactionActivation(executed, false, nil), false},
+		{"trx.action_count other", `trx.action_count == 2`, newTransactionActivation(hardFailed, false, nil), true},
+		{"trx.status", `trx.status == 'hard_fail'`, newTransactionActivation(hardFailed, false, nil), false},
+		{"trx.status executed", `trx.status == 'hard_fail'`, newTransactionActivation(executed, false, nil), true},
+		{"trx.scheduled", `trx.scheduled`, newTransactionActivation(executed, false, nil), true},
+		{"trx.failed_dtrx", `trx.failed_dtrx`, newTransactionActivation(hardFailed, true, nil), false},
+		{"trx.signers", `'EOS5MHPYyhjBjnQZejzZHqHewPWhGTfQWSVTWYEhDmJu4SXkzgweP' in trx.signers`, newTransactionActivation(executed, false, []string{"EOS5MHPYyhjBjnQZejzZHqHewPWhGTfQWSVTWYEhDmJu4SXkzgweP"}), false},
+		{"trx.signers not signed", `'EOS5MHPYyhjBjnQZejzZHqHewPWhGTfQWSVTWYEhDmJu4SXkzgweP' in trx.signers`, newTransactionActivation(executed, false, []string{}), true},
+		{"trx.signers unknown", `'EOS5MHPYyhjBjnQZejzZHqHewPWhGTfQWSVTWYEhDmJu4SXkzgweP' in trx.signers`, newTransactionActivation(executed, false, nil), true},
+		{"block.num", `block.num == 16`, newTransactionActivation(executed, false, nil), false},
+		{"block.num other", `block.num > 16`, newTransactionActivation(executed, false, nil), true},
+		{"block.time", `block.time >= timestamp('2020-06-01T12:00:00Z')`, newTransactionActivation(executed, false, nil), false},
+		{"block.time before", `block.time < timestamp('2020-06-01T12:00:00Z')`, newTransactionActivation(executed, false, nil), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := NewBlockFilter("", test.exclude)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedPass, filter.versionFor(0).shouldProcess(test.trx, test.trx.trace.ActionTraces[0]))
+		})
+	}
+}
+
+func TestBlockFilterAlwaysIncludedActions(t *testing.T) {
+	setabi := ct.TrxTrace(t, ct.ActionTrace(t, "eosio:setabi"))
+	buyram := ct.TrxTrace(t, ct.ActionTrace(t, "eosio:buyram"))
+
+	filter, err := NewBlockFilter("", "account == 'eosio'")
+	require.NoError(t, err)
+
+	assert.True(t, filter.versionFor(0).shouldProcess(newTransactionActivation(setabi, false, nil), setabi.ActionTraces[0]))
+	assert.False(t, filter.versionFor(0).shouldProcess(newTransactionActivation(buyram, false, nil), buyram.ActionTraces[0]))
+
+	filter, err = NewBlockFilter("receiver == 'eosio.token'", "")
+	require.NoError(t, err)
+
+	assert.True(t, filter.versionFor(0).shouldProcess(newTransactionActivation(setabi, false, nil), setabi.ActionTraces[0]))
+
+	filter, err = NewBlockFilter("", "account == 'eosio'", WithAlwaysIncludedActions(nil))
+	require.NoError(t, err)
+
+	assert.False(t, filter.versionFor(0).shouldProcess(newTransactionActivation(setabi, false, nil), setabi.ActionTraces[0]))
+
+	filter, err = NewBlockFilter("", "account == 'eosio'", WithAlwaysIncludedActions([]string{"eosio:buyram"}))
+	require.NoError(t, err)
+
+	assert.False(t, filter.versionFor(0).shouldProcess(newTransactionActivation(setabi, false, nil), setabi.ActionTraces[0]))
+	assert.True(t, filter.versionFor(0).shouldProcess(newTransactionActivation(buyram, false, nil), buyram.ActionTraces[0]))
+
+	_, err = NewBlockFilter("", "", WithAlwaysIncludedActions([]string{"eosio"}))
+	require.Error(t, err)
+
+	_, err = NewBlockFilter("", "", WithAlwaysIncludedActions([]string{"eosio:"}))
+	require.Error(t, err)
+}
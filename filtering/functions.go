@@ -0,0 +1,201 @@
+package filtering
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/eoscanada/eos-go"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/interpreter/functions"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// functionDeclarations are the EOSIO specific functions available to filter programs, their
+// implementation being defined in `functionOverloads`.
+var functionDeclarations = []*exprpb.Decl{
+	decls.NewFunction("asset_amount",
+		decls.NewOverload("asset_amount_string", []*exprpb.Type{decls.String}, decls.Double),
+	),
+	decls.NewFunction("asset_symbol",
+		decls.NewOverload("asset_symbol_string", []*exprpb.Type{decls.String}, decls.String),
+	),
+	decls.NewFunction("name_suffix",
+		decls.NewOverload("name_suffix_string", []*exprpb.Type{decls.String}, decls.String),
+	),
+	decls.NewFunction("starts_with_any",
+		decls.NewOverload("starts_with_any_string_list", []*exprpb.Type{decls.String, decls.NewListType(decls.String)}, decls.Bool),
+		decls.NewOverload("starts_with_any_list_list", []*exprpb.Type{decls.NewListType(decls.String), decls.NewListType(decls.String)}, decls.Bool),
+	),
+	decls.NewFunction("in_set",
+		decls.NewOverload("in_set_string_string", []*exprpb.Type{decls.String, decls.String}, decls.Bool),
+		decls.NewOverload("in_set_list_string", []*exprpb.Type{decls.NewListType(decls.String), decls.String}, decls.Bool),
+	),
+}
+
+// functionOverloads returns the implementations of the functions of `functionDeclarations`,
+// `in_set` looking up the received named sets, resolved when compiling the program, see
+// `resolveNamedSets`.
+func functionOverloads(sets map[string]map[string]struct{}) []*functions.Overload {
+	return []*functions.Overload{
+		{Operator: "asset_amount", Unary: assetAmount},
+		{Operator: "asset_symbol", Unary: assetSymbol},
+		{Operator: "name_suffix", Unary: nameSuffix},
+		{Operator: "starts_with_any", Binary: startsWithAny},
+		{Operator: "in_set", Binary: func(value ref.Val, setNameValue ref.Val) ref.Val {
+			return inSet(sets, value, setNameValue)
+		}},
+	}
+}
+
+// resolveNamedSets returns the named sets used by the `in_set` calls of the program, their set
+// name having to be the string literal of a registered set so that errors are reported when the
+// program is compiled and that evaluating it does not go through the sets registry.
+func resolveNamedSets(expr *exprpb.Expr) (sets map[string]map[string]struct{}, err error) {
+	sets = map[string]map[string]struct{}{}
+
+	// Returning `true` stops the walk, which we do on the first error
+	exprReferences(expr, func(candidate *exprpb.Expr) bool {
+		call := candidate.GetCallExpr()
+		if call == nil || call.Function != "in_set" || len(call.Args) != 2 {
+			return false
+		}
+
+		setName, ok := stringLiteral(call.Args[1])
+		if !ok {
+			err = fmt.Errorf("in_set: set name must be a string literal")
+			return true
+		}
+
+		set := namedSet(setName)
+		if set == nil {
+			err = fmt.Errorf("in_set: unknown set %q", setName)
+			return true
+		}
+
+		sets[setName] = set
+		return false
+	})
+
+	return sets, err
+}
+
+// assetAmount returns the decimal amount of an asset string like `1.2345 EOS`, so `1.2345`.
+func assetAmount(value ref.Val) ref.Val {
+	asset, err := toAsset(value)
+	if err != nil {
+		return types.NewErr("asset_amount: %s", err)
+	}
+
+	return types.Double(float64(asset.Amount) / math.Pow10(int(asset.Precision)))
+}
+
+// assetSymbol returns the symbol code of an asset string like `1.2345 EOS`, so `EOS`.
+func assetSymbol(value ref.Val) ref.Val {
+	asset, err := toAsset(value)
+	if err != nil {
+		return types.NewErr("asset_symbol: %s", err)
+	}
+
+	return types.String(asset.Symbol.Symbol)
+}
+
+func toAsset(value ref.Val) (out eos.Asset, err error) {
+	in, ok := value.(types.String)
+	if !ok {
+		return out, fmt.Errorf("expected a string, got %s", value.Type().TypeName())
+	}
+
+	return eos.NewAssetFromString(string(in))
+}
+
+// nameSuffix returns the part of an account name after its last dot, `eos` for `bob.eos`,
+// or the full name when it contains no dot, following EOSIO's `name::suffix` semantics.
+func nameSuffix(value ref.Val) ref.Val {
+	name, ok := value.(types.String)
+	if !ok {
+		return types.NewErr("name_suffix: expected a string, got %s", value.Type().TypeName())
+	}
+
+	in := string(name)
+	if index := strings.LastIndexByte(in, '.'); index != -1 {
+		return types.String(in[index+1:])
+	}
+
+	return name
+}
+
+// startsWithAny returns `true` when the string, or any element of the string list, starts with
+// any of the prefixes received.
+func startsWithAny(value ref.Val, prefixesValue ref.Val) ref.Val {
+	candidates, err := toStrings(value)
+	if err != nil {
+		return types.NewErr("starts_with_any: %s", err)
+	}
+
+	prefixes, err := toStrings(prefixesValue)
+	if err != nil {
+		return types.NewErr("starts_with_any: %s", err)
+	}
+
+	for _, candidate := range candidates {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(candidate, prefix) {
+				return types.True
+			}
+		}
+	}
+
+	return types.False
+}
+
+// inSet returns `true` when the string, or any element of the string list, is part of the
+// named set, looked up in the sets resolved when the program was compiled.
+func inSet(sets map[string]map[string]struct{}, value ref.Val, setNameValue ref.Val) ref.Val {
+	setName, ok := setNameValue.(types.String)
+	if !ok {
+		return types.NewErr("in_set: expected set name to be a string, got %s", setNameValue.Type().TypeName())
+	}
+
+	set, found := sets[string(setName)]
+	if !found {
+		return types.NewErr("in_set: unknown set %q", string(setName))
+	}
+
+	candidates, err := toStrings(value)
+	if err != nil {
+		return types.NewErr("in_set: %s", err)
+	}
+
+	for _, candidate := range candidates {
+		if _, found := set[candidate]; found {
+			return types.True
+		}
+	}
+
+	return types.False
+}
+
+func toStrings(value ref.Val) ([]string, error) {
+	switch v := value.(type) {
+	case types.String:
+		return []string{string(v)}, nil
+	case traits.Lister:
+		var out []string
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			element, ok := it.Next().(types.String)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings")
+			}
+
+			out = append(out, string(element))
+		}
+
+		return out, nil
+	}
+
+	return nil, fmt.Errorf("expected a string or a list of strings, got %s", value.Type().TypeName())
+}
@@ -0,0 +1,147 @@
+package filtering
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"go.uber.org/zap"
+)
+
+// DefaultAlwaysIncludedActions are the system actions the rest of the stack depends on, like
+// `abicodec` for ABIs, `fluxdb` for permissions and `blockmeta` for account creations. They are
+// included whatever the filter programs say, unless changed with `WithAlwaysIncludedActions`.
+var DefaultAlwaysIncludedActions = []string{
+	"eosio:newaccount",
+	"eosio:setabi",
+	"eosio:setcode",
+	"eosio:onblock",
+	"eosio:updateauth",
+	"eosio:deleteauth",
+	"eosio:linkauth",
+	"eosio:unlinkauth",
+}
+
+// WithAlwaysIncludedActions replaces the `DefaultAlwaysIncludedActions` of the block filter by
+// `actions`, each in the form `<account>:<action>`, that are included before the include and
+// exclude programs are even evaluated. An empty list disables the feature completely.
+func WithAlwaysIncludedActions(actions []string) BlockFilterOption {
+	return func(o *blockFilterOptions) error {
+		set, err := newActionSet(actions)
+		if err != nil {
+			return err
+		}
+
+		o.alwaysIncluded = set
+		return nil
+	}
+}
+
+func newActionSet(actions []string) (map[string]bool, error) {
+	set := make(map[string]bool, len(actions))
+	for _, action := range actions {
+		parts := strings.Split(action, ":")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid always included action %q, expected <account>:<action>", action)
+		}
+
+		set[action] = true
+	}
+
+	return set, nil
+}
+
+func mustNewActionSet(actions []string) map[string]bool {
+	set, err := newActionSet(actions)
+	if err != nil {
+		panic(err)
+	}
+
+	return set
+}
+
+func (v *filterVersion) isAlwaysIncluded(actTrace *pbcodec.ActionTrace) bool {
+	if len(v.alwaysIncluded) == 0 {
+		return false
+	}
+
+	return v.alwaysIncluded[actTrace.Account()+":"+actTrace.Name()]
+}
+
+// recordedExprs returns the include and exclude expressions recorded in the blocks filtered by
+// the version. When the always included actions are not the `DefaultAlwaysIncludedActions`, they
+// are made part of the expressions, so that a block filtered with a different set of always
+// included actions is not considered as already filtered the same way. The expressions remain
+// equivalent CEL programs: always included actions are matched by the include expression and
+// never by the exclude one.
+func (v *filterVersion) recordedExprs() (includeExpr, excludeExpr string) {
+	includeExpr, excludeExpr = v.includeProgram.code, v.excludeProgram.code
+	if isDefaultActionSet(v.alwaysIncluded) {
+		return
+	}
+
+	actions := make([]string, 0, len(v.alwaysIncluded))
+	for action := range v.alwaysIncluded {
+		actions = append(actions, fmt.Sprintf("%q", action))
+	}
+	sort.Strings(actions)
+
+	alwaysIncludedExpr := fmt.Sprintf(`(account + ":" + action) in [%s]`, strings.Join(actions, ", "))
+	if !v.includeProgram.IsNoop() {
+		includeExpr = fmt.Sprintf("%s || (%s)", alwaysIncludedExpr, includeExpr)
+	}
+
+	if !v.excludeProgram.IsNoop() {
+		excludeExpr = fmt.Sprintf("!(%s) && (%s)", alwaysIncludedExpr, excludeExpr)
+	}
+
+	return
+}
+
+func isDefaultActionSet(set map[string]bool) bool {
+	if len(set) != len(DefaultAlwaysIncludedActions) {
+		return false
+	}
+
+	for _, action := range DefaultAlwaysIncludedActions {
+		if !set[action] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// warnExcludedAlwaysIncludedActions logs a warning for each always included action that the
+// programs of the version would have excluded on their own. The programs are evaluated against
+// a synthetic action authorized by `<account>@active` and without any data, so this is a best
+// effort check that cannot catch expressions depending on the action's data.
+func (v *filterVersion) warnExcludedAlwaysIncludedActions() {
+	if v.IsNoop() {
+		return
+	}
+
+	for action := range v.alwaysIncluded {
+		parts := strings.Split(action, ":")
+		actTrace := &pbcodec.ActionTrace{
+			Receiver: parts[0],
+			Receipt:  &pbcodec.ActionReceipt{Receiver: parts[0]},
+			Action: &pbcodec.Action{
+				Account:       parts[0],
+				Name:          parts[1],
+				Authorization: []*pbcodec.PermissionLevel{{Actor: parts[0], Permission: "active"}},
+			},
+		}
+
+		trxTrace := &pbcodec.TransactionTrace{ActionTraces: []*pbcodec.ActionTrace{actTrace}}
+		if !v.matchesPrograms(newTransactionActivation(trxTrace, false, nil), actTrace) {
+			zlog.Warn("filter programs would exclude a system action, it is always included regardless",
+				zap.String("action", action),
+				zap.Uint64("start_block", v.startBlockNum),
+				zap.String("include_expr", v.includeProgram.code),
+				zap.String("exclude_expr", v.excludeProgram.code),
+			)
+		}
+	}
+}
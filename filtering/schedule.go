@@ -0,0 +1,100 @@
+package filtering
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// FilterSchedule is a list of filter programs versioned by block ranges, each entry
+// applying from its start block up to the start block of the next entry.
+type FilterSchedule []*FilterScheduleEntry
+
+type FilterScheduleEntry struct {
+	StartBlock uint64 `yaml:"start_block"`
+	Include    string `yaml:"include"`
+	Exclude    string `yaml:"exclude"`
+}
+
+// LoadFilterSchedule reads a filter schedule from a YAML file in the form:
+//
+//   - start_block: 0
+//     include: "*"
+//     exclude: "receiver == 'spamcoin'"
+//   - start_block: 120000000
+//     include: "*"
+//     exclude: "receiver == 'spamcoin' || receiver == 'eidosonecoin'"
+func LoadFilterSchedule(path string) (FilterSchedule, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read filter schedule file %q: %w", path, err)
+	}
+
+	schedule, err := parseFilterSchedule(content)
+	if err != nil {
+		return nil, fmt.Errorf("filter schedule file %q: %w", path, err)
+	}
+
+	return schedule, nil
+}
+
+func parseFilterSchedule(content []byte) (FilterSchedule, error) {
+	var schedule FilterSchedule
+	if err := yaml.UnmarshalStrict(content, &schedule); err != nil {
+		return nil, fmt.Errorf("unmarshal yaml: %w", err)
+	}
+
+	if err := schedule.validate(); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// Override returns a copy of the schedule where the include and exclude programs of every
+// entry are replaced by `include` and `exclude`, an empty program leaving the entries' ones
+// untouched. When the schedule does not start at block 0, an entry with no-op programs is
+// first added at block 0 so that the overriding programs apply to all blocks.
+func (s FilterSchedule) Override(include, exclude string) FilterSchedule {
+	entries := s
+	if len(s) > 0 && s[0].StartBlock > 0 {
+		entries = append(FilterSchedule{{StartBlock: 0}}, s...)
+	}
+
+	out := make(FilterSchedule, len(entries))
+	for i, entry := range entries {
+		overridden := *entry
+		if include != "" {
+			overridden.Include = include
+		}
+
+		if exclude != "" {
+			overridden.Exclude = exclude
+		}
+
+		out[i] = &overridden
+	}
+
+	return out
+}
+
+func (s FilterSchedule) validate() error {
+	if len(s) == 0 {
+		return fmt.Errorf("filter schedule must have at least one entry")
+	}
+
+	for i, entry := range s {
+		if entry == nil {
+			return fmt.Errorf("filter schedule entry #%d is empty", i)
+		}
+	}
+
+	for i := 1; i < len(s); i++ {
+		if s[i].StartBlock <= s[i-1].StartBlock {
+			return fmt.Errorf("filter schedule entries must be sorted by strictly increasing start block, entry #%d starts at block #%d but previous one starts at block #%d", i, s[i].StartBlock, s[i-1].StartBlock)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package filtering
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+var namedSetsLock sync.RWMutex
+var namedSets = map[string]map[string]struct{}{}
+
+// RegisterNamedSet registers a set of values under `name` so that filter programs can test
+// membership with the `in_set(<value>, '<name>')` function. Sets are resolved when filter
+// programs are compiled, so they must be registered before, registering a set under an
+// already registered name replaces it for the programs compiled afterwards.
+func RegisterNamedSet(name string, values []string) {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+
+	namedSetsLock.Lock()
+	defer namedSetsLock.Unlock()
+
+	namedSets[name] = set
+}
+
+// LoadNamedSetFile reads the file at `path`, one value per line, and registers its values
+// under `name`, see `RegisterNamedSet`. Empty lines and lines starting with `#` are ignored.
+func LoadNamedSetFile(name string, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open set file %q: %w", path, err)
+	}
+	defer file.Close()
+
+	var values []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		values = append(values, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read set file %q: %w", path, err)
+	}
+
+	zlog.Info("loaded named set", zap.String("name", name), zap.String("path", path), zap.Int("value_count", len(values)))
+	RegisterNamedSet(name, values)
+	return nil
+}
+
+// LoadNamedSetFiles loads each named set file defined by the received specs, each spec being
+// in the form `<name>=<path>`, see `LoadNamedSetFile`.
+func LoadNamedSetFiles(specs []string) error {
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid named set spec %q, expected <name>=<path>", spec)
+		}
+
+		if err := LoadNamedSetFile(parts[0], parts[1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func namedSet(name string) map[string]struct{} {
+	namedSetsLock.RLock()
+	defer namedSetsLock.RUnlock()
+
+	return namedSets[name]
+}
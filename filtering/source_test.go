@@ -53,12 +53,13 @@ func TestFilteringPreprocessor(t *testing.T) {
 
 func TestFilteringTwice(t *testing.T) {
 	tests := []struct {
-		name                      string
-		include, exclude          string
-		include2, exclude2        string
-		block                     *pbcodec.Block
-		expected                  *pbcodec.Block
-		shouldPanicOnSecondFilter bool
+		name               string
+		include, exclude   string
+		include2, exclude2 string
+		block              *pbcodec.Block
+		expected           *pbcodec.Block
+		expectedInclude    string
+		expectedExclude    string
 	}{
 		{
 			"standard",
@@ -74,15 +75,49 @@ func TestFilteringTwice(t *testing.T) {
 			},
 				ct.TrxTrace(t, ct.ActionTrace(t, "eosio:eosio:newaccount", ct.ActionMatched)),
 			),
-			false,
+			"*", `receiver == "spamcoint"`,
 		},
 		{
-			"panicky",
+			"different exclusion are combined",
 			"*", `receiver == "spamcoint"`,
 			"*", `receiver == "spamcoin"`,
 			ct.Block(t, "00000001aa",
 				ct.TrxTrace(t, ct.ActionTrace(t, "eosio:eosio:newaccount")),
 				ct.TrxTrace(t, ct.ActionTrace(t, "spamcoint:spamcoint:transfer")),
+				ct.TrxTrace(t, ct.ActionTrace(t, "spamcoin:spamcoin:transfer")),
+			),
+			ct.Block(t, "00000001aa", ct.FilteredBlock{
+				UnfilteredStats: ct.Counts{TrxTraceCount: 3, ActTraceInputCount: 3, ActTraceTotalCount: 3},
+				FilteredStats:   ct.Counts{TrxTraceCount: 1, ActTraceInputCount: 1, ActTraceTotalCount: 1},
+			},
+				ct.TrxTrace(t, ct.ActionTrace(t, "eosio:eosio:newaccount", ct.ActionMatched)),
+			),
+			"*", `(receiver == "spamcoint") || (receiver == "spamcoin")`,
+		},
+		{
+			"narrower inclusion on already filtered block",
+			"*", `receiver == "spamcoint"`,
+			`receiver == "eosio"`, "",
+			ct.Block(t, "00000001aa",
+				ct.TrxTrace(t, ct.ActionTrace(t, "eosio:eosio:newaccount")),
+				ct.TrxTrace(t, ct.ActionTrace(t, "bob:eosio.token:transfer")),
+				ct.TrxTrace(t, ct.ActionTrace(t, "spamcoint:spamcoint:transfer")),
+			),
+			ct.Block(t, "00000001aa", ct.FilteredBlock{
+				UnfilteredStats: ct.Counts{TrxTraceCount: 3, ActTraceInputCount: 3, ActTraceTotalCount: 3},
+				FilteredStats:   ct.Counts{TrxTraceCount: 1, ActTraceInputCount: 1, ActTraceTotalCount: 1},
+			},
+				ct.TrxTrace(t, ct.ActionTrace(t, "eosio:eosio:newaccount", ct.ActionMatched)),
+			),
+			`receiver == "eosio"`, `receiver == "spamcoint"`,
+		},
+		{
+			"wider inclusion does not bring back excluded actions",
+			`receiver == "eosio"`, "",
+			"*", "",
+			ct.Block(t, "00000001aa",
+				ct.TrxTrace(t, ct.ActionTrace(t, "eosio:eosio:newaccount")),
+				ct.TrxTrace(t, ct.ActionTrace(t, "bob:eosio.token:transfer")),
 			),
 			ct.Block(t, "00000001aa", ct.FilteredBlock{
 				UnfilteredStats: ct.Counts{2, 2, 2},
@@ -90,17 +125,14 @@ func TestFilteringTwice(t *testing.T) {
 			},
 				ct.TrxTrace(t, ct.ActionTrace(t, "eosio:eosio:newaccount", ct.ActionMatched)),
 			),
-			true,
+			`receiver == "eosio"`, "",
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-
-			defer func() { recover() }()
-			// We assign them manually in the expected block to keep them in sync with ther `include`, `exclude` test parameters
-			test.expected.FilteringIncludeFilterExpr = test.include
-			test.expected.FilteringExcludeFilterExpr = test.exclude
+			test.expected.FilteringIncludeFilterExpr = test.expectedInclude
+			test.expected.FilteringExcludeFilterExpr = test.expectedExclude
 
 			filter, err := NewBlockFilter(test.include, test.exclude)
 			require.NoError(t, err)
@@ -111,21 +143,131 @@ func TestFilteringTwice(t *testing.T) {
 			_, err = preprocessor.PreprocessBlock(blk)
 			require.NoError(t, err)
 
-			assert.Equal(t, test.expected, blk.ToNative().(*pbcodec.Block))
-
 			filter2, err := NewBlockFilter(test.include2, test.exclude2)
 			require.NoError(t, err)
 
 			preprocessor2 := &FilteringPreprocessor{Filter: filter2}
 
 			_, err = preprocessor2.PreprocessBlock(blk)
-			if test.shouldPanicOnSecondFilter {
-				t.Errorf("%s did not panic", test.name)
-				return
-			}
 			require.NoError(t, err)
 
 			assert.Equal(t, test.expected, blk.ToNative().(*pbcodec.Block))
 		})
 	}
 }
+
+func TestStrictFiltering(t *testing.T) {
+	trxTrace := func(actTraces ...*pbcodec.ActionTrace) *pbcodec.TransactionTrace {
+		components := []interface{}{
+			&pbcodec.DBOp{ActionIndex: 0, Code: "eosio.token", TableName: "accounts"},
+			&pbcodec.DBOp{ActionIndex: 1, Code: "spamcoin", TableName: "stats"},
+			&pbcodec.RAMOp{ActionIndex: 1, Payer: "spamcoin", Delta: 128},
+			&pbcodec.TableOp{ActionIndex: 1, Payer: "spamcoin", TableName: "stats"},
+			&pbcodec.PermOp{ActionIndex: 0},
+		}
+
+		for _, actTrace := range actTraces {
+			components = append(components, actTrace)
+		}
+
+		return ct.TrxTrace(t, components...)
+	}
+
+	tests := []struct {
+		name     string
+		strict   bool
+		block    *pbcodec.Block
+		expected *pbcodec.Block
+	}{
+		{
+			"strict",
+			true,
+			ct.Block(t, "00000001aa",
+				trxTrace(ct.ActionTrace(t, "eosio.token:transfer"), ct.ActionTrace(t, "spamcoin:spamcoin:transfer", ct.ExecutionIndex(1))),
+			),
+			ct.Block(t, "00000001aa", ct.FilteredBlock{
+				UnfilteredStats: ct.Counts{TrxTraceCount: 1, ActTraceInputCount: 2, ActTraceTotalCount: 2},
+				FilteredStats:   ct.Counts{TrxTraceCount: 1, ActTraceInputCount: 1, ActTraceTotalCount: 1},
+			},
+				ct.TrxTrace(t,
+					&pbcodec.DBOp{ActionIndex: 0, Code: "eosio.token", TableName: "accounts"},
+					&pbcodec.PermOp{ActionIndex: 0},
+					ct.ActionTrace(t, "eosio.token:transfer", ct.ActionMatched),
+					ct.ActionTrace(t, "spamcoin:spamcoin:transfer", ct.ExecutionIndex(1)),
+				),
+			),
+		},
+		{
+			"not strict",
+			false,
+			ct.Block(t, "00000001aa",
+				trxTrace(ct.ActionTrace(t, "eosio.token:transfer"), ct.ActionTrace(t, "spamcoin:spamcoin:transfer", ct.ExecutionIndex(1))),
+			),
+			ct.Block(t, "00000001aa", ct.FilteredBlock{
+				UnfilteredStats: ct.Counts{TrxTraceCount: 1, ActTraceInputCount: 2, ActTraceTotalCount: 2},
+				FilteredStats:   ct.Counts{TrxTraceCount: 1, ActTraceInputCount: 1, ActTraceTotalCount: 1},
+			},
+				trxTrace(ct.ActionTrace(t, "eosio.token:transfer", ct.ActionMatched), ct.ActionTrace(t, "spamcoin:spamcoin:transfer", ct.ExecutionIndex(1))),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.expected.FilteringIncludeFilterExpr = "*"
+			test.expected.FilteringExcludeFilterExpr = `receiver == "spamcoin"`
+
+			filter, err := NewBlockFilter("*", `receiver == "spamcoin"`)
+			require.NoError(t, err)
+			filter.SetStrict(test.strict)
+
+			preprocessor := &FilteringPreprocessor{Filter: filter}
+			blk := ct.ToBstreamBlock(t, test.block)
+
+			_, err = preprocessor.PreprocessBlock(blk)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, blk.ToNative().(*pbcodec.Block))
+		})
+	}
+}
+
+func TestFilteringTwice_AlwaysIncludedActions(t *testing.T) {
+	block := ct.Block(t, "00000001aa",
+		ct.TrxTrace(t, ct.ActionTrace(t, "eosio:eosio:setabi")),
+		ct.TrxTrace(t, ct.ActionTrace(t, "eosio:eosio:buyram")),
+	)
+
+	filter, err := NewBlockFilter("", `account == "eosio"`)
+	require.NoError(t, err)
+
+	blk := ct.ToBstreamBlock(t, block)
+	require.NoError(t, filter.TransformInPlace(blk))
+
+	filtered := blk.ToNative().(*pbcodec.Block)
+	require.Len(t, filtered.FilteredTransactionTraces, 1)
+	assert.Equal(t, `account == "eosio"`, filtered.FilteringExcludeFilterExpr)
+
+	// A different set of always included actions filters the block further
+	filter, err = NewBlockFilter("", `account == "eosio"`, WithAlwaysIncludedActions([]string{"eosio:buyram"}))
+	require.NoError(t, err)
+	require.NoError(t, filter.TransformInPlace(blk))
+
+	filtered = blk.ToNative().(*pbcodec.Block)
+	assert.Len(t, filtered.FilteredTransactionTraces, 0)
+	assert.Equal(t, "", filtered.FilteringIncludeFilterExpr)
+	assert.Equal(t, `(account == "eosio") || (!((account + ":" + action) in ["eosio:buyram"]) && (account == "eosio"))`, filtered.FilteringExcludeFilterExpr)
+
+	// Recorded expressions are valid programs
+	_, err = newCELFilterExclude(filtered.FilteringExcludeFilterExpr)
+	require.NoError(t, err)
+
+	// The same set of always included actions is considered as already filtered the same way
+	blk = ct.ToBstreamBlock(t, block)
+	require.NoError(t, filter.TransformInPlace(blk))
+	require.NoError(t, filter.TransformInPlace(blk))
+
+	filtered = blk.ToNative().(*pbcodec.Block)
+	assert.Len(t, filtered.FilteredTransactionTraces, 1)
+	assert.Equal(t, `!((account + ":" + action) in ["eosio:buyram"]) && (account == "eosio")`, filtered.FilteringExcludeFilterExpr)
+}
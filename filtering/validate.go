@@ -0,0 +1,509 @@
+package filtering
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/eoscanada/eos-go"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/operators"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// ABIGetter returns the ABI of the contract deployed on `account`, `nil` when the ABI is not known.
+type ABIGetter func(account string) (*eos.ABI, error)
+
+// ValidationIssue is a probable mistake found in a filter program by the static validation,
+// the program is still valid and can be used as is.
+type ValidationIssue struct {
+	Program    string // `include` or `exclude`
+	StartBlock uint64 // Start block of the filter version the program is part of
+	Position   int32  // Position of the offending subexpression in the program's code
+	Message    string
+}
+
+func (i *ValidationIssue) String() string {
+	return fmt.Sprintf("%s program (starting at block #%d), position %d: %s", i.Program, i.StartBlock, i.Position, i.Message)
+}
+
+// Validate statically analyzes the include and exclude programs of each version of the filter,
+// see `ValidateFilterProgram`.
+func (f *BlockFilter) Validate(getABI ABIGetter) ([]*ValidationIssue, error) {
+	var out []*ValidationIssue
+	for _, version := range f.versions {
+		for _, program := range []struct {
+			name   string
+			filter *CELFilter
+		}{{"include", version.includeProgram}, {"exclude", version.excludeProgram}} {
+			if program.filter.IsNoop() {
+				continue
+			}
+
+			issues, err := ValidateFilterProgram(program.filter.code, getABI)
+			if err != nil {
+				return nil, fmt.Errorf("%s program (starting at block #%d): %w", program.name, version.startBlockNum, err)
+			}
+
+			for _, issue := range issues {
+				issue.Program = program.name
+				issue.StartBlock = version.startBlockNum
+			}
+
+			out = append(out, issues...)
+		}
+	}
+
+	return out, nil
+}
+
+// ValidateFilterProgram statically analyzes a filter program and reports probable mistakes:
+//
+//   - `data.*` fields that exist on none of the actions of the contracts named in the program,
+//     the contracts being the string literals compared to `account` and `receiver`, narrowed to
+//     the actions compared to `action` if any, like a `data.form` typo in
+//     `account == 'eosio.token' && data.form == 'bob'`;
+//   - comparisons of `data.*` fields with a literal whose type does not match the ABI type of the
+//     field, like `data.quantity == 10`;
+//   - subexpressions that are always true or always false, like `action == 'a' && action == 'b'`.
+//
+// The ABIs are retrieved through `getABI`, `data.*` fields are not validated when the program
+// names no contract or when none of the contract ABIs is known.
+func ValidateFilterProgram(code string, getABI ABIGetter) ([]*ValidationIssue, error) {
+	env, err := newFilterEnv()
+	if err != nil {
+		return nil, fmt.Errorf("new env: %w", err)
+	}
+
+	exprAst, issues := env.Compile(strings.TrimSpace(code))
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("parse filter: %w", issues.Err())
+	}
+
+	parsedExpr, err := cel.AstToParsedExpr(exprAst)
+	if err != nil {
+		return nil, fmt.Errorf("to parsed expr: %w", err)
+	}
+
+	v := &programValidator{
+		positions: parsedExpr.SourceInfo.GetPositions(),
+		contracts: map[string]bool{},
+		actions:   map[string]bool{},
+	}
+
+	v.collectNames(parsedExpr.Expr)
+	if err := v.loadFields(getABI); err != nil {
+		return nil, err
+	}
+
+	v.validate(parsedExpr.Expr)
+
+	sort.SliceStable(v.issues, func(i, j int) bool { return v.issues[i].Position < v.issues[j].Position })
+	return v.issues, nil
+}
+
+type programValidator struct {
+	positions map[int64]int32
+
+	contracts map[string]bool
+	actions   map[string]bool
+
+	// fields maps each field of the candidate actions to its ABI types, `nil` when the ABIs
+	// are not known, in which case `data.*` fields are not validated
+	fields map[string]map[string]bool
+
+	issues []*ValidationIssue
+}
+
+func (v *programValidator) addIssue(expr *exprpb.Expr, format string, args ...interface{}) {
+	v.issues = append(v.issues, &ValidationIssue{Position: v.positions[expr.Id], Message: fmt.Sprintf(format, args...)})
+}
+
+// collectNames walks the whole expression to find the contracts and the actions it names
+func (v *programValidator) collectNames(expr *exprpb.Expr) {
+	call := expr.GetCallExpr()
+	if call == nil {
+		if comprehension := expr.GetComprehensionExpr(); comprehension != nil {
+			v.collectNames(comprehension.IterRange)
+			v.collectNames(comprehension.LoopStep)
+		}
+		return
+	}
+
+	if len(call.Args) == 2 && (call.Function == operators.Equals || call.Function == operators.In) {
+		for _, name := range []string{"account", "receiver", "action"} {
+			literals := comparedLiterals(call, name)
+			for _, literal := range literals {
+				if name == "action" {
+					v.actions[literal] = true
+				} else {
+					v.contracts[literal] = true
+				}
+			}
+		}
+	}
+
+	for _, arg := range call.Args {
+		v.collectNames(arg)
+	}
+}
+
+// comparedLiterals returns the string literals compared to the identifier by an `==` or `in` call
+func comparedLiterals(call *exprpb.Expr_Call, identifier string) (out []string) {
+	left, right := call.Args[0], call.Args[1]
+	if identifierName(right) == identifier && call.Function == operators.Equals {
+		left, right = right, left
+	}
+
+	if identifierName(left) != identifier {
+		return nil
+	}
+
+	if list := right.GetListExpr(); list != nil && call.Function == operators.In {
+		for _, element := range list.Elements {
+			if literal, ok := stringLiteral(element); ok {
+				out = append(out, literal)
+			}
+		}
+		return out
+	}
+
+	if literal, ok := stringLiteral(right); ok && call.Function == operators.Equals {
+		return []string{literal}
+	}
+
+	return nil
+}
+
+func (v *programValidator) loadFields(getABI ABIGetter) error {
+	if getABI == nil || len(v.contracts) == 0 {
+		return nil
+	}
+
+	for contract := range v.contracts {
+		abi, err := getABI(contract)
+		if err != nil {
+			return fmt.Errorf("get abi of %q: %w", contract, err)
+		}
+
+		if abi == nil {
+			continue
+		}
+
+		if v.fields == nil {
+			v.fields = map[string]map[string]bool{}
+		}
+
+		for _, action := range abi.Actions {
+			if len(v.actions) > 0 && !v.actions[string(action.Name)] {
+				continue
+			}
+
+			for _, field := range structFields(abi, action.Type, 0) {
+				if v.fields[field.Name] == nil {
+					v.fields[field.Name] = map[string]bool{}
+				}
+
+				v.fields[field.Name][resolveABIType(abi, field.Type)] = true
+			}
+		}
+	}
+
+	return nil
+}
+
+// structFields returns the fields of the struct, including the ones of its base structs
+func structFields(abi *eos.ABI, structName string, depth int) (out []eos.FieldDef) {
+	// Protects against malicious ABIs with cyclic bases
+	if depth > 32 {
+		return nil
+	}
+
+	structDef := abi.StructForName(resolveABIType(abi, structName))
+	if structDef == nil {
+		return nil
+	}
+
+	if structDef.Base != "" {
+		out = append(out, structFields(abi, structDef.Base, depth+1)...)
+	}
+
+	return append(out, structDef.Fields...)
+}
+
+func resolveABIType(abi *eos.ABI, typeName string) string {
+	for i := 0; i < 32; i++ {
+		found := false
+		for _, abiType := range abi.Types {
+			if abiType.NewTypeName == typeName {
+				typeName = abiType.Type
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			break
+		}
+	}
+
+	return typeName
+}
+
+func (v *programValidator) validate(expr *exprpb.Expr) {
+	if field, ok := dataField(expr); ok {
+		v.validateDataField(expr, field)
+		return
+	}
+
+	if selection := expr.GetSelectExpr(); selection != nil {
+		v.validate(selection.Operand)
+		return
+	}
+
+	if list := expr.GetListExpr(); list != nil {
+		for _, element := range list.Elements {
+			v.validate(element)
+		}
+		return
+	}
+
+	if comprehension := expr.GetComprehensionExpr(); comprehension != nil {
+		v.validate(comprehension.IterRange)
+		v.validate(comprehension.LoopStep)
+		return
+	}
+
+	call := expr.GetCallExpr()
+	if call == nil {
+		return
+	}
+
+	if call.Function == operators.LogicalAnd || call.Function == operators.LogicalOr {
+		terms := flattenLogical(expr, call.Function)
+		v.validateLogical(expr, call.Function, terms)
+
+		for _, term := range terms {
+			v.validate(term)
+		}
+		return
+	}
+
+	switch call.Function {
+	case operators.Equals, operators.NotEquals, operators.Less, operators.LessEquals, operators.Greater, operators.GreaterEquals:
+		v.validateComparison(expr, call)
+	}
+
+	if call.Target != nil {
+		v.validate(call.Target)
+	}
+
+	for _, arg := range call.Args {
+		v.validate(arg)
+	}
+}
+
+// dataField returns the field name of a `data.<field>` selection, including `has(data.<field>)`
+func dataField(expr *exprpb.Expr) (string, bool) {
+	selection := expr.GetSelectExpr()
+	if selection == nil || identifierName(selection.Operand) != "data" {
+		return "", false
+	}
+
+	return selection.Field, true
+}
+
+func (v *programValidator) validateDataField(expr *exprpb.Expr, field string) {
+	if v.fields == nil || v.fields[field] != nil {
+		return
+	}
+
+	v.addIssue(expr, "field `data.%s` does not exist on any action %s", field, v.candidatesDescription())
+}
+
+func (v *programValidator) candidatesDescription() string {
+	description := "of contract(s) " + strings.Join(sortedKeys(v.contracts), ", ")
+	if len(v.actions) > 0 {
+		description += " named " + strings.Join(sortedKeys(v.actions), ", ")
+	}
+
+	return description
+}
+
+func (v *programValidator) validateComparison(expr *exprpb.Expr, call *exprpb.Expr_Call) {
+	left, right := call.Args[0], call.Args[1]
+
+	leftKind, leftIsLiteral := literalKind(left)
+	rightKind, rightIsLiteral := literalKind(right)
+	if leftIsLiteral && rightIsLiteral {
+		v.addIssue(expr, "comparison of two literals is always true or always false")
+		return
+	}
+
+	if sameIdentifier(left, right) {
+		v.addIssue(expr, "comparison of `%s` with itself is always true or always false", identifierName(left))
+		return
+	}
+
+	if field, ok := dataField(left); ok && rightIsLiteral {
+		v.validateFieldKind(expr, field, rightKind)
+	} else if field, ok := dataField(right); ok && leftIsLiteral {
+		v.validateFieldKind(expr, field, leftKind)
+	}
+}
+
+func (v *programValidator) validateFieldKind(expr *exprpb.Expr, field string, kind string) {
+	abiTypes := v.fields[field]
+	if len(abiTypes) == 0 {
+		return
+	}
+
+	for abiType := range abiTypes {
+		if abiTypeAccepts(abiType, kind) {
+			return
+		}
+	}
+
+	v.addIssue(expr, "field `data.%s` of ABI type %s is compared to a %s literal", field, strings.Join(sortedKeys(abiTypes), " or "), kind)
+}
+
+// validateLogical reports the chains of `&&` or `||` that are always true or always false
+func (v *programValidator) validateLogical(expr *exprpb.Expr, function string, terms []*exprpb.Expr) {
+	isAnd := function == operators.LogicalAnd
+
+	for _, term := range terms {
+		if value, ok := boolLiteral(term); ok {
+			if value == isAnd {
+				v.addIssue(term, "literal `%t` has no effect in this expression", value)
+			} else {
+				v.addIssue(expr, "expression is always %t because of literal `%t`", value, value)
+			}
+		}
+	}
+
+	// Equality tests on the same identifier with different literals, `action == 'a' && action == 'b'` is
+	// always false and `action != 'a' || action != 'b'` is always true
+	operator := operators.Equals
+	if !isAnd {
+		operator = operators.NotEquals
+	}
+
+	var identifiers []string
+	literalsByIdentifier := map[string]map[string]bool{}
+	for _, term := range terms {
+		termCall := term.GetCallExpr()
+		if termCall == nil || termCall.Function != operator || len(termCall.Args) != 2 {
+			continue
+		}
+
+		identifier, literal, ok := identifierLiteralComparison(termCall)
+		if !ok {
+			continue
+		}
+
+		if literalsByIdentifier[identifier] == nil {
+			literalsByIdentifier[identifier] = map[string]bool{}
+			identifiers = append(identifiers, identifier)
+		}
+		literalsByIdentifier[identifier][literal] = true
+	}
+
+	for _, identifier := range identifiers {
+		if len(literalsByIdentifier[identifier]) > 1 {
+			v.addIssue(expr, "expression is always %t, `%s` is compared to different values %s", !isAnd, identifier, strings.Join(sortedKeys(literalsByIdentifier[identifier]), ", "))
+		}
+	}
+}
+
+// flattenLogical returns the terms of a chain of the same logical operator, like `a && b && c`
+func flattenLogical(expr *exprpb.Expr, function string) (out []*exprpb.Expr) {
+	call := expr.GetCallExpr()
+	if call == nil || call.Function != function {
+		return []*exprpb.Expr{expr}
+	}
+
+	for _, arg := range call.Args {
+		out = append(out, flattenLogical(arg, function)...)
+	}
+
+	return out
+}
+
+func identifierLiteralComparison(call *exprpb.Expr_Call) (identifier string, literal string, ok bool) {
+	left, right := call.Args[0], call.Args[1]
+	if identifierName(left) == "" {
+		left, right = right, left
+	}
+
+	identifier = identifierName(left)
+	if identifier == "" {
+		return "", "", false
+	}
+
+	literal, ok = stringLiteral(right)
+	return identifier, literal, ok
+}
+
+func sameIdentifier(left, right *exprpb.Expr) bool {
+	name := identifierName(left)
+	return name != "" && name == identifierName(right)
+}
+
+func boolLiteral(expr *exprpb.Expr) (bool, bool) {
+	constant := expr.GetConstExpr()
+	if constant == nil {
+		return false, false
+	}
+
+	value, ok := constant.ConstantKind.(*exprpb.Constant_BoolValue)
+	if !ok {
+		return false, false
+	}
+
+	return value.BoolValue, true
+}
+
+// literalKind returns the kind of the literal, `string`, `number` or `bool`
+func literalKind(expr *exprpb.Expr) (string, bool) {
+	constant := expr.GetConstExpr()
+	if constant == nil {
+		return "", false
+	}
+
+	switch constant.ConstantKind.(type) {
+	case *exprpb.Constant_StringValue:
+		return "string", true
+	case *exprpb.Constant_Int64Value, *exprpb.Constant_Uint64Value, *exprpb.Constant_DoubleValue:
+		return "number", true
+	case *exprpb.Constant_BoolValue:
+		return "bool", true
+	}
+
+	return "", false
+}
+
+// abiTypeAccepts returns whether the JSON value of a field of the ABI type can be of the kind. The
+// 64 bits and larger integer types are accepted as any kind since they can be encoded as strings,
+// as are arrays, optionals, variants and structs which are not compared to literals directly.
+func abiTypeAccepts(abiType string, kind string) bool {
+	switch abiType {
+	case "bool":
+		return kind == "bool"
+	case "int8", "int16", "int32", "uint8", "uint16", "uint32", "varint32", "varuint32", "float32", "float64":
+		return kind == "number"
+	case "name", "string", "asset", "symbol", "symbol_code", "checksum160", "checksum256", "checksum512",
+		"public_key", "signature", "time_point", "time_point_sec", "block_timestamp_type", "bytes":
+		return kind == "string"
+	}
+
+	return true
+}
+
+func sortedKeys(in map[string]bool) (out []string) {
+	for key := range in {
+		out = append(out, key)
+	}
+
+	sort.Strings(out)
+	return out
+}
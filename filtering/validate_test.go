@@ -0,0 +1,114 @@
+package filtering
+
+import (
+	"testing"
+
+	"github.com/eoscanada/eos-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFilterProgram(t *testing.T) {
+	tokenABI := &eos.ABI{
+		Types: []eos.ABIType{{NewTypeName: "account_name", Type: "name"}},
+		Structs: []eos.StructDef{
+			{Name: "transfer", Fields: []eos.FieldDef{
+				{Name: "from", Type: "account_name"},
+				{Name: "to", Type: "account_name"},
+				{Name: "quantity", Type: "asset"},
+				{Name: "memo", Type: "string"},
+			}},
+			{Name: "open", Fields: []eos.FieldDef{
+				{Name: "owner", Type: "name"},
+				{Name: "ram_payer", Type: "name"},
+			}},
+			{Name: "retire", Fields: []eos.FieldDef{
+				{Name: "count", Type: "uint32"},
+			}},
+		},
+		Actions: []eos.ActionDef{
+			{Name: "transfer", Type: "transfer"},
+			{Name: "open", Type: "open"},
+			{Name: "retire", Type: "retire"},
+		},
+	}
+
+	getABI := func(account string) (*eos.ABI, error) {
+		if account == "eosio.token" {
+			return tokenABI, nil
+		}
+
+		return nil, nil
+	}
+
+	tests := []struct {
+		name           string
+		code           string
+		expectedIssues []string
+	}{
+		{"valid", `account == 'eosio.token' && data.from == 'bob'`, nil},
+		{"typo", `account == 'eosio.token' && data.form == 'bob'`, []string{
+			"field `data.form` does not exist on any action of contract(s) eosio.token",
+		}},
+		{"field of another action", `account == 'eosio.token' && action == 'transfer' && data.owner == 'bob'`, []string{
+			"field `data.owner` does not exist on any action of contract(s) eosio.token named transfer",
+		}},
+		{"field of any action", `account == 'eosio.token' && data.owner == 'bob'`, nil},
+		{"unknown abi", `account == 'unknown' && data.form == 'bob'`, nil},
+		{"no contract", `data.form == 'bob'`, nil},
+		{"has", `receiver == 'eosio.token' && has(data.form)`, []string{
+			"field `data.form` does not exist on any action of contract(s) eosio.token",
+		}},
+		{"type mismatch", `account == 'eosio.token' && data.count == '10'`, []string{
+			"field `data.count` of ABI type uint32 is compared to a string literal",
+		}},
+		{"type mismatch number", `account == 'eosio.token' && data.to == 10`, []string{
+			"field `data.to` of ABI type name is compared to a number literal",
+		}},
+		{"conflicting equalities", `action == 'transfer' && action == 'open'`, []string{
+			"expression is always false, `action` is compared to different values open, transfer",
+		}},
+		{"conflicting inequalities", `receiver != 'a' || receiver != 'b'`, []string{
+			"expression is always true, `receiver` is compared to different values a, b",
+		}},
+		{"always true literal", `receiver == 'a' || true`, []string{
+			"expression is always true because of literal `true`",
+		}},
+		{"no effect literal", `receiver == 'a' && true`, []string{
+			"literal `true` has no effect in this expression",
+		}},
+		{"literals comparison", `receiver == 'a' || 'a' == 'a'`, []string{
+			"comparison of two literals is always true or always false",
+		}},
+		{"self comparison", `receiver == receiver`, []string{
+			"comparison of `receiver` with itself is always true or always false",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			issues, err := ValidateFilterProgram(test.code, getABI)
+			require.NoError(t, err)
+
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, issue.Message)
+			}
+
+			assert.Equal(t, test.expectedIssues, messages)
+		})
+	}
+}
+
+func TestBlockFilterValidate(t *testing.T) {
+	filter, err := NewBlockFilter("*", `action == 'transfer' && action == 'open'`)
+	require.NoError(t, err)
+
+	issues, err := filter.Validate(nil)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+
+	assert.Equal(t, "exclude", issues[0].Program)
+	assert.Equal(t, uint64(0), issues[0].StartBlock)
+	assert.True(t, issues[0].Position > 0)
+}
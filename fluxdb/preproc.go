@@ -48,25 +48,29 @@ func PreprocessBlock(rawBlk *bstream.Block) (interface{}, error) {
 		BlockID:  blockID,
 	}
 
-	for _, trx := range blk.TransactionTraces() {
-		for _, dbOp := range trx.DbOps {
-			if dbOp.Operation == pbcodec.DBOp_OPERATION_UPDATE && bytes.Equal(dbOp.OldData, dbOp.NewData) && dbOp.OldPayer == dbOp.NewPayer {
-				continue
-			}
+	processDbOp := func(dbOp *pbcodec.DBOp) {
+		if dbOp.Operation == pbcodec.DBOp_OPERATION_UPDATE && bytes.Equal(dbOp.OldData, dbOp.NewData) && dbOp.OldPayer == dbOp.NewPayer {
+			return
+		}
 
-			path := tableDataRowPath(dbOp)
+		path := tableDataRowPath(dbOp)
 
-			lastOp := lastDbOpForRowPath[path]
-			if lastOp == nil && dbOp.Operation == pbcodec.DBOp_OPERATION_INSERT {
-				firstDbOpWasInsert[path] = true
-			}
+		lastOp := lastDbOpForRowPath[path]
+		if lastOp == nil && dbOp.Operation == pbcodec.DBOp_OPERATION_INSERT {
+			firstDbOpWasInsert[path] = true
+		}
 
-			if dbOp.Operation == pbcodec.DBOp_OPERATION_REMOVE && firstDbOpWasInsert[path] {
-				delete(firstDbOpWasInsert, path)
-				delete(lastDbOpForRowPath, path)
-			} else {
-				lastDbOpForRowPath[path] = dbOp
-			}
+		if dbOp.Operation == pbcodec.DBOp_OPERATION_REMOVE && firstDbOpWasInsert[path] {
+			delete(firstDbOpWasInsert, path)
+			delete(lastDbOpForRowPath, path)
+		} else {
+			lastDbOpForRowPath[path] = dbOp
+		}
+	}
+
+	for _, trx := range blk.TransactionTraces() {
+		for _, dbOp := range trx.DbOps {
+			processDbOp(dbOp)
 		}
 
 		for _, permOp := range trx.PermOps {
@@ -108,6 +112,12 @@ func PreprocessBlock(rawBlk *bstream.Block) (interface{}, error) {
 		}
 	}
 
+	// Blocks read from the state history plugin carry the final state of the rows changed in the
+	// block instead of the operations of each transaction
+	for _, dbOp := range blk.UnattributedDbOps {
+		processDbOp(dbOp)
+	}
+
 	req.KeyAccounts = keyAccountOpsToWritableRows(lastKeyAccountOpForRowPath)
 	req.TableScopes = tableOpsToWritableRows(lastTableOpForTablePath)
 
@@ -181,6 +181,28 @@ func TestPreprocessBlock_DbOps(t *testing.T) {
 	}
 }
 
+func TestPreprocessBlock_UnattributedDbOps(t *testing.T) {
+	blk := newBlock("0000003a", []string{"1"})
+	blk.TransactionTraces()[0].DbOps = []*pbcodec.DBOp{
+		testDBOp("UPD", "eosio/scope/table1/key1", "payer1/payer1", "d0/d1"),
+	}
+	blk.UnattributedDbOps = []*pbcodec.DBOp{
+		testDBOp("UPD", "eosio/scope/table1/key1", "/payer1", "/d2"),
+		testDBOp("REM", "eosio/scope/table1/key2", "payer2/", "d3/"),
+	}
+
+	bstreamBlock, err := codec.BlockFromProto(blk)
+	require.NoError(t, err)
+
+	req, err := PreprocessBlock(bstreamBlock)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []*TableDataRow{
+		{N("eosio"), N("scope"), N("table1"), N("key1"), N("payer1"), false, []byte("d2")},
+		{N("eosio"), N("scope"), N("table1"), N("key2"), N(""), true, nil},
+	}, req.(*WriteRequest).TableDatas)
+}
+
 func testDBOp(op string, path, payers, datas string) *pbcodec.DBOp {
 	chunks := strings.SplitN(path, "/", 4)
 	payerChunks := strings.SplitN(payers, "/", 2)
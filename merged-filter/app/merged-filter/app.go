@@ -1,6 +1,7 @@
 package merged_filter
 
 import (
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -30,8 +31,12 @@ type Config struct {
 	TruncationEnabled bool
 	TruncationWindow  uint64
 
-	IncludeFilterExpr string
-	ExcludeFilterExpr string
+	IncludeFilterExpr     string
+	ExcludeFilterExpr     string
+	FilterScheduleFile    string
+	FilterStrict          bool
+	AlwaysIncludedActions []string // `<account>:<action>` actions kept regardless of the filter programs, none when empty
+	ChainID               string   // Chain ID in hex, used to resolve `trx.signers` in filter programs
 }
 
 func New(config *Config) *App {
@@ -56,7 +61,7 @@ func (a *App) Run() error {
 
 	zlog.Info("writing to store", zap.String("store_url", a.config.DestBlocksStoreURL))
 
-	blockFilter, err := filtering.NewBlockFilter(a.config.IncludeFilterExpr, a.config.ExcludeFilterExpr)
+	blockFilter, err := a.newBlockFilter()
 	if err != nil {
 		return err
 	}
@@ -87,3 +92,39 @@ func (a *App) Run() error {
 	return nil
 
 }
+
+func (a *App) newBlockFilter() (*filtering.BlockFilter, error) {
+	var blockFilter *filtering.BlockFilter
+	var err error
+
+	alwaysIncluded := filtering.WithAlwaysIncludedActions(a.config.AlwaysIncludedActions)
+	if a.config.FilterScheduleFile == "" {
+		blockFilter, err = filtering.NewBlockFilter(a.config.IncludeFilterExpr, a.config.ExcludeFilterExpr, alwaysIncluded)
+	} else {
+		zlog.Info("using filter schedule", zap.String("schedule_file", a.config.FilterScheduleFile))
+
+		var schedule filtering.FilterSchedule
+		schedule, err = filtering.LoadFilterSchedule(a.config.FilterScheduleFile)
+		if err != nil {
+			return nil, err
+		}
+
+		blockFilter, err = filtering.NewScheduledBlockFilter(schedule, alwaysIncluded)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if a.config.ChainID != "" {
+		chainID, err := hex.DecodeString(a.config.ChainID)
+		if err != nil {
+			return nil, fmt.Errorf("decoding chain_id from command line argument: %w", err)
+		}
+
+		blockFilter.SetChainID(chainID)
+	}
+
+	blockFilter.SetStrict(a.config.FilterStrict)
+	return blockFilter, nil
+}
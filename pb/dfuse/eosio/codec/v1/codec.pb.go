@@ -637,10 +637,30 @@ type Block struct {
 	FilteringIncludeFilterExpr string `protobuf:"bytes,41,opt,name=filtering_include_filter_expr,json=filteringIncludeFilterExpr,proto3" json:"filtering_include_filter_expr,omitempty"`
 	// The CEL filter expression used to exclude transaction in `filtered_transaction_traces` array, works
 	// in combination with `filtering_include_filter_expr` value.
-	FilteringExcludeFilterExpr string   `protobuf:"bytes,42,opt,name=filtering_exclude_filter_expr,json=filteringExcludeFilterExpr,proto3" json:"filtering_exclude_filter_expr,omitempty"`
-	XXX_NoUnkeyedLiteral       struct{} `json:"-"`
-	XXX_unrecognized           []byte   `json:"-"`
-	XXX_sizecache              int32    `json:"-"`
+	FilteringExcludeFilterExpr string `protobuf:"bytes,42,opt,name=filtering_exclude_filter_expr,json=filteringExcludeFilterExpr,proto3" json:"filtering_exclude_filter_expr,omitempty"`
+	// The major version of the deep mind instrumentation that produced this block, as reported
+	// by the `DEEP_MIND_VERSION` line of the instrumented nodeos.
+	DeepMindMajorVersion uint32 `protobuf:"varint,50,opt,name=deep_mind_major_version,json=deepMindMajorVersion,proto3" json:"deep_mind_major_version,omitempty"`
+	// The minor version of the deep mind instrumentation that produced this block, always 0 when
+	// the instrumentation did not report one.
+	DeepMindMinorVersion uint32 `protobuf:"varint,51,opt,name=deep_mind_minor_version,json=deepMindMinorVersion,proto3" json:"deep_mind_minor_version,omitempty"`
+	// The build information (version and commit) of the instrumented nodeos that produced this
+	// block, empty when unknown.
+	NodeosBuildInfo string `protobuf:"bytes,52,opt,name=nodeos_build_info,json=nodeosBuildInfo,proto3" json:"nodeos_build_info,omitempty"`
+	// The source the block was ingested from, empty when it was produced from the deep mind
+	// instrumentation of nodeos and `ship` when it was produced from the state history plugin.
+	IngestionSource string `protobuf:"bytes,53,opt,name=ingestion_source,json=ingestionSource,proto3" json:"ingestion_source,omitempty"`
+	// The fields that the ingestion source could not provide and that are left empty in this
+	// block (i.e. `transaction_trace.creation_tree`, `transaction_trace.ram_ops`), always empty for
+	// blocks produced from the deep mind instrumentation.
+	UnavailableFields []string `protobuf:"bytes,54,rep,name=unavailable_fields,json=unavailableFields,proto3" json:"unavailable_fields,omitempty"`
+	// The database operations of the block that could not be attributed to a transaction by the
+	// ingestion source. Only blocks produced from the state history plugin have them, one per row
+	// modified in the block, the old payer and data of updated rows being unknown.
+	UnattributedDbOps    []*DBOp  `protobuf:"bytes,55,rep,name=unattributed_db_ops,json=unattributedDbOps,proto3" json:"unattributed_db_ops,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Block) Reset()         { *m = Block{} }
@@ -927,6 +947,48 @@ func (m *Block) GetFilteringExcludeFilterExpr() string {
 	return ""
 }
 
+func (m *Block) GetDeepMindMajorVersion() uint32 {
+	if m != nil {
+		return m.DeepMindMajorVersion
+	}
+	return 0
+}
+
+func (m *Block) GetDeepMindMinorVersion() uint32 {
+	if m != nil {
+		return m.DeepMindMinorVersion
+	}
+	return 0
+}
+
+func (m *Block) GetNodeosBuildInfo() string {
+	if m != nil {
+		return m.NodeosBuildInfo
+	}
+	return ""
+}
+
+func (m *Block) GetIngestionSource() string {
+	if m != nil {
+		return m.IngestionSource
+	}
+	return ""
+}
+
+func (m *Block) GetUnavailableFields() []string {
+	if m != nil {
+		return m.UnavailableFields
+	}
+	return nil
+}
+
+func (m *Block) GetUnattributedDbOps() []*DBOp {
+	if m != nil {
+		return m.UnattributedDbOps
+	}
+	return nil
+}
+
 // BlockWithRefs is a lightweight block, with traces and transactions
 // purged from the `block` within, and only.  It is used in transports
 // to pass block data around.
@@ -3456,19 +3518,25 @@ func (m *TrxOp) GetTransaction() *SignedTransaction {
 }
 
 type DBOp struct {
-	Operation            DBOp_Operation `protobuf:"varint,1,opt,name=operation,proto3,enum=dfuse.eosio.codec.v1.DBOp_Operation" json:"operation,omitempty"`
-	ActionIndex          uint32         `protobuf:"varint,2,opt,name=action_index,json=actionIndex,proto3" json:"action_index,omitempty"`
-	Code                 string         `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
-	Scope                string         `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
-	TableName            string         `protobuf:"bytes,5,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
-	PrimaryKey           string         `protobuf:"bytes,6,opt,name=primary_key,json=primaryKey,proto3" json:"primary_key,omitempty"`
-	OldPayer             string         `protobuf:"bytes,7,opt,name=old_payer,json=oldPayer,proto3" json:"old_payer,omitempty"`
-	NewPayer             string         `protobuf:"bytes,8,opt,name=new_payer,json=newPayer,proto3" json:"new_payer,omitempty"`
-	OldData              []byte         `protobuf:"bytes,9,opt,name=old_data,json=oldData,proto3" json:"old_data,omitempty"`
-	NewData              []byte         `protobuf:"bytes,10,opt,name=new_data,json=newData,proto3" json:"new_data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Operation   DBOp_Operation `protobuf:"varint,1,opt,name=operation,proto3,enum=dfuse.eosio.codec.v1.DBOp_Operation" json:"operation,omitempty"`
+	ActionIndex uint32         `protobuf:"varint,2,opt,name=action_index,json=actionIndex,proto3" json:"action_index,omitempty"`
+	Code        string         `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Scope       string         `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
+	TableName   string         `protobuf:"bytes,5,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
+	PrimaryKey  string         `protobuf:"bytes,6,opt,name=primary_key,json=primaryKey,proto3" json:"primary_key,omitempty"`
+	OldPayer    string         `protobuf:"bytes,7,opt,name=old_payer,json=oldPayer,proto3" json:"old_payer,omitempty"`
+	NewPayer    string         `protobuf:"bytes,8,opt,name=new_payer,json=newPayer,proto3" json:"new_payer,omitempty"`
+	OldData     []byte         `protobuf:"bytes,9,opt,name=old_data,json=oldData,proto3" json:"old_data,omitempty"`
+	NewData     []byte         `protobuf:"bytes,10,opt,name=new_data,json=newData,proto3" json:"new_data,omitempty"`
+	// The JSON representation of `old_data`, decoded against the ABI of `code` in effect at the
+	// time of the operation, empty when the row could not be decoded.
+	OldDataJson string `protobuf:"bytes,11,opt,name=old_data_json,json=oldDataJson,proto3" json:"old_data_json,omitempty"`
+	// The JSON representation of `new_data`, decoded against the ABI of `code` in effect at the
+	// time of the operation, empty when the row could not be decoded.
+	NewDataJson          string   `protobuf:"bytes,12,opt,name=new_data_json,json=newDataJson,proto3" json:"new_data_json,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *DBOp) Reset()         { *m = DBOp{} }
@@ -3566,6 +3634,20 @@ func (m *DBOp) GetNewData() []byte {
 	return nil
 }
 
+func (m *DBOp) GetOldDataJson() string {
+	if m != nil {
+		return m.OldDataJson
+	}
+	return ""
+}
+
+func (m *DBOp) GetNewDataJson() string {
+	if m != nil {
+		return m.NewDataJson
+	}
+	return ""
+}
+
 type RAMOp struct {
 	Operation   RAMOp_Operation `protobuf:"varint,1,opt,name=operation,proto3,enum=dfuse.eosio.codec.v1.RAMOp_Operation" json:"operation,omitempty"`
 	ActionIndex uint32          `protobuf:"varint,2,opt,name=action_index,json=actionIndex,proto3" json:"action_index,omitempty"`
@@ -5716,386 +5798,395 @@ func init() {
 func init() { proto.RegisterFile("dfuse/eosio/codec/v1/codec.proto", fileDescriptor_3286b8d338e80dff) }
 
 var fileDescriptor_3286b8d338e80dff = []byte{
-	// 6096 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x7c, 0x4b, 0x6f, 0x1b, 0x59,
-	0x76, 0xb0, 0x49, 0xf1, 0x79, 0x48, 0x49, 0xd4, 0xb5, 0x1e, 0x94, 0xfc, 0x92, 0xcb, 0xdd, 0x6e,
-	0xb7, 0xbb, 0x2d, 0xb7, 0xdd, 0xdd, 0x33, 0xd3, 0xf3, 0x75, 0x7f, 0x6e, 0x8a, 0xa4, 0x2d, 0xb6,
-	0x25, 0x52, 0xb8, 0xa4, 0xed, 0xf6, 0x64, 0x26, 0x85, 0x52, 0xd5, 0x95, 0x54, 0x6d, 0xb2, 0xaa,
-	0xa6, 0xaa, 0x28, 0x4b, 0x83, 0x60, 0x80, 0x60, 0x36, 0x09, 0x30, 0x83, 0x00, 0xd9, 0x04, 0x48,
-	0x16, 0x09, 0x82, 0xd9, 0x66, 0x91, 0x59, 0x25, 0x13, 0x64, 0x19, 0x20, 0xdb, 0x20, 0xc8, 0x22,
-	0x8b, 0x24, 0xc0, 0x2c, 0x92, 0xcc, 0x1f, 0x48, 0xb6, 0xc1, 0x7d, 0xd5, 0x83, 0x2c, 0x52, 0xa2,
-	0xc7, 0x08, 0xb2, 0x12, 0xef, 0xb9, 0xe7, 0x9c, 0xfb, 0x3a, 0xf7, 0x3c, 0x6f, 0x09, 0x36, 0x8d,
-	0xc3, 0xa1, 0x47, 0xee, 0x13, 0xdb, 0x33, 0xed, 0xfb, 0xba, 0x6d, 0x10, 0xfd, 0xfe, 0xc9, 0x03,
-	0xfe, 0x63, 0xcb, 0x71, 0x6d, 0xdf, 0x46, 0xcb, 0x0c, 0x63, 0x8b, 0x61, 0x6c, 0xf1, 0x8e, 0x93,
-	0x07, 0x1b, 0x37, 0x8e, 0x6c, 0xfb, 0xa8, 0x4f, 0xee, 0x33, 0x9c, 0x83, 0xe1, 0xe1, 0x7d, 0xdf,
-	0x1c, 0x10, 0xcf, 0xd7, 0x06, 0x0e, 0x27, 0x53, 0xfe, 0x69, 0x05, 0xb2, 0xdb, 0x7d, 0x5b, 0x7f,
-	0x85, 0x16, 0x20, 0x6d, 0x1a, 0xd5, 0xd4, 0x66, 0xea, 0x4e, 0x11, 0xa7, 0x4d, 0x03, 0xad, 0x42,
-	0xce, 0x1a, 0x0e, 0x0e, 0x88, 0x5b, 0x4d, 0x6f, 0xa6, 0xee, 0xcc, 0x63, 0xd1, 0x42, 0x55, 0xc8,
-	0x9f, 0x10, 0xd7, 0x33, 0x6d, 0xab, 0x3a, 0xc7, 0x3a, 0x64, 0x13, 0x7d, 0x06, 0xb9, 0x63, 0xa2,
-	0x19, 0xc4, 0xad, 0x66, 0x36, 0x53, 0x77, 0x4a, 0x0f, 0x6f, 0x6e, 0x25, 0xcd, 0x69, 0x8b, 0x0d,
-	0xb7, 0xc3, 0x10, 0xb1, 0x20, 0x40, 0xf7, 0x00, 0x39, 0xae, 0x6d, 0x0c, 0x75, 0xe2, 0xaa, 0x9e,
-	0x79, 0x64, 0x69, 0xfe, 0xd0, 0x25, 0xd5, 0x2c, 0x9b, 0xcc, 0x92, 0xec, 0xe9, 0xca, 0x0e, 0xf4,
-	0x15, 0x54, 0x0e, 0x28, 0x17, 0x95, 0x9c, 0xfa, 0xc4, 0xa2, 0x83, 0x7b, 0xd5, 0xfc, 0xe6, 0xdc,
-	0x9d, 0xd2, 0xc3, 0x1b, 0xc9, 0x63, 0x36, 0x25, 0x1e, 0x5e, 0x64, 0x84, 0x41, 0xdb, 0x43, 0x7b,
-	0x70, 0xcb, 0x70, 0x6c, 0x4f, 0x75, 0x5c, 0xdb, 0xb1, 0x3d, 0x62, 0xa8, 0xa6, 0xeb, 0x12, 0xb6,
-	0xa4, 0x83, 0x3e, 0x51, 0x19, 0xb6, 0x35, 0x1c, 0x54, 0x0b, 0x6c, 0xad, 0x9b, 0x14, 0x75, 0x5f,
-	0x60, 0xb6, 0x22, 0x88, 0xdb, 0x02, 0x0f, 0x7d, 0x0e, 0x1b, 0x8c, 0x5d, 0x32, 0x97, 0x22, 0xe3,
-	0x52, 0xa5, 0x18, 0x89, 0xd4, 0xfb, 0x62, 0x61, 0xae, 0x6d, 0xfb, 0xea, 0x80, 0xb8, 0xaf, 0xfa,
-	0xa4, 0x5a, 0x62, 0x9b, 0xf9, 0xee, 0x94, 0xcd, 0xc4, 0xb6, 0xed, 0xef, 0x31, 0x64, 0xb1, 0x3c,
-	0x37, 0x00, 0xa0, 0x23, 0x58, 0x0f, 0x76, 0xd6, 0xb7, 0xd5, 0xbe, 0xe6, 0xf9, 0xaa, 0x00, 0x18,
-	0xd5, 0x32, 0xdb, 0xb3, 0x0f, 0x93, 0x59, 0xef, 0x0b, 0xb2, 0x9e, 0xbd, 0xab, 0x79, 0xbe, 0x68,
-	0x19, 0x78, 0xd5, 0x49, 0x84, 0x23, 0x0b, 0xae, 0x8e, 0x0d, 0x64, 0x0e, 0x9c, 0xbe, 0xc9, 0xb6,
-	0xf4, 0xa0, 0x3a, 0xcf, 0xc6, 0xda, 0xba, 0xc8, 0x58, 0x2d, 0x4e, 0xd6, 0xc2, 0xdb, 0xb8, 0xea,
-	0x24, 0xf6, 0xb8, 0x07, 0xe8, 0x16, 0xcc, 0xeb, 0xb6, 0x75, 0x68, 0xba, 0x03, 0x55, 0xb7, 0x87,
-	0x96, 0x5f, 0x5d, 0xdc, 0x9c, 0xbb, 0x33, 0x8f, 0xcb, 0x02, 0x58, 0xa7, 0x30, 0xf4, 0x35, 0x54,
-	0x1c, 0x62, 0x19, 0xa6, 0x75, 0xa4, 0x7a, 0xfa, 0x31, 0x31, 0x86, 0x7d, 0x52, 0xad, 0xb0, 0xfd,
-	0xbc, 0x37, 0x61, 0x22, 0x1c, 0x5b, 0xce, 0xa7, 0x2b, 0x88, 0xf0, 0xa2, 0x60, 0x23, 0x01, 0xc8,
-	0x86, 0x2b, 0x9a, 0xee, 0x9b, 0x27, 0x9a, 0x4f, 0x0c, 0x95, 0xdd, 0x25, 0xdd, 0xee, 0xab, 0x87,
-	0x84, 0x09, 0xa8, 0x57, 0x5d, 0x62, 0x83, 0xdc, 0x4f, 0x1e, 0xa4, 0x26, 0x09, 0xf7, 0x05, 0xdd,
-	0x63, 0x41, 0x86, 0xd7, 0xb5, 0x49, 0x5d, 0xe8, 0x2a, 0x14, 0x4f, 0xb4, 0xbe, 0x69, 0xd0, 0xce,
-	0x2a, 0xda, 0x4c, 0xdd, 0x29, 0xe0, 0x10, 0x80, 0xbe, 0x00, 0x70, 0xfb, 0xe6, 0xc0, 0xf4, 0x55,
-	0xdb, 0xf1, 0xaa, 0x97, 0xd9, 0x5e, 0x5f, 0x4f, 0x1e, 0x1d, 0x33, 0xbc, 0x8e, 0x83, 0x8b, 0xae,
-	0xf8, 0xe5, 0x21, 0x0d, 0xd6, 0x86, 0xd6, 0xa1, 0xd9, 0xf7, 0x89, 0x4b, 0x0c, 0xd5, 0x77, 0x35,
-	0xcb, 0xa3, 0x33, 0xa1, 0xf7, 0x2a, 0xc7, 0x78, 0xdd, 0x49, 0xe6, 0xd5, 0x0b, 0x31, 0x31, 0xd1,
-	0x89, 0xe9, 0xf8, 0x78, 0x35, 0x64, 0x14, 0xe9, 0xf5, 0xd0, 0x0f, 0x60, 0x25, 0x79, 0x80, 0xfb,
-	0x33, 0x0e, 0xb0, 0x9c, 0xc8, 0xfe, 0x4b, 0xb8, 0x9a, 0xbc, 0x02, 0x21, 0x1d, 0xab, 0xec, 0xe6,
-	0x6d, 0x24, 0x4e, 0x8e, 0xcb, 0xca, 0xe7, 0xb0, 0x31, 0x85, 0xfe, 0x23, 0x7e, 0x73, 0x27, 0x52,
-	0x7f, 0x03, 0xb7, 0x22, 0xe3, 0x33, 0xc1, 0xd7, 0x4d, 0x3f, 0xc6, 0x88, 0x9e, 0xcc, 0x32, 0x5b,
-	0xec, 0x95, 0x49, 0x8b, 0x3d, 0xed, 0x38, 0x78, 0x33, 0xe4, 0xd3, 0x12, 0x6c, 0x22, 0xa3, 0xd1,
-	0xd3, 0x3a, 0x84, 0x9b, 0xe7, 0x8f, 0xf4, 0xe0, 0xfc, 0x91, 0xae, 0x9f, 0x33, 0xce, 0x37, 0x70,
-	0x6d, 0xc2, 0x9e, 0xfa, 0xae, 0xa6, 0x13, 0xaf, 0xba, 0xc2, 0xc6, 0xb8, 0x7d, 0xee, 0xd1, 0xf5,
-	0x28, 0x3a, 0xbe, 0x92, 0xb8, 0xf9, 0xac, 0x8f, 0xae, 0xe9, 0xca, 0xb4, 0x91, 0xb6, 0x66, 0x1a,
-	0x69, 0x7d, 0xf2, 0x38, 0x4f, 0x41, 0x99, 0xb6, 0x26, 0x71, 0xda, 0x6b, 0xec, 0xb4, 0x6f, 0x4c,
-	0x9e, 0x30, 0x3f, 0xf4, 0x27, 0xb0, 0x79, 0x2e, 0xab, 0x0f, 0x18, 0xab, 0x6b, 0xd3, 0x19, 0x61,
-	0xb8, 0x1d, 0x99, 0x15, 0x39, 0x25, 0xfa, 0x90, 0xea, 0x15, 0xd3, 0x72, 0x86, 0xbe, 0x1a, 0x93,
-	0xc3, 0x2a, 0x63, 0x17, 0x59, 0x43, 0x53, 0x20, 0xb7, 0x28, 0x6e, 0x2d, 0x22, 0x91, 0x6d, 0x78,
-	0xe7, 0x42, 0x1c, 0x3f, 0xe4, 0x96, 0xed, 0x5c, 0x7e, 0x13, 0xe6, 0xe8, 0xdb, 0xbe, 0xd6, 0x8f,
-	0x73, 0x5c, 0x9f, 0x34, 0xc7, 0x1e, 0xc5, 0x3d, 0x77, 0x8e, 0x09, 0x1c, 0xef, 0x25, 0xcf, 0x71,
-	0x8c, 0xdf, 0x5d, 0x58, 0xe2, 0x8e, 0x01, 0x75, 0x22, 0xa8, 0xd6, 0x7f, 0x45, 0xce, 0xaa, 0x0b,
-	0xcc, 0x8d, 0xe0, 0x96, 0xb1, 0xcb, 0xe1, 0x4f, 0xc9, 0x19, 0xea, 0x01, 0x62, 0xda, 0x96, 0x04,
-	0xa6, 0x41, 0x3d, 0x79, 0x50, 0x05, 0xa6, 0xb8, 0x6f, 0x4f, 0x37, 0x53, 0x81, 0x59, 0xa8, 0x70,
-	0x0e, 0xb2, 0xfd, 0xfc, 0x01, 0xf2, 0x60, 0x93, 0x69, 0x65, 0x35, 0x3e, 0x0f, 0x6d, 0xe8, 0x1f,
-	0xdb, 0xae, 0xe9, 0x9f, 0xa9, 0x27, 0x0f, 0xab, 0xd7, 0xd9, 0x18, 0x1f, 0x4c, 0xb1, 0xe8, 0x62,
-	0x9a, 0x35, 0x49, 0x85, 0xaf, 0x32, 0xa6, 0x89, 0x7d, 0xcf, 0x1f, 0xa2, 0x1f, 0x24, 0x2c, 0xe5,
-	0x61, 0xf5, 0xc6, 0x34, 0x1b, 0x24, 0x97, 0x12, 0xb0, 0x99, 0xb8, 0xa6, 0x87, 0xe8, 0x03, 0x58,
-	0xe2, 0x3b, 0xcf, 0x56, 0xe2, 0x30, 0x13, 0x5c, 0xbd, 0xc3, 0x4c, 0x50, 0x25, 0xe8, 0xa8, 0x71,
-	0x38, 0xaa, 0xc1, 0xb5, 0x10, 0xd9, 0xb4, 0xf4, 0xfe, 0xd0, 0x20, 0x2a, 0x87, 0xa8, 0xe4, 0xd4,
-	0x71, 0xab, 0xef, 0xb3, 0xe3, 0xd8, 0x08, 0x90, 0x5a, 0x1c, 0xe7, 0x31, 0x6b, 0x37, 0x4f, 0x1d,
-	0x37, 0xce, 0x82, 0x9c, 0x8e, 0xb3, 0xb8, 0x3b, 0xc2, 0xa2, 0x79, 0x3a, 0xc2, 0x42, 0xf9, 0xbd,
-	0x39, 0x98, 0x67, 0xbb, 0xf5, 0xc2, 0xf4, 0x8f, 0x31, 0x39, 0xf4, 0xc6, 0xfc, 0xdb, 0x07, 0x90,
-	0x65, 0x47, 0xc4, 0xdc, 0xdb, 0x89, 0x8a, 0x92, 0xfb, 0x57, 0x1c, 0x13, 0x69, 0xb0, 0x9e, 0xa8,
-	0x6e, 0x5d, 0x72, 0xe8, 0x31, 0x67, 0x78, 0xa2, 0x9b, 0x16, 0x33, 0x63, 0x87, 0x1e, 0x5e, 0x33,
-	0xc7, 0x35, 0x2e, 0x9b, 0xe5, 0x3e, 0x54, 0xc6, 0x38, 0x67, 0x66, 0xe1, 0xbc, 0xe8, 0x8f, 0x70,
-	0xfc, 0x2d, 0x58, 0x1d, 0x57, 0x4d, 0x8c, 0x6f, 0x76, 0x16, 0xbe, 0xcb, 0xfe, 0xa8, 0x92, 0xa5,
-	0xcc, 0x15, 0x28, 0x47, 0x1d, 0xdd, 0x6a, 0x8e, 0x09, 0x45, 0x0c, 0xa6, 0xbc, 0x0f, 0x8b, 0xa3,
-	0xab, 0x5c, 0x85, 0xdc, 0xb1, 0xe6, 0x1d, 0x13, 0xaf, 0x9a, 0xda, 0x9c, 0xbb, 0x53, 0xc6, 0xa2,
-	0xa5, 0xec, 0xc0, 0xfa, 0x44, 0xdf, 0x88, 0x4a, 0xe1, 0xb8, 0x9f, 0xc5, 0xe9, 0x2b, 0xce, 0x08,
-	0xb2, 0xf2, 0x93, 0x34, 0xac, 0x4d, 0xf0, 0xe5, 0xd0, 0x1d, 0xa8, 0x04, 0xd7, 0xa4, 0x6f, 0x1e,
-	0xa8, 0xd4, 0x31, 0x4f, 0x31, 0x05, 0xb3, 0x20, 0xe1, 0xbb, 0xe6, 0x41, 0x7b, 0x38, 0xa0, 0x3e,
-	0x66, 0x80, 0x49, 0xa7, 0xc8, 0x64, 0xa5, 0x8c, 0xcb, 0x12, 0xb8, 0xa3, 0x79, 0xc7, 0xe8, 0x09,
-	0x94, 0xa2, 0x0a, 0x64, 0x6e, 0x26, 0x05, 0x02, 0x5e, 0xa8, 0x3a, 0xf6, 0xa3, 0x8c, 0x1e, 0x8a,
-	0x63, 0x9f, 0xf9, 0xfa, 0x86, 0x1c, 0x1f, 0x2a, 0x03, 0xa8, 0x8c, 0xad, 0x3e, 0x12, 0xbf, 0xa5,
-	0xe2, 0xf1, 0xdb, 0x23, 0x28, 0x4a, 0x6f, 0xdb, 0xab, 0xa6, 0x99, 0xc1, 0xbd, 0x39, 0x7d, 0xf4,
-	0xa7, 0xe4, 0x0c, 0x87, 0x34, 0xca, 0xf7, 0xa1, 0x14, 0xe9, 0x41, 0x37, 0xa1, 0xac, 0xe9, 0x4c,
-	0x7f, 0xab, 0x96, 0x36, 0x20, 0xe2, 0xee, 0x95, 0x04, 0xac, 0xad, 0x0d, 0x48, 0xb2, 0xbe, 0x4e,
-	0x27, 0xea, 0x6b, 0xe5, 0x77, 0x60, 0x7d, 0xe2, 0xaa, 0xa7, 0xac, 0xaa, 0x39, 0xbe, 0xaa, 0xf7,
-	0x2e, 0xb8, 0xa7, 0xd1, 0xb5, 0xfd, 0x49, 0x0a, 0x96, 0xc6, 0x10, 0x2e, 0xb2, 0x44, 0x1d, 0xd6,
-	0x26, 0x98, 0x02, 0xa1, 0x79, 0x66, 0xb2, 0x03, 0x2b, 0x07, 0x49, 0x60, 0x45, 0x87, 0x95, 0x44,
-	0x7c, 0xf4, 0x08, 0xd2, 0x27, 0x1f, 0xb1, 0x69, 0x4d, 0x0c, 0x79, 0x92, 0x8d, 0xca, 0x47, 0x3b,
-	0x97, 0x70, 0xfa, 0xe4, 0xa3, 0xed, 0x22, 0xe4, 0x4f, 0x34, 0xd7, 0xd4, 0x2c, 0x5f, 0xe9, 0xc3,
-	0xda, 0x04, 0x5c, 0x1a, 0x9c, 0xf8, 0xc7, 0x2e, 0xf1, 0x8e, 0xed, 0xbe, 0x21, 0x0e, 0x20, 0x04,
-	0xa0, 0x8f, 0x21, 0xf3, 0x8a, 0x9c, 0xc9, 0xdd, 0x9f, 0x10, 0xa2, 0x3f, 0x25, 0x67, 0x2f, 0x88,
-	0x79, 0x74, 0xec, 0x63, 0x86, 0xac, 0x74, 0x61, 0x71, 0x24, 0xb8, 0x45, 0xd7, 0x00, 0x2c, 0xdb,
-	0x90, 0x8e, 0x95, 0x18, 0x86, 0x42, 0xb8, 0xf1, 0x67, 0x87, 0xc1, 0xac, 0x20, 0x85, 0xf1, 0xe1,
-	0xca, 0xf4, 0x30, 0x28, 0xac, 0x4d, 0x41, 0x8a, 0x0e, 0xab, 0xc9, 0x61, 0x2d, 0x42, 0x90, 0x89,
-	0x9c, 0x20, 0xfb, 0x8d, 0x3e, 0x85, 0x35, 0x16, 0xc6, 0xf2, 0xf3, 0xb3, 0x86, 0x83, 0x30, 0x72,
-	0xe6, 0x39, 0x91, 0x65, 0xda, 0xcd, 0x66, 0xd9, 0x1e, 0x0e, 0x24, 0x2b, 0x85, 0x40, 0x75, 0x52,
-	0x3c, 0xfb, 0x36, 0x87, 0xf9, 0x45, 0x1a, 0xd0, 0x78, 0x78, 0x24, 0xec, 0x5c, 0x26, 0xb0, 0x73,
-	0xcb, 0x90, 0x35, 0x2d, 0x83, 0x9c, 0x32, 0xdd, 0x9c, 0xc1, 0xbc, 0x81, 0x1e, 0x41, 0xce, 0xf3,
-	0x35, 0x7f, 0xe8, 0xb1, 0x99, 0x2c, 0x4c, 0xba, 0x12, 0x11, 0xfe, 0x5d, 0x86, 0x8e, 0x05, 0x19,
-	0x9d, 0xb4, 0xee, 0x0c, 0xd5, 0xa1, 0xa7, 0x1d, 0x11, 0x75, 0x60, 0xea, 0xae, 0xad, 0x7a, 0x44,
-	0xb7, 0x2d, 0xc3, 0x93, 0x93, 0xd6, 0x9d, 0xe1, 0x33, 0xda, 0xbb, 0x47, 0x3b, 0xbb, 0xbc, 0x0f,
-	0xdd, 0x86, 0x45, 0x8b, 0xf8, 0x82, 0xec, 0xb5, 0xed, 0x1a, 0x9e, 0xc8, 0x22, 0xcd, 0x5b, 0xc4,
-	0x67, 0xe8, 0x2f, 0x28, 0x10, 0x3d, 0x07, 0xe4, 0x68, 0xfa, 0xab, 0xb8, 0x5f, 0x2d, 0x2c, 0xd6,
-	0xa4, 0xeb, 0xcb, 0xf0, 0xa3, 0x3b, 0xb2, 0xe4, 0x8c, 0x82, 0x94, 0xbf, 0xa5, 0xd7, 0x78, 0x14,
-	0x8a, 0xae, 0x03, 0x04, 0x59, 0x27, 0x6e, 0x53, 0x8a, 0x38, 0x02, 0x41, 0x9b, 0x50, 0xd2, 0xed,
-	0x81, 0xe3, 0x12, 0x8f, 0x69, 0x18, 0xbe, 0xc0, 0x28, 0x08, 0x7d, 0x1b, 0xaa, 0x62, 0xbe, 0xba,
-	0x6d, 0xf9, 0xe4, 0xd4, 0x57, 0x0f, 0x5d, 0x42, 0x54, 0x43, 0xf3, 0x35, 0xb6, 0xc0, 0x32, 0x5e,
-	0xe1, 0xfd, 0x75, 0xde, 0xfd, 0xd8, 0x25, 0xa4, 0xa1, 0xf9, 0x1a, 0xcb, 0x7c, 0x8d, 0x2f, 0x34,
-	0xc3, 0x48, 0x12, 0xe6, 0xff, 0x57, 0x73, 0x50, 0x8a, 0x24, 0xd0, 0xd0, 0x77, 0xa0, 0x18, 0xa4,
-	0xf4, 0x84, 0xe9, 0xd9, 0xd8, 0xe2, 0x49, 0xbf, 0x2d, 0x99, 0xf4, 0xdb, 0xea, 0x49, 0x0c, 0x1c,
-	0x22, 0xa3, 0x0d, 0x28, 0x48, 0xed, 0x26, 0xa4, 0x25, 0x68, 0xd3, 0xeb, 0x2c, 0xd2, 0x28, 0xc4,
-	0x60, 0x9b, 0x3e, 0x8f, 0x43, 0x00, 0xa7, 0x24, 0x27, 0xa6, 0x3d, 0xf4, 0x98, 0x50, 0x31, 0x4a,
-	0xde, 0xa6, 0x46, 0x3a, 0xea, 0x6d, 0x0c, 0x5c, 0xdb, 0xf6, 0xab, 0x79, 0xb6, 0x9a, 0xa8, 0x63,
-	0xb3, 0x47, 0xe1, 0xf2, 0xc2, 0x06, 0x78, 0x05, 0x86, 0x57, 0x8a, 0xa2, 0xbc, 0x1f, 0xb1, 0xd5,
-	0x52, 0xc1, 0xf3, 0x24, 0xda, 0x62, 0x60, 0xe7, 0x84, 0xa2, 0xdf, 0x85, 0x25, 0x9e, 0x4d, 0x8c,
-	0x66, 0x05, 0x4b, 0x17, 0xcb, 0x0a, 0x56, 0x38, 0x65, 0x24, 0x2d, 0xb8, 0x0f, 0x15, 0x8b, 0xbc,
-	0x56, 0x03, 0x03, 0x30, 0x7b, 0x6c, 0xb0, 0x60, 0x91, 0xd7, 0x12, 0xe8, 0x3d, 0x7f, 0xa0, 0xfc,
-	0x3e, 0x40, 0x25, 0x72, 0x94, 0xcd, 0x13, 0x62, 0xf9, 0x63, 0x5e, 0xe9, 0x3a, 0x14, 0xb8, 0x1a,
-	0x30, 0x0d, 0x61, 0x07, 0xf3, 0xac, 0xdd, 0x32, 0xd0, 0x15, 0x28, 0x06, 0x1a, 0x42, 0x5c, 0x1a,
-	0x8e, 0x4b, 0x3d, 0x95, 0x51, 0x47, 0x2c, 0x33, 0xee, 0x88, 0x21, 0x02, 0x4b, 0xa6, 0xe5, 0x13,
-	0xd7, 0xa2, 0xd1, 0x95, 0x61, 0x98, 0x91, 0x2b, 0xf5, 0xad, 0x73, 0xaf, 0x3f, 0x9b, 0xee, 0x56,
-	0xcd, 0x30, 0x68, 0x64, 0xc8, 0x99, 0xf4, 0xcf, 0x76, 0x2e, 0xe1, 0x8a, 0x64, 0x59, 0x13, 0x1c,
-	0xd1, 0x57, 0x50, 0x08, 0xb8, 0xe7, 0x18, 0xf7, 0x0f, 0x67, 0xe1, 0xbe, 0x73, 0x09, 0x07, 0xf4,
-	0xa8, 0x03, 0x45, 0x1e, 0x16, 0x52, 0x66, 0xf9, 0x69, 0x0e, 0xd1, 0x18, 0x33, 0x19, 0x23, 0xee,
-	0x5c, 0xc2, 0x21, 0x0f, 0xa4, 0xc2, 0xa2, 0xe1, 0xbb, 0xa7, 0x32, 0x4e, 0x32, 0xad, 0x23, 0x26,
-	0x75, 0xa5, 0x87, 0x9f, 0x5c, 0x90, 0x6d, 0xc3, 0x77, 0x4f, 0xe5, 0x11, 0x53, 0xde, 0x0b, 0x46,
-	0x08, 0x30, 0xad, 0x23, 0x74, 0x00, 0x4b, 0x6c, 0x00, 0x5d, 0xb3, 0x74, 0xd2, 0xef, 0x6b, 0xbe,
-	0x94, 0xd8, 0xd2, 0xc3, 0x8f, 0x67, 0x18, 0xa2, 0xce, 0xc8, 0xd9, 0x08, 0x15, 0x23, 0x68, 0x73,
-	0x76, 0x1b, 0xdf, 0x87, 0xc5, 0x91, 0x83, 0x40, 0x2d, 0x28, 0x45, 0xf5, 0x47, 0x6a, 0x9a, 0xa2,
-	0xa4, 0xf6, 0x3b, 0xae, 0x28, 0xa3, 0xb4, 0x1b, 0xff, 0x9c, 0x82, 0x2c, 0x63, 0x8f, 0xb6, 0x21,
-	0xef, 0x72, 0xab, 0x22, 0x18, 0x5e, 0x3c, 0x49, 0x27, 0x09, 0x47, 0x27, 0x96, 0x7e, 0xf3, 0x89,
-	0xa1, 0x1a, 0x94, 0x9c, 0xe1, 0x41, 0xdf, 0xd4, 0x55, 0xe6, 0x4d, 0x70, 0x6d, 0xb7, 0x39, 0xe1,
-	0x36, 0x32, 0xc4, 0xa7, 0xe4, 0xcc, 0xc3, 0xe0, 0x04, 0xbf, 0x37, 0x7e, 0x96, 0x82, 0x82, 0x14,
-	0x0c, 0xf4, 0x39, 0x64, 0x59, 0x34, 0x24, 0x16, 0x77, 0xd1, 0xe4, 0x12, 0x27, 0x42, 0x75, 0x28,
-	0x1d, 0x84, 0x8a, 0x58, 0x2c, 0xec, 0x02, 0x25, 0x8f, 0x28, 0xd5, 0xc6, 0x1f, 0xa7, 0x60, 0x3e,
-	0x26, 0x51, 0xe8, 0xff, 0x03, 0xe8, 0x2e, 0x61, 0x59, 0xe5, 0x83, 0x33, 0x31, 0xb3, 0xc9, 0xea,
-	0xab, 0xc1, 0x13, 0x79, 0x45, 0x41, 0xb2, 0x7d, 0xf6, 0x16, 0xf7, 0x7b, 0x63, 0x1f, 0xca, 0x51,
-	0x51, 0x44, 0x5f, 0x42, 0x49, 0x17, 0xbf, 0x67, 0x98, 0x1b, 0x48, 0x9a, 0xed, 0xb3, 0xed, 0x3c,
-	0x64, 0x09, 0x15, 0x71, 0xe5, 0x1e, 0x40, 0x78, 0x42, 0xe8, 0x46, 0xfc, 0x60, 0x85, 0xfd, 0x0d,
-	0x8f, 0x4d, 0xf9, 0x79, 0x0e, 0x96, 0x23, 0xd3, 0xdc, 0x35, 0x0f, 0x89, 0x7e, 0xa6, 0xf7, 0xc9,
-	0x98, 0xfa, 0x7c, 0x0e, 0x28, 0x6a, 0x7e, 0x84, 0x8b, 0x93, 0x9e, 0xcd, 0xc5, 0x89, 0x5a, 0x30,
-	0x0e, 0x42, 0x2f, 0xe1, 0x72, 0x3c, 0x2c, 0xe7, 0xb7, 0xe2, 0x9d, 0x19, 0x6f, 0x05, 0xf2, 0xc7,
-	0xfd, 0xb5, 0x91, 0x03, 0x83, 0xdf, 0xe0, 0x82, 0x8c, 0xec, 0xe3, 0xe5, 0xd1, 0x7d, 0x44, 0x1d,
-	0x58, 0x0c, 0x54, 0x21, 0xcf, 0x04, 0x88, 0xea, 0xd2, 0x45, 0x65, 0x7f, 0x21, 0x20, 0x67, 0x6d,
-	0xf4, 0x02, 0x56, 0x43, 0x86, 0xdc, 0x3a, 0x89, 0x12, 0x60, 0xf9, 0xa2, 0xf7, 0x61, 0x39, 0x60,
-	0x10, 0xf5, 0x6b, 0xe2, 0xd7, 0x60, 0x79, 0xe6, 0x6b, 0x30, 0x22, 0xab, 0x2b, 0x33, 0xcb, 0x2a,
-	0xfa, 0x18, 0x56, 0x18, 0x3b, 0xba, 0xb2, 0x98, 0x69, 0xbd, 0xc9, 0x4c, 0xeb, 0xb2, 0xec, 0x8c,
-	0xd6, 0xf1, 0xd0, 0xa7, 0xd1, 0xfd, 0x88, 0x51, 0x29, 0x8c, 0x6a, 0x25, 0xe8, 0x8d, 0x91, 0x7d,
-	0x06, 0x55, 0x3e, 0x72, 0xc2, 0x70, 0xb7, 0x18, 0xe1, 0x5a, 0xa4, 0x3f, 0x4a, 0xfa, 0x55, 0xa6,
-	0x30, 0x5f, 0xb9, 0xfc, 0x55, 0xa6, 0xb0, 0x5a, 0xb9, 0xa9, 0xfc, 0x3c, 0x05, 0x4b, 0x63, 0x22,
-	0x42, 0x15, 0xd5, 0xb8, 0x69, 0xb8, 0x79, 0xbe, 0xcc, 0xc6, 0x44, 0x2b, 0xee, 0x21, 0xa7, 0xc7,
-	0x3c, 0xe4, 0xbb, 0xb0, 0x94, 0xe4, 0xf8, 0xd2, 0x00, 0x6c, 0x51, 0x8f, 0xbb, 0xbc, 0xca, 0x1f,
-	0xa5, 0xa1, 0x14, 0x9d, 0xe0, 0xa3, 0xa0, 0x6e, 0x3c, 0xd5, 0x6c, 0x45, 0x48, 0x46, 0xaa, 0xc7,
-	0x6d, 0x58, 0x8e, 0x0d, 0x2e, 0x2b, 0x4b, 0x3c, 0xde, 0xbc, 0x3a, 0xb9, 0x08, 0x67, 0x5b, 0x18,
-	0x45, 0x66, 0x57, 0x13, 0xb5, 0xa4, 0x6f, 0x41, 0x5e, 0xb2, 0x98, 0xbb, 0x00, 0x0b, 0x89, 0x8c,
-	0x1e, 0x01, 0x44, 0x5c, 0xcf, 0xcc, 0xc5, 0x5c, 0xcf, 0x08, 0x89, 0xf2, 0x87, 0x69, 0x58, 0x1a,
-	0x5b, 0x26, 0xfa, 0x2e, 0x65, 0xeb, 0x98, 0xae, 0x16, 0x39, 0xbf, 0x69, 0x4e, 0x7e, 0x04, 0x1b,
-	0x29, 0x30, 0xef, 0x92, 0xc3, 0x30, 0xb4, 0x94, 0xb1, 0x8b, 0x4b, 0x0e, 0x65, 0x40, 0x89, 0xee,
-	0x40, 0x25, 0xc4, 0x71, 0x5c, 0x72, 0x68, 0x9e, 0x0a, 0xff, 0x72, 0x41, 0xa2, 0xed, 0x33, 0x28,
-	0xba, 0x07, 0x97, 0x07, 0xda, 0xa9, 0x3a, 0x1a, 0xc1, 0x65, 0x18, 0x72, 0x65, 0xa0, 0x9d, 0xb6,
-	0x63, 0x41, 0xdc, 0x7b, 0x40, 0x61, 0x6a, 0x24, 0x4e, 0xf4, 0x44, 0x34, 0x31, 0x3f, 0xd0, 0x4e,
-	0xeb, 0x32, 0x3e, 0xf4, 0xa8, 0x6b, 0x6b, 0x90, 0xbe, 0x76, 0x46, 0x43, 0x48, 0xe6, 0x33, 0xce,
-	0xe3, 0x02, 0x03, 0x74, 0x89, 0xae, 0xfc, 0x41, 0x31, 0xe6, 0x37, 0x73, 0xc5, 0x33, 0xaa, 0xf8,
-	0x63, 0xce, 0x71, 0x9a, 0x45, 0xba, 0xa1, 0x73, 0x1c, 0x84, 0xc0, 0x1b, 0xd1, 0x10, 0xf8, 0x33,
-	0x00, 0x4e, 0x42, 0x63, 0xa2, 0x8b, 0xc4, 0x4e, 0x0c, 0x9b, 0xb6, 0xa9, 0xb4, 0x07, 0xb5, 0xee,
-	0xc0, 0x5d, 0xe7, 0x41, 0xd4, 0xa2, 0xec, 0xd8, 0x16, 0x6e, 0xfb, 0x4e, 0xe8, 0x44, 0x71, 0x5f,
-	0x7b, 0xeb, 0xa2, 0xe6, 0x42, 0x48, 0x79, 0xe0, 0x4a, 0x55, 0x21, 0x4f, 0xfa, 0x9a, 0xe3, 0x11,
-	0x83, 0xed, 0xd1, 0x1c, 0x96, 0x4d, 0xba, 0xfa, 0xe0, 0x4c, 0x98, 0x9b, 0x9c, 0xc1, 0x05, 0x19,
-	0x4f, 0xd3, 0x60, 0x4e, 0x86, 0x4a, 0x06, 0x73, 0x76, 0x0b, 0x38, 0x04, 0xa0, 0xc7, 0x30, 0x1f,
-	0xaf, 0xb4, 0x15, 0xa7, 0x25, 0xfe, 0x6a, 0x11, 0x5b, 0x50, 0x8e, 0xd5, 0xd5, 0x30, 0x2c, 0x1d,
-	0x6a, 0x26, 0x55, 0xb7, 0xcc, 0xfd, 0xe5, 0xc6, 0x05, 0x66, 0x32, 0x2e, 0x8b, 0x9c, 0x01, 0xf5,
-	0x39, 0xf8, 0x21, 0x7f, 0x41, 0xbd, 0x7f, 0x9d, 0x38, 0x4c, 0xee, 0x17, 0xa7, 0xab, 0x70, 0x81,
-	0x86, 0x43, 0x0a, 0x74, 0x0d, 0x80, 0xb8, 0xae, 0xed, 0xaa, 0x14, 0x8d, 0x95, 0xfd, 0x33, 0xb8,
-	0xc8, 0x20, 0x75, 0xdb, 0x20, 0xe8, 0x01, 0xe4, 0x8c, 0x03, 0x56, 0x2a, 0x5d, 0x62, 0x4b, 0xde,
-	0x48, 0x66, 0xdd, 0xd8, 0xee, 0x38, 0x38, 0x6b, 0x1c, 0x74, 0x1c, 0x0f, 0x7d, 0x1b, 0x0a, 0x6c,
-	0x75, 0x94, 0x08, 0x4d, 0xd3, 0x0c, 0xc2, 0x9e, 0xe4, 0x29, 0x36, 0x25, 0xfc, 0x12, 0x4a, 0x22,
-	0x65, 0x1d, 0xa9, 0xcf, 0x4f, 0x58, 0x8b, 0xc8, 0x61, 0x53, 0x73, 0x74, 0x28, 0x7f, 0xb2, 0xa1,
-	0x1d, 0xe2, 0x0e, 0x22, 0x45, 0xe4, 0xab, 0x93, 0x5e, 0x30, 0xb8, 0x03, 0x3a, 0xb4, 0xc3, 0xfe,
-	0x7a, 0xe8, 0x13, 0xc8, 0xbb, 0x1a, 0xa7, 0x5b, 0x99, 0x56, 0x12, 0xc6, 0xb5, 0xbd, 0x8e, 0x83,
-	0x73, 0xae, 0xc6, 0xa8, 0xba, 0x80, 0x28, 0x95, 0x6e, 0xbb, 0x2e, 0x09, 0x6b, 0xca, 0xab, 0x8c,
-	0xc1, 0xbb, 0x13, 0x19, 0xd4, 0x03, 0xf4, 0x8e, 0x83, 0x2b, 0xae, 0x36, 0x88, 0x02, 0xbc, 0x91,
-	0x47, 0x0a, 0x6b, 0xb3, 0x3e, 0x52, 0xf8, 0x2e, 0x14, 0x7d, 0xed, 0xa0, 0xcf, 0xb7, 0xb0, 0xca,
-	0xa8, 0xaf, 0x4d, 0x10, 0x2d, 0x8a, 0xd6, 0x71, 0x70, 0xc1, 0xe7, 0x3f, 0x3c, 0xf4, 0x14, 0xe6,
-	0x03, 0x6b, 0xee, 0xbb, 0x84, 0x54, 0xd7, 0xa7, 0x15, 0x94, 0xeb, 0x02, 0xf5, 0x71, 0x5f, 0xf3,
-	0xdb, 0xb6, 0x41, 0x70, 0x59, 0x12, 0xf7, 0x5c, 0x42, 0x94, 0x5f, 0xa6, 0xa0, 0x3a, 0xe9, 0xba,
-	0xfe, 0x5f, 0xcf, 0xac, 0x29, 0x7f, 0x93, 0x82, 0x1c, 0xbf, 0xc6, 0x54, 0xa1, 0x88, 0x4c, 0xb5,
-	0xd0, 0xa4, 0xb2, 0x19, 0xa4, 0x29, 0xd3, 0x91, 0x34, 0xe5, 0x53, 0x98, 0x17, 0xa9, 0xeb, 0x1f,
-	0x71, 0x4b, 0x34, 0x37, 0x4d, 0x1a, 0xa8, 0x18, 0x9a, 0x2c, 0x37, 0xb6, 0x4b, 0x4e, 0x48, 0x1f,
-	0xc7, 0x69, 0xa9, 0xc6, 0xfa, 0xc6, 0xb3, 0x2d, 0xee, 0x27, 0x88, 0xf4, 0x13, 0x05, 0xb0, 0x9c,
-	0xd8, 0x3a, 0x14, 0x5c, 0xed, 0x35, 0xef, 0xcb, 0xb2, 0x9c, 0x50, 0xde, 0xd5, 0x5e, 0x33, 0xdf,
-	0xe1, 0x57, 0x39, 0x28, 0x45, 0x94, 0x10, 0xda, 0x80, 0x02, 0x53, 0x8f, 0x27, 0xc4, 0x65, 0xae,
-	0x6c, 0x11, 0x07, 0x6d, 0xf4, 0xc5, 0x68, 0xf8, 0x7a, 0x6b, 0xaa, 0x19, 0x1f, 0x8d, 0x5c, 0x3f,
-	0x81, 0x5c, 0x2c, 0x88, 0x9a, 0xee, 0x04, 0x08, 0x5c, 0x74, 0x13, 0xca, 0x51, 0x5f, 0x84, 0x9d,
-	0x41, 0x01, 0x97, 0x22, 0x5e, 0x46, 0x54, 0x8f, 0x67, 0xe2, 0x7a, 0xbc, 0x0a, 0x79, 0xdd, 0xb6,
-	0x3c, 0xbb, 0x2f, 0xdf, 0xbe, 0xc9, 0x26, 0x7a, 0x17, 0x16, 0xa2, 0x01, 0x88, 0x69, 0x88, 0xcc,
-	0xdb, 0x7c, 0x04, 0x3a, 0x9a, 0x23, 0xca, 0x8f, 0x98, 0xc1, 0x44, 0xab, 0x55, 0x48, 0xb6, 0x5a,
-	0x71, 0xe3, 0x58, 0x9c, 0xc5, 0x38, 0x76, 0x01, 0xc9, 0x9a, 0x08, 0x55, 0x21, 0x06, 0xe9, 0xfb,
-	0x9a, 0x57, 0x85, 0x69, 0xc2, 0x52, 0xe3, 0xf8, 0xb8, 0xb6, 0xd7, 0xa0, 0xd8, 0xb8, 0x22, 0x18,
-	0x60, 0x6d, 0xc0, 0x00, 0xde, 0xdb, 0x35, 0x05, 0xcb, 0xa3, 0xa6, 0xe0, 0x5d, 0x58, 0x90, 0x2f,
-	0x67, 0x5c, 0xc3, 0xb4, 0xb4, 0x3e, 0xb3, 0x16, 0xf3, 0x58, 0x98, 0xc6, 0x0e, 0x07, 0xa2, 0x4f,
-	0x60, 0x95, 0xe9, 0x01, 0xdb, 0x55, 0x47, 0xd0, 0x97, 0xc4, 0xc5, 0xe4, 0xbd, 0xb5, 0x18, 0xd5,
-	0xf7, 0xe0, 0xae, 0xde, 0xb7, 0x3d, 0xe2, 0xf9, 0xea, 0xd0, 0xb2, 0x6c, 0xdf, 0x3c, 0x34, 0x89,
-	0xa1, 0x52, 0x77, 0xde, 0x4b, 0xe0, 0x84, 0x18, 0xa7, 0xdb, 0x82, 0xe2, 0x59, 0x40, 0x50, 0x13,
-	0xf8, 0x71, 0xde, 0xef, 0x45, 0x03, 0x3a, 0xee, 0xe3, 0x5c, 0xe6, 0x9e, 0x5b, 0x18, 0x68, 0x30,
-	0x67, 0x27, 0x56, 0xc2, 0x1f, 0x68, 0x3e, 0xb5, 0xff, 0xec, 0x1d, 0x42, 0xb4, 0x84, 0xbf, 0xc7,
-	0xe1, 0xca, 0x9f, 0xa7, 0x61, 0x3e, 0x76, 0x29, 0x62, 0xd7, 0x2c, 0x35, 0x72, 0xcd, 0x56, 0x21,
-	0x67, 0x98, 0x47, 0xc4, 0xf3, 0x85, 0xb6, 0x10, 0x2d, 0x3a, 0xb7, 0xa3, 0xbe, 0x7d, 0xa0, 0xf5,
-	0x55, 0x8f, 0xfc, 0x70, 0x48, 0x2c, 0x9d, 0x5f, 0x86, 0x0c, 0x5e, 0xe0, 0xe0, 0xae, 0x80, 0xa2,
-	0x27, 0x5c, 0xb1, 0x84, 0x68, 0xdc, 0x73, 0x56, 0x26, 0xc8, 0xca, 0xd0, 0x3f, 0x96, 0xa4, 0xb8,
-	0xac, 0x45, 0x5a, 0xe8, 0x16, 0x75, 0x76, 0xf5, 0x93, 0x90, 0x51, 0x96, 0x8d, 0x57, 0xa6, 0xc0,
-	0x28, 0x12, 0xe5, 0x15, 0x22, 0xf1, 0xba, 0x48, 0x99, 0x02, 0x03, 0xa4, 0x9b, 0x50, 0xd6, 0x0e,
-	0xcc, 0x10, 0x87, 0x5f, 0xa5, 0x92, 0x76, 0x60, 0x4a, 0x14, 0x65, 0x0f, 0xca, 0xd1, 0xa9, 0x5c,
-	0xa4, 0x14, 0xb8, 0x01, 0x85, 0x80, 0xa3, 0xf0, 0x51, 0x65, 0x5b, 0xa9, 0xc1, 0xe2, 0xc8, 0x2d,
-	0x98, 0xa2, 0x9e, 0x97, 0x21, 0xcb, 0xae, 0x15, 0xe3, 0x32, 0x87, 0x79, 0x43, 0xf9, 0x18, 0x8a,
-	0x41, 0x58, 0x41, 0x35, 0xb8, 0x7f, 0xe6, 0x10, 0x51, 0x25, 0x63, 0xbf, 0x29, 0x8c, 0xe9, 0x54,
-	0x5e, 0xc5, 0x66, 0xbf, 0x95, 0x9f, 0xa6, 0x21, 0xcb, 0x9c, 0x15, 0x54, 0x87, 0xa2, 0xed, 0x90,
-	0x48, 0x94, 0xb1, 0x30, 0xf9, 0x6d, 0xc0, 0x69, 0xc7, 0xd9, 0xea, 0x48, 0x64, 0x1c, 0xd2, 0x25,
-	0x1a, 0x8e, 0x71, 0xdd, 0x35, 0x97, 0xa4, 0xbb, 0x46, 0x12, 0x21, 0x99, 0x37, 0x4f, 0x84, 0x28,
-	0xdf, 0x81, 0x62, 0x30, 0x3b, 0xb4, 0x02, 0x4b, 0x9d, 0xfd, 0x26, 0xae, 0xf5, 0x5a, 0x9d, 0xb6,
-	0xfa, 0xac, 0xfd, 0xb4, 0xdd, 0x79, 0xd1, 0xae, 0x5c, 0x42, 0xcb, 0x50, 0x09, 0xc1, 0x75, 0xdc,
-	0xac, 0xf5, 0x9a, 0x95, 0x94, 0xf2, 0x17, 0x73, 0x90, 0xa1, 0x1e, 0x1f, 0xda, 0x1e, 0xdf, 0x8d,
-	0x77, 0x26, 0x3b, 0x88, 0xc9, 0x9b, 0x11, 0xd6, 0x37, 0xf8, 0xd5, 0x14, 0xb1, 0x97, 0x58, 0x31,
-	0xbb, 0x97, 0x08, 0x32, 0x4c, 0x25, 0xf1, 0x1d, 0x61, 0xbf, 0xe9, 0xe9, 0x7a, 0xba, 0xed, 0x10,
-	0x61, 0x17, 0x79, 0x83, 0xaa, 0x30, 0xee, 0xfd, 0xb0, 0xfd, 0xe5, 0xe6, 0x81, 0xfb, 0x43, 0x4c,
-	0xb6, 0x6e, 0x40, 0xc9, 0x71, 0xcd, 0x81, 0xe6, 0x9e, 0xb1, 0x1a, 0x3a, 0xb7, 0x0e, 0x20, 0x40,
-	0x4f, 0xc9, 0x19, 0x35, 0x0d, 0x76, 0xdf, 0x50, 0x1d, 0xed, 0x8c, 0xb8, 0x4c, 0x9e, 0x8b, 0xb8,
-	0x60, 0xf7, 0x8d, 0x7d, 0xda, 0xe6, 0x01, 0xc4, 0x6b, 0xd1, 0xc9, 0x4d, 0x42, 0xc1, 0x22, 0xaf,
-	0x79, 0xe7, 0x3a, 0x50, 0x44, 0x6e, 0x8e, 0x8b, 0xdc, 0x1c, 0xdb, 0x7d, 0x43, 0x5a, 0x6a, 0x4a,
-	0xc7, 0xba, 0x80, 0x77, 0x59, 0x84, 0x5b, 0x6a, 0x63, 0xd6, 0x33, 0x68, 0xb5, 0xbb, 0x4d, 0xdc,
-	0xab, 0xa4, 0xe2, 0xd0, 0x67, 0xfb, 0x0d, 0x7a, 0x32, 0xe9, 0x38, 0x14, 0x37, 0xf7, 0x3a, 0xcf,
-	0x9b, 0x95, 0x39, 0xe5, 0xef, 0xca, 0x90, 0x65, 0x9e, 0xeb, 0x0c, 0xe2, 0xcb, 0xf0, 0xdf, 0xf8,
-	0xc4, 0x96, 0x21, 0xcb, 0xb7, 0x89, 0x1f, 0x19, 0x6f, 0x84, 0x37, 0x32, 0x13, 0xb9, 0x91, 0x14,
-	0xca, 0x63, 0x32, 0xae, 0x88, 0x78, 0x83, 0xce, 0x94, 0x9e, 0xa1, 0xe7, 0x68, 0x42, 0xfb, 0x9c,
-	0x33, 0xd3, 0xb6, 0x44, 0xc6, 0x21, 0x1d, 0x15, 0x87, 0xa1, 0x65, 0xfe, 0x70, 0x48, 0xd8, 0x71,
-	0xf3, 0x23, 0x2b, 0x72, 0x08, 0x3d, 0xed, 0xef, 0x06, 0xce, 0x4b, 0x9e, 0x0d, 0xa0, 0x4c, 0x1b,
-	0x20, 0xee, 0xc2, 0x28, 0xff, 0x99, 0xbb, 0xc0, 0xd1, 0x6d, 0xc0, 0xea, 0xe8, 0xf5, 0x51, 0x7b,
-	0xb5, 0xed, 0xdd, 0x66, 0x25, 0x85, 0xae, 0xc3, 0x46, 0xd8, 0xd7, 0x68, 0x3e, 0x6e, 0x62, 0xdc,
-	0x6c, 0xa8, 0x3d, 0xfc, 0xb5, 0x5a, 0x6b, 0x34, 0x2a, 0x69, 0x74, 0x13, 0xae, 0x4d, 0xe8, 0xaf,
-	0xd7, 0xda, 0xf5, 0xe6, 0x6e, 0x65, 0x6e, 0x0a, 0xca, 0xfe, 0xb3, 0xee, 0x4e, 0xb3, 0x51, 0xc9,
-	0xa0, 0xf7, 0xe1, 0xdd, 0x09, 0x28, 0xb8, 0xb6, 0xa7, 0xd6, 0x3b, 0x18, 0x37, 0xeb, 0xb4, 0xaf,
-	0x92, 0x45, 0x0a, 0x5c, 0x9f, 0x84, 0xca, 0x04, 0xa9, 0x51, 0xc9, 0xa1, 0x2a, 0x2c, 0x47, 0x71,
-	0x76, 0x9b, 0xbd, 0x66, 0xed, 0x59, 0x6f, 0xa7, 0x92, 0x47, 0xab, 0x80, 0xc2, 0x9e, 0xdd, 0x56,
-	0xfb, 0x29, 0x83, 0x17, 0xe2, 0x14, 0xed, 0xe6, 0x8b, 0x5a, 0xbd, 0xde, 0x79, 0xd6, 0xee, 0x55,
-	0x8a, 0xe8, 0x06, 0x5c, 0x09, 0x7b, 0xf6, 0x71, 0x6b, 0xaf, 0x86, 0x5f, 0xaa, 0xad, 0x76, 0xa3,
-	0xc9, 0x77, 0x00, 0xe2, 0x13, 0x8a, 0x23, 0x08, 0xd1, 0x2e, 0x4d, 0xc3, 0x11, 0x97, 0xa2, 0x8c,
-	0x3e, 0x82, 0x0f, 0xa7, 0xe3, 0xd0, 0xf1, 0xe8, 0xdc, 0xd4, 0xfd, 0xda, 0xcb, 0x26, 0xae, 0xcc,
-	0xa3, 0x8f, 0xe1, 0xfe, 0x39, 0x14, 0x7c, 0x02, 0x6a, 0x67, 0xb7, 0x21, 0x88, 0x16, 0xe2, 0x87,
-	0x2d, 0xfa, 0xf9, 0x61, 0x2f, 0xc6, 0x4f, 0xaa, 0xdb, 0xac, 0x77, 0xda, 0x8d, 0xf8, 0x6a, 0x2b,
-	0xe8, 0x1d, 0xd8, 0x9c, 0x8c, 0x22, 0xd6, 0xbb, 0x84, 0x1e, 0xc2, 0xd6, 0x64, 0xac, 0xc4, 0xd5,
-	0x20, 0xf4, 0x29, 0x3c, 0x38, 0x97, 0x66, 0x6c, 0x3d, 0x97, 0xe3, 0xba, 0xa4, 0xdb, 0xec, 0xd5,
-	0xb6, 0x5b, 0x95, 0xe5, 0xb8, 0xa4, 0x77, 0x9b, 0xbd, 0x7a, 0xa7, 0xd1, 0xac, 0xac, 0xc4, 0x8f,
-	0xf9, 0x59, 0x3b, 0x10, 0x80, 0xd5, 0xf8, 0x31, 0xf3, 0xd1, 0x68, 0x8f, 0xb4, 0x26, 0x6b, 0x13,
-	0x11, 0xc4, 0xf9, 0x55, 0x95, 0xff, 0x4a, 0x41, 0x31, 0xb8, 0xde, 0x74, 0x02, 0xed, 0xda, 0x5e,
-	0xb3, 0xbb, 0x5f, 0xab, 0x37, 0x23, 0x57, 0x6d, 0x09, 0xe6, 0x43, 0x30, 0x9d, 0x6a, 0x2a, 0x8e,
-	0x29, 0xe5, 0x2e, 0x8d, 0x10, 0x2c, 0x44, 0xc0, 0x74, 0x92, 0x73, 0x68, 0x0d, 0x2e, 0xc7, 0x61,
-	0x4c, 0x84, 0x2b, 0x99, 0x38, 0x32, 0x5b, 0x6b, 0x96, 0x1e, 0x74, 0x08, 0x8b, 0x5e, 0x94, 0x4a,
-	0x0e, 0x5d, 0x83, 0xf5, 0xb0, 0x6f, 0x64, 0xaf, 0x2b, 0x79, 0x74, 0x19, 0x16, 0xc3, 0x6e, 0x2e,
-	0x1c, 0x85, 0xf8, 0xe0, 0x0c, 0xa8, 0xe2, 0xce, 0x8b, 0x4a, 0x51, 0xf9, 0x59, 0x18, 0x85, 0x22,
-	0x58, 0xa8, 0xd5, 0x47, 0xb4, 0xcb, 0x02, 0x80, 0x80, 0x51, 0x09, 0x4a, 0xd1, 0x2d, 0x10, 0x6d,
-	0xa1, 0x21, 0xd2, 0x74, 0x0b, 0x24, 0x28, 0xbc, 0xea, 0x73, 0x68, 0x11, 0x4a, 0x02, 0x4c, 0x15,
-	0x45, 0x25, 0x13, 0x21, 0x15, 0x92, 0x96, 0x8d, 0x80, 0xc4, 0x41, 0xe4, 0x94, 0xdf, 0x4d, 0xc1,
-	0xe2, 0x48, 0x02, 0x83, 0x7b, 0x8a, 0x41, 0xfe, 0x23, 0x48, 0x37, 0x96, 0x43, 0x60, 0xcb, 0x18,
-	0xd1, 0xc3, 0xe9, 0x51, 0x3d, 0x3c, 0x83, 0xb5, 0xa0, 0x6e, 0x77, 0x5e, 0x64, 0x2e, 0x50, 0x73,
-	0xdc, 0x9a, 0xbd, 0x37, 0x35, 0xd7, 0xf1, 0x96, 0xed, 0x99, 0xf4, 0x4b, 0x32, 0x49, 0x7e, 0x49,
-	0x76, 0xb2, 0x5f, 0x92, 0x1b, 0xf1, 0x4b, 0x94, 0xf6, 0xdb, 0x71, 0x03, 0xc4, 0xd9, 0xa5, 0x95,
-	0x7f, 0xcc, 0x40, 0x8e, 0x67, 0xd7, 0x50, 0x63, 0x7c, 0x8f, 0x6e, 0x4f, 0x4b, 0xc7, 0xbd, 0xf1,
-	0x16, 0xad, 0x42, 0xce, 0x23, 0x96, 0x11, 0xec, 0x91, 0x68, 0x51, 0xaf, 0x89, 0xff, 0x0a, 0xd3,
-	0xbf, 0x05, 0x0e, 0x68, 0x19, 0xe1, 0xbe, 0x66, 0xa3, 0xfb, 0x7a, 0x13, 0xca, 0xac, 0x1e, 0xe7,
-	0x1d, 0xd3, 0x18, 0xd0, 0x17, 0xfb, 0x55, 0x0a, 0x60, 0x35, 0x9f, 0x7a, 0x72, 0x3c, 0x19, 0x3e,
-	0xb4, 0x7c, 0xb3, 0x2f, 0x5c, 0x35, 0x60, 0xa0, 0x67, 0x14, 0x42, 0xe5, 0x32, 0xcc, 0xf0, 0x53,
-	0x26, 0xdc, 0xfa, 0x97, 0x43, 0x60, 0xcd, 0x4f, 0x70, 0xba, 0x8b, 0x17, 0x70, 0xba, 0x7f, 0x83,
-	0xea, 0xa3, 0xf2, 0xd7, 0xa9, 0x37, 0xf5, 0xba, 0xd1, 0x3a, 0xac, 0x44, 0x8c, 0xd2, 0xb3, 0x6e,
-	0xa0, 0x42, 0x47, 0xdc, 0xbe, 0xc7, 0xb5, 0xd6, 0x6e, 0xb3, 0x51, 0x99, 0x1b, 0x61, 0xc3, 0x55,
-	0x42, 0x06, 0x5d, 0x81, 0xb5, 0x10, 0xba, 0xd7, 0x69, 0xb4, 0x1e, 0xbf, 0x94, 0x9d, 0xd9, 0xe4,
-	0x4e, 0x3e, 0x4a, 0x4e, 0xf9, 0x75, 0x8a, 0xc5, 0x4e, 0x42, 0xb0, 0x1e, 0xc2, 0x8a, 0x67, 0x0f,
-	0x5d, 0x9d, 0xa8, 0x23, 0x5b, 0xc8, 0x15, 0xc0, 0x65, 0xde, 0xd9, 0x9b, 0x9c, 0x79, 0x19, 0x2d,
-	0x40, 0x44, 0x5f, 0xf5, 0xcc, 0xc5, 0x5f, 0xf5, 0xc4, 0x13, 0x2d, 0x99, 0x59, 0x12, 0x2d, 0x9f,
-	0x42, 0x5e, 0x64, 0xa3, 0x45, 0x65, 0x61, 0x7a, 0x32, 0x3a, 0xc7, 0x93, 0xd1, 0xca, 0xbf, 0xa7,
-	0xa0, 0x18, 0xe4, 0x98, 0xe9, 0x45, 0x7f, 0x65, 0x5a, 0x72, 0x69, 0xec, 0xf7, 0x45, 0xae, 0xc4,
-	0xbb, 0xb0, 0x20, 0x13, 0xda, 0x22, 0xf8, 0x17, 0x31, 0x9d, 0x80, 0x36, 0x78, 0x0e, 0xe0, 0xdb,
-	0x90, 0x17, 0x00, 0xb1, 0xb4, 0x6b, 0x53, 0x73, 0xde, 0x58, 0x62, 0x2b, 0xdb, 0x90, 0x79, 0x4a,
-	0xa7, 0x52, 0x81, 0xf2, 0xd3, 0x56, 0xbb, 0x11, 0x91, 0xa0, 0x15, 0x58, 0x62, 0x90, 0x7d, 0x4c,
-	0x2d, 0x5f, 0xaf, 0xf5, 0x9c, 0x8b, 0xd0, 0x12, 0xcc, 0x33, 0x70, 0x00, 0x4a, 0x2b, 0x3f, 0x82,
-	0xca, 0x68, 0x22, 0x17, 0x7d, 0x04, 0xcb, 0x23, 0x29, 0x1c, 0xbe, 0x44, 0xba, 0xfc, 0x2c, 0x46,
-	0xb1, 0x04, 0x0e, 0x5f, 0xe9, 0x27, 0xd1, 0x92, 0x6e, 0xc2, 0xb6, 0x84, 0xf5, 0xeb, 0x08, 0x95,
-	0xf2, 0x2f, 0x69, 0xc8, 0xf1, 0x4c, 0xfc, 0x0c, 0x6a, 0x8a, 0x13, 0xbc, 0xb1, 0x9a, 0xaa, 0xf1,
-	0x38, 0xcd, 0x21, 0xee, 0x40, 0x3c, 0x6a, 0xba, 0x7d, 0x5e, 0x6e, 0xb6, 0x73, 0xf0, 0x0d, 0xd1,
-	0x7d, 0x16, 0xcf, 0x51, 0x20, 0x65, 0xc1, 0xe2, 0x40, 0xca, 0xa2, 0x38, 0x1b, 0x0b, 0x1a, 0x2e,
-	0x12, 0x77, 0xf0, 0xbf, 0x14, 0xf7, 0xfd, 0x47, 0x0a, 0x2a, 0xa3, 0x73, 0x10, 0x45, 0x41, 0x60,
-	0x97, 0x4f, 0x14, 0x05, 0x1d, 0xcd, 0x25, 0x96, 0x4f, 0xef, 0x5d, 0x89, 0xdf, 0x49, 0x0e, 0xe0,
-	0xfa, 0xd9, 0x7e, 0x6d, 0x05, 0xf9, 0x2c, 0xde, 0x48, 0xcc, 0x5f, 0x7c, 0x01, 0x65, 0xf6, 0x3e,
-	0x77, 0xe8, 0xf0, 0x8f, 0x2f, 0xcf, 0x2f, 0x15, 0x96, 0x28, 0xfe, 0x33, 0x47, 0x7e, 0x9a, 0x59,
-	0x0c, 0x9f, 0x7c, 0x67, 0xa6, 0xa5, 0x2e, 0x23, 0x0f, 0xcf, 0x03, 0x0a, 0xe5, 0xc7, 0x00, 0xe1,
-	0x42, 0x13, 0xdf, 0x0f, 0xaf, 0x42, 0x8e, 0xaf, 0x4a, 0x26, 0xe0, 0x78, 0x0b, 0x35, 0x60, 0xde,
-	0x25, 0x3f, 0x1c, 0x9a, 0x2e, 0x35, 0x35, 0x43, 0xff, 0x58, 0x4c, 0xfc, 0xdc, 0xc1, 0xcb, 0x92,
-	0x8a, 0x82, 0x94, 0x7f, 0x4b, 0x41, 0x31, 0x7c, 0x4f, 0xfe, 0xf6, 0x1f, 0x7a, 0xa3, 0x27, 0x50,
-	0x10, 0x79, 0x2d, 0x59, 0x6e, 0xff, 0xe0, 0x42, 0x25, 0x05, 0xc1, 0x24, 0x20, 0x46, 0xdf, 0x82,
-	0xec, 0x6b, 0xcd, 0xf4, 0x65, 0xe5, 0x7d, 0xc2, 0xcb, 0xb0, 0x17, 0x9a, 0xe9, 0x0b, 0x52, 0x8e,
-	0xae, 0x6c, 0x43, 0x31, 0x98, 0x13, 0x75, 0x67, 0xc2, 0x37, 0x34, 0x62, 0x9b, 0x8b, 0xc1, 0x13,
-	0x1a, 0xba, 0xd7, 0xaf, 0x19, 0xa2, 0xfc, 0x2a, 0x9e, 0xb7, 0x94, 0x27, 0xb0, 0x38, 0x32, 0x3d,
-	0x2a, 0x60, 0x9a, 0xee, 0xdb, 0x81, 0x80, 0xb1, 0x06, 0xba, 0x0e, 0xe0, 0x04, 0x88, 0xe2, 0xc0,
-	0x22, 0x10, 0xe5, 0x04, 0x56, 0x12, 0xd7, 0x89, 0x9a, 0x31, 0xc2, 0xd4, 0xb4, 0x6f, 0x77, 0x46,
-	0x6b, 0x2f, 0x11, 0xc2, 0x89, 0x0b, 0x78, 0x04, 0x10, 0xee, 0x0c, 0x35, 0x58, 0x74, 0x6f, 0x58,
-	0x3d, 0x5e, 0x7c, 0x4f, 0x41, 0xdb, 0x5d, 0xa2, 0x4f, 0x64, 0xf0, 0xf7, 0x73, 0x50, 0x90, 0x55,
-	0x3b, 0xf4, 0x78, 0x5c, 0xe7, 0xdd, 0x99, 0x5e, 0xe8, 0x4b, 0xd6, 0x7a, 0x9f, 0x41, 0xd6, 0xf3,
-	0x35, 0x9f, 0x4c, 0x7f, 0x5e, 0xc7, 0x79, 0x74, 0x29, 0xe2, 0xce, 0x25, 0xcc, 0x29, 0xd0, 0xe7,
-	0x90, 0x63, 0x4f, 0x96, 0x8f, 0x84, 0xd8, 0x2b, 0xd3, 0x68, 0xeb, 0x0c, 0x73, 0xe7, 0x12, 0x16,
-	0x34, 0x08, 0xc3, 0x82, 0xcc, 0xe6, 0x32, 0x04, 0xf9, 0x15, 0xd6, 0xfb, 0xd3, 0xb8, 0x88, 0x04,
-	0xee, 0x2e, 0x23, 0xd8, 0xb9, 0x84, 0xe7, 0xb5, 0x28, 0x00, 0x75, 0x40, 0x02, 0xd4, 0x30, 0x2b,
-	0x54, 0x9a, 0xbe, 0x31, 0x82, 0x25, 0xab, 0xdf, 0xed, 0x5c, 0xc2, 0x32, 0xc5, 0xcc, 0xda, 0x6f,
-	0xcb, 0xb7, 0x96, 0xaa, 0x76, 0x3b, 0xc7, 0x7d, 0x01, 0xe5, 0xbf, 0xe7, 0xa0, 0x14, 0xd9, 0x53,
-	0xf4, 0x03, 0x58, 0xd3, 0x4e, 0x88, 0xab, 0x1d, 0x11, 0xf9, 0x90, 0x24, 0x78, 0x6c, 0x30, 0xf5,
-	0xe9, 0x24, 0x9b, 0x65, 0x4d, 0xd7, 0x87, 0x83, 0x61, 0x9f, 0x9a, 0x55, 0xbc, 0x2c, 0xd8, 0xf0,
-	0xa7, 0x27, 0xf2, 0x81, 0xc2, 0x18, 0xfb, 0xa0, 0xfc, 0x29, 0x8e, 0xfd, 0x8d, 0xd8, 0xcb, 0xe7,
-	0x25, 0xac, 0xec, 0x25, 0xfe, 0x07, 0x40, 0x38, 0x6f, 0x5e, 0x89, 0x90, 0x5f, 0xf5, 0x07, 0x53,
-	0x89, 0xe0, 0x86, 0x93, 0xc8, 0xc4, 0x70, 0x03, 0xbe, 0x77, 0xa0, 0xc2, 0xbf, 0x54, 0xa5, 0x5c,
-	0xc5, 0x95, 0xe0, 0x79, 0xbe, 0x05, 0x06, 0x6f, 0x13, 0x79, 0x9b, 0x02, 0x4c, 0xca, 0x53, 0x60,
-	0xe6, 0x22, 0x98, 0x75, 0x67, 0x28, 0x30, 0x6f, 0xc3, 0x22, 0xc7, 0x74, 0xb5, 0x81, 0x7a, 0x70,
-	0xe6, 0x13, 0x4f, 0x94, 0x1e, 0xe6, 0x19, 0x18, 0x6b, 0x83, 0x6d, 0x0a, 0xa4, 0xf3, 0x3c, 0x31,
-	0x5d, 0x7f, 0x28, 0x46, 0x67, 0x67, 0xc5, 0x6c, 0x7e, 0x06, 0x2f, 0x8a, 0x8e, 0x36, 0xe1, 0x72,
-	0x17, 0xc5, 0xa5, 0xe3, 0x73, 0xdc, 0x62, 0x0c, 0xb7, 0xee, 0x0c, 0x19, 0xae, 0xf2, 0xaf, 0x69,
-	0x28, 0x47, 0x6f, 0x04, 0xfa, 0x6d, 0x58, 0x0e, 0x88, 0x54, 0x47, 0x73, 0xb5, 0x01, 0xf1, 0x89,
-	0xeb, 0x89, 0x73, 0x9f, 0xf0, 0xb0, 0xbb, 0x49, 0xcd, 0x9f, 0xa9, 0x33, 0x96, 0xfb, 0x01, 0x0d,
-	0x46, 0xba, 0x18, 0x26, 0x84, 0x51, 0xfe, 0xc1, 0x02, 0xa2, 0xfc, 0xd3, 0x6f, 0xc2, 0xdf, 0x12,
-	0x4b, 0x8e, 0xf0, 0x7f, 0x0c, 0x9b, 0xf2, 0xce, 0x85, 0x45, 0x75, 0x29, 0x6d, 0xaf, 0x4d, 0xcb,
-	0xb0, 0x5f, 0x8b, 0x32, 0xf9, 0x55, 0x81, 0x27, 0xcf, 0xb7, 0xc6, 0x91, 0x5e, 0x30, 0x9c, 0x28,
-	0x9f, 0xb0, 0xca, 0x3e, 0xc2, 0x27, 0x13, 0xe3, 0x23, 0x65, 0x2a, 0xc6, 0x47, 0xf9, 0xb3, 0x14,
-	0x5c, 0x4e, 0x50, 0x16, 0x13, 0xbc, 0x91, 0x2a, 0xe4, 0x85, 0xd4, 0xb1, 0x0d, 0x29, 0x60, 0xd9,
-	0x64, 0x9f, 0x42, 0x85, 0x62, 0x37, 0xc7, 0xd2, 0x08, 0x45, 0x2b, 0x90, 0xb8, 0x6b, 0x00, 0x11,
-	0x59, 0xe3, 0x59, 0x86, 0xa2, 0x1e, 0x88, 0xd9, 0x15, 0x28, 0x86, 0x02, 0x96, 0x65, 0xbd, 0x05,
-	0x57, 0xc8, 0x96, 0xf2, 0x0f, 0x29, 0x40, 0xe3, 0xca, 0x67, 0xc2, 0x0c, 0xeb, 0xd1, 0x97, 0x47,
-	0xb3, 0xdd, 0xd6, 0xf0, 0x85, 0x52, 0x1d, 0x8a, 0xe1, 0x6d, 0x9b, 0x9b, 0x8d, 0x89, 0x7c, 0x0b,
-	0x21, 0xd7, 0x14, 0xbd, 0xb2, 0x74, 0x4d, 0x5c, 0x53, 0xf6, 0xa1, 0x32, 0x4a, 0x4a, 0x3d, 0x6a,
-	0xe6, 0xd6, 0xc9, 0xca, 0x2b, 0xb7, 0x73, 0xcc, 0x75, 0x93, 0xe5, 0xd5, 0x75, 0x28, 0x9c, 0x68,
-	0xfd, 0x21, 0x51, 0x85, 0xc3, 0x9d, 0xc1, 0x79, 0xd6, 0x6e, 0x9e, 0xa2, 0x0d, 0x28, 0xe8, 0xb6,
-	0xe5, 0x0d, 0x07, 0xc2, 0x21, 0xcc, 0xe0, 0xa0, 0xad, 0xfc, 0x24, 0x0d, 0xab, 0xc9, 0x32, 0x4a,
-	0xad, 0xa7, 0xaf, 0xb9, 0x47, 0x84, 0x97, 0xf4, 0x32, 0x58, 0xb4, 0x50, 0x05, 0xe6, 0x06, 0x9a,
-	0x1c, 0x84, 0xfe, 0xe4, 0x67, 0xef, 0x9a, 0x76, 0xf0, 0x8e, 0x43, 0x36, 0x69, 0xec, 0x35, 0xd0,
-	0x4e, 0xd5, 0xc1, 0xb0, 0xef, 0x9b, 0x4e, 0xdf, 0x14, 0xdf, 0xef, 0xf0, 0x47, 0x75, 0x7b, 0x01,
-	0x10, 0x7d, 0xc9, 0xfe, 0x41, 0x8a, 0xef, 0x6a, 0xba, 0xaf, 0xba, 0xd4, 0x86, 0x66, 0xa7, 0x7d,
-	0xe8, 0x8c, 0xa9, 0x15, 0x61, 0xff, 0x3d, 0x85, 0x51, 0x60, 0x6e, 0x42, 0x4b, 0xe4, 0xd4, 0xd1,
-	0x2c, 0x83, 0xd3, 0xe7, 0xce, 0xa7, 0x07, 0x8e, 0x4f, 0xa9, 0x95, 0x27, 0x90, 0x65, 0x40, 0xea,
-	0x33, 0x5a, 0xc3, 0x01, 0xb5, 0x53, 0xc2, 0x19, 0xca, 0xe0, 0x10, 0x80, 0x36, 0xa1, 0x64, 0x10,
-	0xcb, 0x1e, 0x98, 0x16, 0xeb, 0xe7, 0x3b, 0x10, 0x05, 0x29, 0x7f, 0x99, 0xa1, 0xc1, 0xb9, 0x2c,
-	0xe5, 0xcb, 0xcc, 0x14, 0x8f, 0xd8, 0x78, 0x66, 0x2a, 0xc9, 0x6b, 0xaf, 0x42, 0x7e, 0x40, 0xbc,
-	0x40, 0xa4, 0x8a, 0x58, 0x36, 0xd1, 0x97, 0xcc, 0xa9, 0xd0, 0x5f, 0x09, 0x3f, 0xf1, 0xee, 0x39,
-	0xef, 0x08, 0xb6, 0x76, 0xed, 0xa3, 0x3d, 0x4e, 0x8a, 0x39, 0xe1, 0xc6, 0x8f, 0x01, 0x42, 0x20,
-	0x6a, 0xb0, 0x57, 0x1b, 0x3e, 0x39, 0x95, 0xef, 0x4c, 0x2e, 0xc2, 0x51, 0x7c, 0x07, 0x86, 0x25,
-	0x29, 0x95, 0x8c, 0x43, 0xdb, 0x1d, 0x68, 0x81, 0x17, 0xcf, 0x5b, 0x41, 0xd1, 0x36, 0x13, 0x16,
-	0x6d, 0x37, 0xfe, 0x34, 0xcd, 0x26, 0x20, 0x78, 0xd0, 0xab, 0xd9, 0xa7, 0x8e, 0x9e, 0xbc, 0x9a,
-	0xac, 0x41, 0x09, 0x0f, 0xcd, 0x7e, 0xb0, 0x29, 0xf4, 0x37, 0x85, 0xf5, 0x4d, 0x8b, 0xef, 0x48,
-	0x16, 0xb3, 0xdf, 0x74, 0xe0, 0x01, 0xf1, 0x8f, 0x6d, 0x99, 0xc2, 0x12, 0x2d, 0x2a, 0xe1, 0xc7,
-	0xb6, 0xe7, 0x47, 0xca, 0x8d, 0x41, 0x1b, 0xdd, 0x80, 0x12, 0xf5, 0xfa, 0x35, 0x23, 0x9a, 0xf5,
-	0x03, 0x0e, 0x62, 0xe5, 0xc8, 0xd8, 0x77, 0x69, 0xf9, 0x59, 0xbe, 0x4b, 0x8b, 0xec, 0x66, 0xe1,
-	0x8d, 0x77, 0x53, 0xf9, 0x75, 0x1a, 0xf2, 0x22, 0xa9, 0x90, 0x90, 0xab, 0x48, 0x25, 0xe5, 0x2a,
-	0x08, 0xac, 0x79, 0x43, 0x16, 0x48, 0x9a, 0x27, 0x44, 0x75, 0x89, 0xe7, 0xbb, 0x66, 0xf0, 0x90,
-	0x78, 0x8a, 0x35, 0xea, 0x06, 0x44, 0x38, 0x42, 0x83, 0x57, 0xbd, 0x44, 0x38, 0xba, 0x07, 0xc8,
-	0x20, 0x9e, 0xee, 0x9a, 0x6c, 0xf2, 0xf1, 0xec, 0xc9, 0x52, 0xa4, 0x47, 0xcc, 0x4a, 0x81, 0xb2,
-	0x41, 0xa8, 0xd6, 0x27, 0x96, 0x6e, 0x12, 0x1e, 0xdb, 0x14, 0x71, 0x0c, 0x86, 0x1e, 0xc2, 0xca,
-	0xe8, 0x97, 0xf1, 0x2a, 0x2b, 0xfe, 0xf3, 0x63, 0xbb, 0x3c, 0xf2, 0x75, 0x7c, 0xef, 0xcc, 0x21,
-	0xa8, 0x05, 0xf3, 0x9e, 0x43, 0x74, 0xf3, 0xd0, 0xd4, 0x35, 0xf1, 0xa9, 0xd6, 0xdc, 0xe4, 0x27,
-	0x52, 0xdd, 0x28, 0x2a, 0x8e, 0x53, 0x2a, 0xbf, 0x48, 0xc1, 0x6a, 0xf2, 0x26, 0xb0, 0xa7, 0x4e,
-	0x96, 0x76, 0xd0, 0x27, 0x3c, 0x58, 0x2c, 0x60, 0xd9, 0x44, 0x9f, 0xc2, 0xaa, 0xe3, 0x12, 0xf1,
-	0xef, 0x8e, 0xf8, 0x37, 0x15, 0x3c, 0xe8, 0x14, 0x96, 0x6e, 0x25, 0xd6, 0x8b, 0x45, 0x27, 0x7a,
-	0x02, 0x9b, 0x44, 0x73, 0xfb, 0x26, 0xf1, 0x7c, 0x55, 0xeb, 0xf7, 0xed, 0xd7, 0x34, 0xb6, 0x0d,
-	0x99, 0x04, 0x4f, 0x79, 0x8b, 0xf8, 0x9a, 0xc4, 0xab, 0x71, 0xb4, 0x5a, 0x80, 0x45, 0xc5, 0x4e,
-	0xf9, 0x0c, 0xe6, 0x63, 0x8b, 0x4a, 0x8c, 0xac, 0x97, 0x21, 0xcb, 0xf4, 0xbd, 0xb8, 0x43, 0xbc,
-	0xa1, 0xfc, 0x2a, 0x05, 0x48, 0x98, 0x46, 0x99, 0x5f, 0xc2, 0xe4, 0x70, 0xca, 0x73, 0x8d, 0x2a,
-	0xe4, 0x45, 0x62, 0x49, 0x7e, 0xd3, 0x27, 0x9a, 0xe3, 0xdf, 0xf4, 0x4d, 0xca, 0x1a, 0x66, 0xa6,
-	0x65, 0x0d, 0xb3, 0xb3, 0x64, 0x0d, 0x2f, 0xf6, 0x92, 0xec, 0xee, 0x2f, 0x53, 0x80, 0xf8, 0xf7,
-	0xd7, 0xe2, 0x5b, 0x03, 0xb3, 0x4f, 0xe3, 0xff, 0x2b, 0xb0, 0xb6, 0xbd, 0xdb, 0xa9, 0x3f, 0xc5,
-	0xcd, 0xe7, 0x4d, 0xdc, 0x6d, 0x6d, 0xb7, 0x76, 0x5b, 0xbd, 0x97, 0x6a, 0xbb, 0xd3, 0x6e, 0x56,
-	0x2e, 0xa1, 0x9b, 0x70, 0x2d, 0xa1, 0x53, 0xb6, 0x58, 0x69, 0xf8, 0x16, 0xdc, 0x48, 0x40, 0x69,
-	0xe1, 0x08, 0x52, 0x1a, 0x5d, 0x85, 0x6a, 0x02, 0x52, 0xb7, 0x57, 0xdb, 0x6d, 0xf2, 0xd2, 0x70,
-	0x42, 0xef, 0x5e, 0xed, 0xe5, 0x76, 0x93, 0xa3, 0x64, 0xee, 0xfe, 0x34, 0xfe, 0x8e, 0x5e, 0x7c,
-	0xc4, 0xb3, 0x01, 0xab, 0x3d, 0x5c, 0x6b, 0x77, 0x79, 0xed, 0xa7, 0xdb, 0xab, 0xf5, 0x9e, 0x75,
-	0xe5, 0xd4, 0xaf, 0xc3, 0xc6, 0x78, 0x5f, 0xf3, 0xeb, 0x66, 0xfd, 0x59, 0xaf, 0xd9, 0xe0, 0x25,
-	0xed, 0xf1, 0xfe, 0x6e, 0xe7, 0x71, 0xef, 0x71, 0xad, 0xb5, 0x5b, 0x49, 0x27, 0xf7, 0xef, 0xd4,
-	0x70, 0x83, 0xf5, 0xcf, 0xa1, 0x6b, 0xb0, 0x3e, 0xde, 0xdf, 0x68, 0xee, 0xd6, 0x5e, 0xb2, 0x5a,
-	0x76, 0x62, 0x77, 0xf3, 0xeb, 0xfd, 0x16, 0x6e, 0x36, 0x2a, 0xd9, 0xe4, 0x6e, 0x19, 0xe3, 0xe5,
-	0x92, 0x07, 0xe7, 0x89, 0xef, 0x66, 0xa3, 0x92, 0xdf, 0xae, 0x7d, 0xef, 0xd1, 0x91, 0xe9, 0x1f,
-	0x0f, 0x0f, 0xb6, 0x74, 0x7b, 0x70, 0x9f, 0x5d, 0xf0, 0x7b, 0xa6, 0x2d, 0x7e, 0xf0, 0xff, 0x29,
-	0xe8, 0x1c, 0xdc, 0x4f, 0xfa, 0x17, 0x83, 0xff, 0xcf, 0x39, 0x60, 0x3f, 0x0f, 0x72, 0x4c, 0xa8,
-	0x3e, 0xfe, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x1b, 0x9d, 0xfb, 0x3f, 0x89, 0x50, 0x00, 0x00,
+	// 6239 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x7c, 0x49, 0x6f, 0x24, 0x47,
+	0x76, 0x70, 0xd7, 0xca, 0xaa, 0x57, 0x45, 0xb2, 0x18, 0xcd, 0x25, 0x9b, 0xbd, 0x88, 0x9d, 0xda,
+	0x5a, 0xad, 0x11, 0x5b, 0x4d, 0x6d, 0xa3, 0xf9, 0xa4, 0x4f, 0x2a, 0xb2, 0xaa, 0x45, 0xaa, 0xc9,
+	0x22, 0x11, 0x64, 0x77, 0x4b, 0xf3, 0xcd, 0x7c, 0x89, 0x64, 0x66, 0x90, 0x4c, 0x75, 0x55, 0x66,
+	0x4e, 0x66, 0x16, 0x9b, 0x1c, 0x7c, 0x18, 0xe0, 0x83, 0x2f, 0x36, 0x30, 0x03, 0x03, 0x06, 0x0c,
+	0x03, 0xf6, 0xc1, 0x86, 0x31, 0x7f, 0xc0, 0x73, 0xb2, 0xc7, 0xf0, 0xd1, 0x80, 0xaf, 0x86, 0x4f,
+	0x3e, 0xd8, 0x06, 0xe6, 0x60, 0x7b, 0x7e, 0x80, 0xed, 0xab, 0xf1, 0x22, 0x22, 0xd7, 0xca, 0x2a,
+	0x92, 0x3d, 0xb2, 0xe1, 0x13, 0x2b, 0x5e, 0xbc, 0xf7, 0x62, 0x7b, 0xf1, 0xd6, 0x48, 0xc2, 0x8a,
+	0x79, 0x34, 0xf4, 0xd9, 0x03, 0xe6, 0xf8, 0x96, 0xf3, 0xc0, 0x70, 0x4c, 0x66, 0x3c, 0x38, 0x7d,
+	0x28, 0x7e, 0xac, 0xba, 0x9e, 0x13, 0x38, 0x64, 0x9e, 0x63, 0xac, 0x72, 0x8c, 0x55, 0xd1, 0x71,
+	0xfa, 0x70, 0xf9, 0x95, 0x63, 0xc7, 0x39, 0xee, 0xb3, 0x07, 0x1c, 0xe7, 0x70, 0x78, 0xf4, 0x20,
+	0xb0, 0x06, 0xcc, 0x0f, 0xf4, 0x81, 0x2b, 0xc8, 0xd4, 0xdf, 0x57, 0xa0, 0xb2, 0xde, 0x77, 0x8c,
+	0xe7, 0x64, 0x06, 0x8a, 0x96, 0xa9, 0x14, 0x56, 0x0a, 0xf7, 0xea, 0xb4, 0x68, 0x99, 0x64, 0x11,
+	0xaa, 0xf6, 0x70, 0x70, 0xc8, 0x3c, 0xa5, 0xb8, 0x52, 0xb8, 0x37, 0x4d, 0x65, 0x8b, 0x28, 0x30,
+	0x75, 0xca, 0x3c, 0xdf, 0x72, 0x6c, 0xa5, 0xc4, 0x3b, 0xc2, 0x26, 0xf9, 0x18, 0xaa, 0x27, 0x4c,
+	0x37, 0x99, 0xa7, 0x94, 0x57, 0x0a, 0xf7, 0x1a, 0x6b, 0x77, 0x57, 0xf3, 0xe6, 0xb4, 0xca, 0x87,
+	0xdb, 0xe4, 0x88, 0x54, 0x12, 0x90, 0x77, 0x80, 0xb8, 0x9e, 0x63, 0x0e, 0x0d, 0xe6, 0x69, 0xbe,
+	0x75, 0x6c, 0xeb, 0xc1, 0xd0, 0x63, 0x4a, 0x85, 0x4f, 0x66, 0x2e, 0xec, 0xd9, 0x0f, 0x3b, 0xc8,
+	0x97, 0xd0, 0x3a, 0x44, 0x2e, 0x1a, 0x3b, 0x0b, 0x98, 0x8d, 0x83, 0xfb, 0xca, 0xd4, 0x4a, 0xe9,
+	0x5e, 0x63, 0xed, 0x95, 0xfc, 0x31, 0xbb, 0x21, 0x1e, 0x9d, 0xe5, 0x84, 0x51, 0xdb, 0x27, 0x3b,
+	0xf0, 0xaa, 0xe9, 0x3a, 0xbe, 0xe6, 0x7a, 0x8e, 0xeb, 0xf8, 0xcc, 0xd4, 0x2c, 0xcf, 0x63, 0x7c,
+	0x49, 0x87, 0x7d, 0xa6, 0x71, 0x6c, 0x7b, 0x38, 0x50, 0x6a, 0x7c, 0xad, 0x2b, 0x88, 0xba, 0x27,
+	0x31, 0xb7, 0x12, 0x88, 0xeb, 0x12, 0x8f, 0x7c, 0x02, 0xcb, 0x9c, 0x5d, 0x3e, 0x97, 0x3a, 0xe7,
+	0xa2, 0x20, 0x46, 0x2e, 0xf5, 0x9e, 0x5c, 0x98, 0xe7, 0x38, 0x81, 0x36, 0x60, 0xde, 0xf3, 0x3e,
+	0x53, 0x1a, 0x7c, 0x33, 0x5f, 0x9f, 0xb0, 0x99, 0xd4, 0x71, 0x82, 0x1d, 0x8e, 0x4c, 0x67, 0x23,
+	0x72, 0x01, 0x20, 0xc7, 0x70, 0x23, 0xda, 0xd9, 0xc0, 0xd1, 0xfa, 0xba, 0x1f, 0x68, 0x12, 0x60,
+	0x2a, 0x4d, 0xbe, 0x67, 0xdf, 0xc9, 0x67, 0xbd, 0x27, 0xc9, 0x0e, 0x9c, 0x6d, 0xdd, 0x0f, 0x64,
+	0xcb, 0xa4, 0x8b, 0x6e, 0x2e, 0x9c, 0xd8, 0x70, 0x6b, 0x64, 0x20, 0x6b, 0xe0, 0xf6, 0x2d, 0xbe,
+	0xa5, 0x87, 0xca, 0x34, 0x1f, 0x6b, 0xf5, 0x32, 0x63, 0x6d, 0x09, 0xb2, 0x2d, 0xba, 0x4e, 0x15,
+	0x37, 0xb7, 0xc7, 0x3b, 0x24, 0xaf, 0xc2, 0xb4, 0xe1, 0xd8, 0x47, 0x96, 0x37, 0xd0, 0x0c, 0x67,
+	0x68, 0x07, 0xca, 0xec, 0x4a, 0xe9, 0xde, 0x34, 0x6d, 0x4a, 0xe0, 0x06, 0xc2, 0xc8, 0x57, 0xd0,
+	0x72, 0x99, 0x6d, 0x5a, 0xf6, 0xb1, 0xe6, 0x1b, 0x27, 0xcc, 0x1c, 0xf6, 0x99, 0xd2, 0xe2, 0xfb,
+	0xf9, 0xce, 0x98, 0x89, 0x08, 0xec, 0x70, 0x3e, 0xfb, 0x92, 0x88, 0xce, 0x4a, 0x36, 0x21, 0x80,
+	0x38, 0x70, 0x53, 0x37, 0x02, 0xeb, 0x54, 0x0f, 0x98, 0xa9, 0xf1, 0xbb, 0x64, 0x38, 0x7d, 0xed,
+	0x88, 0x71, 0x01, 0xf5, 0x95, 0x39, 0x3e, 0xc8, 0x83, 0xfc, 0x41, 0xda, 0x21, 0xe1, 0x9e, 0xa4,
+	0x7b, 0x24, 0xc9, 0xe8, 0x0d, 0x7d, 0x5c, 0x17, 0xb9, 0x05, 0xf5, 0x53, 0xbd, 0x6f, 0x99, 0xd8,
+	0xa9, 0x90, 0x95, 0xc2, 0xbd, 0x1a, 0x8d, 0x01, 0xe4, 0x53, 0x00, 0xaf, 0x6f, 0x0d, 0xac, 0x40,
+	0x73, 0x5c, 0x5f, 0xb9, 0xce, 0xf7, 0xfa, 0x4e, 0xfe, 0xe8, 0x94, 0xe3, 0xed, 0xba, 0xb4, 0xee,
+	0xc9, 0x5f, 0x3e, 0xd1, 0x61, 0x69, 0x68, 0x1f, 0x59, 0xfd, 0x80, 0x79, 0xcc, 0xd4, 0x02, 0x4f,
+	0xb7, 0x7d, 0x9c, 0x09, 0xde, 0xab, 0x2a, 0xe7, 0x75, 0x2f, 0x9f, 0xd7, 0x41, 0x8c, 0x49, 0x99,
+	0xc1, 0x2c, 0x37, 0xa0, 0x8b, 0x31, 0xa3, 0x44, 0xaf, 0x4f, 0x7e, 0x08, 0x0b, 0xf9, 0x03, 0x3c,
+	0xb8, 0xe2, 0x00, 0xf3, 0xb9, 0xec, 0x3f, 0x87, 0x5b, 0xf9, 0x2b, 0x90, 0xd2, 0xb1, 0xc8, 0x6f,
+	0xde, 0x72, 0xee, 0xe4, 0x84, 0xac, 0x7c, 0x02, 0xcb, 0x13, 0xe8, 0xdf, 0x15, 0x37, 0x77, 0x2c,
+	0xf5, 0x37, 0xf0, 0x6a, 0x62, 0x7c, 0x2e, 0xf8, 0x86, 0x15, 0xa4, 0x18, 0xe1, 0xc9, 0xcc, 0xf3,
+	0xc5, 0xde, 0x1c, 0xb7, 0xd8, 0xb3, 0x5d, 0x97, 0xae, 0xc4, 0x7c, 0xb6, 0x24, 0x9b, 0xc4, 0x68,
+	0x78, 0x5a, 0x47, 0x70, 0xf7, 0xe2, 0x91, 0x1e, 0x5e, 0x3c, 0xd2, 0x9d, 0x0b, 0xc6, 0xf9, 0x06,
+	0x6e, 0x8f, 0xd9, 0xd3, 0xc0, 0xd3, 0x0d, 0xe6, 0x2b, 0x0b, 0x7c, 0x8c, 0x37, 0x2e, 0x3c, 0xba,
+	0x03, 0x44, 0xa7, 0x37, 0x73, 0x37, 0x9f, 0xf7, 0xe1, 0x9a, 0x6e, 0x4e, 0x1a, 0x69, 0xf5, 0x4a,
+	0x23, 0xdd, 0x18, 0x3f, 0xce, 0x63, 0x50, 0x27, 0xad, 0x49, 0x9e, 0xf6, 0x12, 0x3f, 0xed, 0x57,
+	0xc6, 0x4f, 0x58, 0x1c, 0xfa, 0x17, 0xb0, 0x72, 0x21, 0xab, 0xb7, 0x39, 0xab, 0xdb, 0x93, 0x19,
+	0x51, 0x78, 0x23, 0x31, 0x2b, 0x76, 0xc6, 0x8c, 0x21, 0xea, 0x15, 0xcb, 0x76, 0x87, 0x81, 0x96,
+	0x92, 0x43, 0x85, 0xb3, 0x4b, 0xac, 0xa1, 0x2b, 0x91, 0xb7, 0x10, 0xb7, 0x9d, 0x90, 0xc8, 0x1e,
+	0xbc, 0x76, 0x29, 0x8e, 0xdf, 0x11, 0x96, 0xed, 0x42, 0x7e, 0x63, 0xe6, 0x18, 0x38, 0x81, 0xde,
+	0x4f, 0x73, 0xbc, 0x31, 0x6e, 0x8e, 0x07, 0x88, 0x7b, 0xe1, 0x1c, 0x73, 0x38, 0xbe, 0x93, 0x3f,
+	0xc7, 0x11, 0x7e, 0xf7, 0x61, 0x4e, 0x38, 0x06, 0xe8, 0x44, 0xa0, 0xd6, 0x7f, 0xce, 0xce, 0x95,
+	0x19, 0xee, 0x46, 0x08, 0xcb, 0xb8, 0x2f, 0xe0, 0x8f, 0xd9, 0x39, 0x39, 0x00, 0xc2, 0xb5, 0x2d,
+	0x8b, 0x4c, 0x83, 0x76, 0xfa, 0x50, 0x81, 0x95, 0xc2, 0x78, 0x41, 0x1b, 0x31, 0x0b, 0x2d, 0xc1,
+	0x21, 0x6c, 0x3f, 0x7d, 0x48, 0x7c, 0x58, 0xe1, 0x5a, 0x59, 0x4b, 0xcf, 0x43, 0x1f, 0x06, 0x27,
+	0x8e, 0x67, 0x05, 0xe7, 0xda, 0xe9, 0x9a, 0x72, 0x87, 0x8f, 0xf1, 0xf6, 0x04, 0x8b, 0x2e, 0xa7,
+	0xd9, 0x0e, 0xa9, 0xe8, 0x2d, 0xce, 0x34, 0xb7, 0xef, 0xe9, 0x1a, 0xf9, 0x61, 0xce, 0x52, 0xd6,
+	0x94, 0x57, 0x26, 0xd9, 0xa0, 0x70, 0x29, 0x11, 0x9b, 0xb1, 0x6b, 0x5a, 0x23, 0x6f, 0xc3, 0x9c,
+	0xd8, 0x79, 0xbe, 0x12, 0x97, 0x9b, 0x60, 0xe5, 0x1e, 0x37, 0x41, 0xad, 0xa8, 0xa3, 0x2d, 0xe0,
+	0xa4, 0x0d, 0xb7, 0x63, 0x64, 0xcb, 0x36, 0xfa, 0x43, 0x93, 0x69, 0x02, 0xa2, 0xb1, 0x33, 0xd7,
+	0x53, 0xde, 0xe2, 0xc7, 0xb1, 0x1c, 0x21, 0x6d, 0x09, 0x9c, 0x47, 0xbc, 0xdd, 0x3d, 0x73, 0xbd,
+	0x34, 0x0b, 0x76, 0x36, 0xca, 0xe2, 0x7e, 0x86, 0x45, 0xf7, 0x2c, 0xcb, 0xe2, 0x03, 0x58, 0x32,
+	0x19, 0x73, 0xb5, 0x81, 0x65, 0x9b, 0xda, 0x40, 0xff, 0xc6, 0xf1, 0xb4, 0xd0, 0x6b, 0x5d, 0xe3,
+	0xb2, 0x34, 0x8f, 0xdd, 0x3b, 0x96, 0x6d, 0xee, 0x60, 0xe7, 0x53, 0xd1, 0x97, 0x21, 0xb3, 0xec,
+	0x04, 0xd9, 0x7b, 0x19, 0x32, 0xcb, 0x8e, 0xc9, 0xee, 0xc3, 0x9c, 0xed, 0x98, 0xcc, 0xf1, 0xb5,
+	0xc3, 0xa1, 0xd5, 0xc7, 0x5b, 0x76, 0xe4, 0x28, 0xef, 0x0b, 0xb1, 0x13, 0x1d, 0xeb, 0x08, 0xdf,
+	0xb2, 0x8f, 0x1c, 0xf2, 0x16, 0xb4, 0x2c, 0xfb, 0x98, 0xf9, 0x5c, 0xba, 0x7d, 0x67, 0xe8, 0x19,
+	0x4c, 0xf9, 0x40, 0xa0, 0x46, 0xf0, 0x7d, 0x0e, 0x46, 0xaf, 0x78, 0x68, 0xeb, 0xa7, 0xba, 0xd5,
+	0xd7, 0xd1, 0x8b, 0x3c, 0xb2, 0x58, 0xdf, 0xf4, 0x95, 0x0f, 0x57, 0x4a, 0xe8, 0x15, 0x27, 0x7a,
+	0x1e, 0xf1, 0x0e, 0xf2, 0x25, 0x5c, 0x1f, 0xda, 0x7a, 0x10, 0x78, 0xd6, 0x21, 0xbf, 0x47, 0xe6,
+	0x21, 0x37, 0x04, 0x1f, 0x71, 0xd5, 0xb9, 0x9c, 0x2f, 0x06, 0x9d, 0xf5, 0x5d, 0x97, 0xce, 0x25,
+	0xc9, 0x3a, 0x87, 0xbb, 0xae, 0xaf, 0xfe, 0x76, 0x09, 0xa6, 0xb9, 0xb4, 0x3d, 0xb3, 0x82, 0x13,
+	0xca, 0x8e, 0xfc, 0x91, 0xf8, 0xe0, 0x21, 0x54, 0xb8, 0x88, 0xf3, 0xf0, 0x60, 0xac, 0xa1, 0xe1,
+	0x3c, 0xa8, 0xc0, 0x24, 0x3a, 0xdc, 0xc8, 0x35, 0x57, 0x1e, 0x3b, 0xf2, 0x95, 0xd2, 0x24, 0x37,
+	0x37, 0xe5, 0x06, 0x1c, 0xf9, 0x74, 0xc9, 0x1a, 0xb5, 0x58, 0x7c, 0x96, 0x7b, 0xd0, 0x1a, 0xe1,
+	0x5c, 0xbe, 0x0a, 0xe7, 0xd9, 0x20, 0xc3, 0xf1, 0xff, 0xc0, 0xe2, 0xa8, 0x6a, 0xe7, 0x7c, 0x2b,
+	0x57, 0xe1, 0x3b, 0x1f, 0x64, 0x8d, 0x14, 0x32, 0x57, 0xa1, 0x99, 0x0c, 0x14, 0x94, 0x2a, 0xbf,
+	0x54, 0x29, 0x98, 0xfa, 0x16, 0xcc, 0x66, 0x57, 0xb9, 0x08, 0xd5, 0x13, 0xdd, 0x3f, 0x61, 0xbe,
+	0x52, 0x58, 0x29, 0xdd, 0x6b, 0x52, 0xd9, 0x52, 0x37, 0xe1, 0xc6, 0x58, 0xdf, 0x12, 0x6f, 0xf1,
+	0xa8, 0x9f, 0x2a, 0xe8, 0x5b, 0x6e, 0x06, 0x59, 0xfd, 0xad, 0x22, 0x2c, 0x8d, 0xf1, 0x85, 0xc9,
+	0x3d, 0x68, 0x45, 0x6a, 0xa6, 0x6f, 0x1d, 0x6a, 0x18, 0xd8, 0x14, 0xf8, 0xed, 0x98, 0x09, 0xe1,
+	0xdb, 0xd6, 0x61, 0x6f, 0x38, 0x40, 0x1f, 0x3d, 0xc2, 0xc4, 0x29, 0x72, 0x59, 0x69, 0xd2, 0x66,
+	0x08, 0xdc, 0xd4, 0xfd, 0x13, 0xf2, 0x05, 0x34, 0x92, 0x0a, 0xb8, 0x74, 0x25, 0x05, 0x0c, 0x7e,
+	0xac, 0x7a, 0xf7, 0x92, 0x8c, 0xd6, 0x94, 0xf2, 0xcb, 0xa9, 0xbf, 0x98, 0xe3, 0x9a, 0x3a, 0x80,
+	0xd6, 0xc8, 0xea, 0x13, 0xf1, 0x6f, 0x21, 0x1d, 0xff, 0x7e, 0x06, 0xf5, 0x30, 0x5a, 0xf1, 0x95,
+	0xe2, 0x4a, 0x69, 0x7c, 0x08, 0x1c, 0x32, 0x7d, 0xcc, 0xce, 0x69, 0x4c, 0xa3, 0xfe, 0x00, 0x1a,
+	0x89, 0x1e, 0x72, 0x17, 0x9a, 0xba, 0xc1, 0xed, 0x9f, 0x66, 0xeb, 0x03, 0x26, 0xef, 0x5e, 0x43,
+	0xc2, 0x7a, 0xfa, 0x80, 0xe5, 0xdb, 0xbb, 0x62, 0xae, 0xbd, 0x53, 0xff, 0x1f, 0xdc, 0x18, 0xbb,
+	0xea, 0x09, 0xab, 0xea, 0x8e, 0xae, 0xea, 0xcd, 0x4b, 0xee, 0x69, 0x72, 0x6d, 0x7f, 0x54, 0x80,
+	0xb9, 0x11, 0x84, 0xcb, 0x2c, 0xd1, 0x80, 0xa5, 0x31, 0xa6, 0x54, 0x29, 0x5e, 0xdd, 0x8e, 0x2e,
+	0x1c, 0xe6, 0x81, 0x55, 0x03, 0x16, 0x72, 0xf1, 0xc9, 0x67, 0x50, 0x3c, 0x7d, 0x57, 0x29, 0x4c,
+	0x0a, 0x19, 0xf3, 0x8d, 0xf2, 0xbb, 0x9b, 0xd7, 0x68, 0xf1, 0xf4, 0xdd, 0xf5, 0x3a, 0x4c, 0x9d,
+	0xea, 0x9e, 0xa5, 0xdb, 0x81, 0xda, 0x87, 0xa5, 0x31, 0xb8, 0x18, 0xdc, 0x05, 0x27, 0x1e, 0xf3,
+	0x4f, 0x9c, 0xbe, 0x29, 0x0f, 0x20, 0x06, 0x90, 0xf7, 0xa0, 0xfc, 0x9c, 0x9d, 0x87, 0xbb, 0x3f,
+	0x26, 0xc5, 0xf1, 0x98, 0x9d, 0x3f, 0x63, 0xd6, 0xf1, 0x49, 0x40, 0x39, 0xb2, 0xba, 0x0f, 0xb3,
+	0x99, 0xe4, 0x00, 0xb9, 0x0d, 0x80, 0xd6, 0x48, 0xfa, 0x54, 0x72, 0x18, 0x84, 0x08, 0xe7, 0x89,
+	0x1f, 0x06, 0xf7, 0x22, 0x10, 0x26, 0x86, 0x6b, 0xd2, 0x86, 0x80, 0xf5, 0x10, 0xa4, 0x1a, 0xb0,
+	0x98, 0x9f, 0x16, 0x20, 0x04, 0xca, 0x89, 0x13, 0xe4, 0xbf, 0xd1, 0x9a, 0xf2, 0x34, 0x80, 0x38,
+	0x3f, 0x7b, 0x38, 0x88, 0x33, 0x0f, 0x22, 0xa7, 0x34, 0x8f, 0xdd, 0x7c, 0x96, 0xbd, 0xe1, 0x20,
+	0x64, 0xa5, 0x32, 0x50, 0xc6, 0xe5, 0x03, 0xbe, 0xcd, 0x61, 0x7e, 0x51, 0x04, 0x32, 0x1a, 0x5e,
+	0x4a, 0x3b, 0x57, 0x8e, 0xec, 0xdc, 0x3c, 0x54, 0x2c, 0xdb, 0x64, 0x67, 0x5c, 0x37, 0x97, 0xa9,
+	0x68, 0x90, 0xcf, 0xa0, 0xea, 0x07, 0x7a, 0x30, 0xf4, 0xf9, 0x4c, 0x66, 0xc6, 0x5d, 0x89, 0x04,
+	0xff, 0x7d, 0x8e, 0x4e, 0x25, 0x19, 0x4e, 0xda, 0x70, 0x87, 0xda, 0xd0, 0xd7, 0x8f, 0x99, 0x36,
+	0xb0, 0x0c, 0xcf, 0xd1, 0x7c, 0x66, 0x38, 0xb6, 0xe9, 0x87, 0x93, 0x36, 0xdc, 0xe1, 0x13, 0xec,
+	0xdd, 0xc1, 0xce, 0x7d, 0xd1, 0x47, 0xde, 0x80, 0x59, 0x9b, 0x05, 0x92, 0xec, 0x85, 0xe3, 0x99,
+	0xbe, 0xcc, 0xc2, 0x4d, 0xdb, 0x2c, 0xe0, 0xe8, 0xcf, 0x10, 0x48, 0x9e, 0x02, 0x71, 0x75, 0xe3,
+	0x79, 0x3a, 0x2e, 0x91, 0x16, 0x6b, 0xdc, 0xf5, 0xe5, 0xf8, 0xc9, 0x1d, 0x99, 0x73, 0xb3, 0x20,
+	0xf5, 0xaf, 0xf0, 0x1a, 0x67, 0xa1, 0xe4, 0x0e, 0x40, 0x94, 0xb5, 0x13, 0x36, 0xa5, 0x4e, 0x13,
+	0x10, 0xb2, 0x02, 0x0d, 0xc3, 0x19, 0xb8, 0x1e, 0xf3, 0xb9, 0x86, 0x11, 0x0b, 0x4c, 0x82, 0xc8,
+	0x47, 0xa0, 0xc8, 0xf9, 0x1a, 0x8e, 0x1d, 0xb0, 0xb3, 0x40, 0x3b, 0xf2, 0x18, 0xd3, 0x4c, 0x3d,
+	0xd0, 0xf9, 0x02, 0x9b, 0x74, 0x41, 0xf4, 0x6f, 0x88, 0xee, 0x47, 0x1e, 0x63, 0x1d, 0x3d, 0xd0,
+	0x79, 0xe6, 0x70, 0x74, 0xa1, 0x65, 0x4e, 0x92, 0x33, 0xff, 0x3f, 0x2f, 0x41, 0x23, 0x91, 0x80,
+	0x24, 0xdf, 0x85, 0x7a, 0x94, 0x12, 0x95, 0xa6, 0x67, 0x79, 0x55, 0x24, 0x4d, 0x57, 0xc3, 0xa4,
+	0xe9, 0xea, 0x41, 0x88, 0x41, 0x63, 0x64, 0xb2, 0x0c, 0xb5, 0x50, 0xbb, 0x49, 0x69, 0x89, 0xda,
+	0x78, 0x9d, 0x65, 0x1a, 0x8a, 0x99, 0x7c, 0xd3, 0xa7, 0x69, 0x0c, 0x10, 0x94, 0xec, 0xd4, 0x72,
+	0x86, 0xbe, 0x52, 0x0d, 0x29, 0x45, 0x1b, 0x8d, 0x74, 0xd2, 0xdb, 0x18, 0x78, 0x8e, 0x13, 0x28,
+	0x53, 0x7c, 0x35, 0x49, 0xc7, 0x66, 0x07, 0xe1, 0xe1, 0x85, 0x8d, 0xf0, 0x6a, 0x2b, 0x85, 0xf0,
+	0xc2, 0x86, 0x28, 0x6f, 0x25, 0x6c, 0x75, 0xa8, 0xe0, 0x45, 0x12, 0x72, 0x36, 0xb2, 0x73, 0x02,
+	0x4c, 0xb6, 0x61, 0x4e, 0x64, 0x63, 0x93, 0x59, 0xd5, 0xc6, 0xe5, 0xb2, 0xaa, 0x2d, 0x41, 0x99,
+	0x48, 0xab, 0xee, 0x41, 0xcb, 0x66, 0x2f, 0xb4, 0xc8, 0x00, 0x5c, 0x3d, 0xb6, 0x9a, 0xb1, 0xd9,
+	0x8b, 0x10, 0xe8, 0x3f, 0x7d, 0xa8, 0xfe, 0x0e, 0x40, 0x2b, 0x71, 0x94, 0xdd, 0x53, 0x66, 0x07,
+	0x23, 0x5e, 0xe9, 0x0d, 0xa8, 0x09, 0x35, 0x60, 0x99, 0xd2, 0x0e, 0x4e, 0xf1, 0xf6, 0x96, 0x49,
+	0x6e, 0x42, 0x3d, 0xd2, 0x10, 0xf2, 0xd2, 0x08, 0x5c, 0xf4, 0x54, 0xb2, 0x8e, 0x58, 0x79, 0xd4,
+	0x11, 0x23, 0x0c, 0xe6, 0x2c, 0x3b, 0x60, 0x9e, 0x8d, 0xd1, 0xa9, 0x69, 0x5a, 0x89, 0x2b, 0xf5,
+	0xe1, 0x85, 0xd7, 0x9f, 0x4f, 0x77, 0xb5, 0x6d, 0x9a, 0x18, 0x59, 0x0b, 0x26, 0xfd, 0xf3, 0xcd,
+	0x6b, 0xb4, 0x15, 0xb2, 0x6c, 0x4b, 0x8e, 0xe4, 0x4b, 0xa8, 0x45, 0xdc, 0xab, 0x2b, 0x85, 0xf1,
+	0x09, 0xda, 0x7c, 0xee, 0x9b, 0xd7, 0x68, 0x44, 0x4f, 0x76, 0xa1, 0x2e, 0xc2, 0x6a, 0x64, 0x36,
+	0x35, 0xc9, 0x21, 0x1a, 0x61, 0x16, 0xc6, 0xd8, 0x9b, 0xd7, 0x68, 0xcc, 0x83, 0x68, 0x30, 0x6b,
+	0x06, 0xde, 0x59, 0x18, 0x67, 0x5a, 0xf6, 0x31, 0x97, 0xba, 0xc6, 0xda, 0xfb, 0x97, 0x64, 0xdb,
+	0x09, 0xbc, 0xb3, 0xf0, 0x88, 0x91, 0xf7, 0x8c, 0x19, 0x03, 0x2c, 0xfb, 0x98, 0x1c, 0xc2, 0x1c,
+	0x1f, 0xc0, 0xd0, 0x6d, 0x83, 0xf5, 0xfb, 0x7a, 0x10, 0x4a, 0x6c, 0x63, 0xed, 0xbd, 0x2b, 0x0c,
+	0xb1, 0xc1, 0xc9, 0xf9, 0x08, 0x2d, 0x33, 0x6a, 0x0b, 0x76, 0xcb, 0x3f, 0x80, 0xd9, 0xcc, 0x41,
+	0x90, 0x2d, 0x68, 0x24, 0xf5, 0x47, 0x61, 0x92, 0xa2, 0x44, 0xfb, 0x9d, 0x56, 0x94, 0x49, 0xda,
+	0xe5, 0xbf, 0x2f, 0x40, 0x85, 0xb3, 0x27, 0xeb, 0x30, 0xe5, 0x09, 0xab, 0x22, 0x19, 0x5e, 0x3e,
+	0xc9, 0x19, 0x12, 0x66, 0x27, 0x56, 0x7c, 0xf9, 0x89, 0x91, 0x36, 0x34, 0xdc, 0xe1, 0x61, 0xdf,
+	0x32, 0x34, 0xee, 0x4d, 0x08, 0x6d, 0xb7, 0x32, 0xe6, 0x36, 0x72, 0xc4, 0xc7, 0xec, 0xdc, 0xa7,
+	0xe0, 0x46, 0xbf, 0x97, 0x7f, 0x56, 0x80, 0x5a, 0x28, 0x18, 0xe4, 0x13, 0xa8, 0xf0, 0x68, 0x48,
+	0x29, 0x4c, 0xba, 0xd7, 0x23, 0xc9, 0x39, 0x41, 0x44, 0x36, 0xa0, 0x71, 0x18, 0x2b, 0x62, 0xb9,
+	0xb0, 0x4b, 0x94, 0x8c, 0x92, 0x54, 0xcb, 0x7f, 0x58, 0x80, 0xe9, 0x94, 0x44, 0x91, 0xff, 0x0d,
+	0x60, 0x78, 0x8c, 0x67, 0xe5, 0x0f, 0xcf, 0xe5, 0xcc, 0xc6, 0xab, 0xaf, 0x8e, 0x48, 0x84, 0xd6,
+	0x25, 0xc9, 0xfa, 0xf9, 0xb7, 0xb8, 0xdf, 0xcb, 0x7b, 0xd0, 0x4c, 0x8a, 0x22, 0xf9, 0x1c, 0x1a,
+	0x86, 0xfc, 0x7d, 0x85, 0xb9, 0x41, 0x48, 0xb3, 0x7e, 0xbe, 0x3e, 0x05, 0x15, 0x86, 0x22, 0xae,
+	0xbe, 0x03, 0x10, 0x9f, 0x10, 0x79, 0x25, 0x7d, 0xb0, 0xd2, 0xfe, 0xc6, 0xc7, 0xa6, 0xfe, 0xbc,
+	0x0a, 0xf3, 0x89, 0x69, 0x6e, 0x5b, 0x47, 0xcc, 0x38, 0x37, 0xfa, 0x6c, 0x44, 0x7d, 0x3e, 0x05,
+	0x92, 0x34, 0x3f, 0xd2, 0xc5, 0x29, 0x5e, 0xcd, 0xc5, 0x99, 0x0b, 0xb2, 0x20, 0xf2, 0x35, 0x5c,
+	0x4f, 0x87, 0xe5, 0xe2, 0x56, 0xbc, 0x76, 0xc5, 0x5b, 0x41, 0x82, 0x11, 0x58, 0xf6, 0xc0, 0xe0,
+	0x37, 0xb8, 0x20, 0x99, 0x7d, 0xbc, 0x9e, 0xdd, 0x47, 0xb2, 0x0b, 0xb3, 0x91, 0x2a, 0x14, 0x99,
+	0x00, 0xa5, 0x71, 0x25, 0xd9, 0x9f, 0x89, 0xc8, 0x79, 0x9b, 0x3c, 0x83, 0xc5, 0x98, 0xa1, 0xb0,
+	0x4e, 0xb2, 0x84, 0xda, 0xbc, 0xec, 0x7d, 0x98, 0x8f, 0x18, 0x24, 0xa0, 0x99, 0x6b, 0x30, 0x7f,
+	0xe5, 0x6b, 0x90, 0x91, 0xd5, 0x85, 0x2b, 0xcb, 0x2a, 0x79, 0x0f, 0x16, 0x38, 0x3b, 0x5c, 0x59,
+	0xca, 0xb4, 0xde, 0xe5, 0xa6, 0x75, 0x3e, 0xec, 0x4c, 0xd6, 0x41, 0xc9, 0x07, 0xc9, 0xfd, 0x48,
+	0x51, 0xa9, 0x9c, 0x6a, 0x21, 0xea, 0x4d, 0x91, 0x7d, 0x0c, 0x8a, 0x18, 0x39, 0x67, 0xb8, 0x57,
+	0x39, 0xe1, 0x52, 0xa2, 0x3f, 0x49, 0xfa, 0x65, 0xb9, 0x36, 0xdd, 0xba, 0xfe, 0x65, 0xb9, 0xb6,
+	0xd8, 0xba, 0xab, 0xfe, 0xbc, 0x00, 0x73, 0x23, 0x22, 0x82, 0x8a, 0x6a, 0xd4, 0x34, 0xdc, 0xbd,
+	0x58, 0x66, 0x1b, 0xc1, 0x58, 0x0f, 0xb9, 0x38, 0xe2, 0x21, 0xdf, 0x87, 0xb9, 0x3c, 0xc7, 0x17,
+	0x03, 0xb0, 0x59, 0x23, 0xed, 0xf2, 0xaa, 0x7f, 0x50, 0x84, 0x46, 0x72, 0x82, 0x9f, 0x45, 0x75,
+	0xf7, 0x89, 0x66, 0x2b, 0x41, 0x92, 0xa9, 0xbe, 0xf7, 0x60, 0x3e, 0x35, 0x78, 0x58, 0x99, 0x13,
+	0xf1, 0xe6, 0xad, 0xf1, 0x45, 0x4c, 0xc7, 0xa6, 0x24, 0x31, 0x3b, 0x01, 0xf2, 0xc9, 0x87, 0x30,
+	0x15, 0xb2, 0x28, 0x5d, 0x82, 0x45, 0x88, 0x4c, 0x3e, 0x03, 0x48, 0xb8, 0x9e, 0xe5, 0xcb, 0xb9,
+	0x9e, 0x09, 0x12, 0xf5, 0xf7, 0x8a, 0x30, 0x37, 0xb2, 0x4c, 0xf2, 0x3d, 0x64, 0xeb, 0x5a, 0x9e,
+	0x9e, 0x38, 0xbf, 0x49, 0x4e, 0x7e, 0x02, 0x9b, 0xa8, 0x30, 0xed, 0xb1, 0xa3, 0x38, 0xb4, 0x0c,
+	0x63, 0x17, 0x8f, 0x1d, 0x85, 0x01, 0x25, 0xe6, 0xc3, 0x62, 0x1c, 0xd7, 0x63, 0x47, 0xd6, 0x99,
+	0xf4, 0x2f, 0x67, 0x42, 0xb4, 0x3d, 0x0e, 0x25, 0xef, 0xc0, 0xf5, 0x81, 0x7e, 0xa6, 0x65, 0x23,
+	0xb8, 0x32, 0x47, 0x6e, 0x0d, 0xf4, 0xb3, 0x5e, 0x2a, 0x88, 0x7b, 0x13, 0x10, 0xa6, 0x25, 0xe2,
+	0x44, 0x5f, 0x46, 0x13, 0xd3, 0x03, 0xfd, 0x6c, 0x23, 0x8c, 0x0f, 0x7d, 0x74, 0x6d, 0x4d, 0xd6,
+	0xd7, 0xcf, 0x31, 0x84, 0xe4, 0x3e, 0xe3, 0x34, 0xad, 0x71, 0xc0, 0x3e, 0x33, 0xd4, 0xdf, 0xad,
+	0xa7, 0xfc, 0x66, 0xa1, 0x78, 0xb2, 0x8a, 0x3f, 0xe5, 0x1c, 0x17, 0x79, 0xa4, 0x1b, 0x3b, 0xc7,
+	0x51, 0x08, 0xbc, 0x9c, 0x0c, 0x81, 0x3f, 0x06, 0x10, 0x24, 0x18, 0x13, 0x5d, 0x26, 0x76, 0xe2,
+	0xd8, 0xd8, 0x46, 0x69, 0x8f, 0xde, 0x0a, 0x44, 0xee, 0xba, 0x08, 0xa2, 0x66, 0xc3, 0x8e, 0x75,
+	0xe9, 0xb6, 0x6f, 0xc6, 0x4e, 0x94, 0xf0, 0xb5, 0x57, 0x2f, 0x6b, 0x2e, 0xa4, 0x94, 0x87, 0xe4,
+	0x98, 0xe3, 0x62, 0x7d, 0xdd, 0xf5, 0x99, 0xc9, 0xf7, 0xa8, 0x44, 0xc3, 0x26, 0xae, 0x3e, 0x3a,
+	0x13, 0xee, 0x26, 0x97, 0x69, 0x2d, 0x8c, 0xa7, 0x31, 0x98, 0x0b, 0x43, 0x25, 0x93, 0x3b, 0xbb,
+	0x35, 0x1a, 0x03, 0xc8, 0x23, 0x98, 0x4e, 0x57, 0x2a, 0xeb, 0x93, 0x12, 0x7f, 0xed, 0x84, 0x2d,
+	0x68, 0xa6, 0xea, 0x92, 0x14, 0xe6, 0x8e, 0x74, 0x0b, 0xd5, 0x2d, 0x77, 0x7f, 0x85, 0x71, 0x81,
+	0x2b, 0x19, 0x97, 0x59, 0xc1, 0x00, 0x7d, 0x0e, 0x71, 0xc8, 0x9f, 0xa2, 0xf7, 0x6f, 0x30, 0x97,
+	0xcb, 0xfd, 0xec, 0x64, 0x15, 0x2e, 0xd1, 0x68, 0x4c, 0x81, 0xe9, 0x22, 0xe6, 0x79, 0x8e, 0xa7,
+	0x21, 0x1a, 0x7f, 0x36, 0x51, 0xa6, 0x75, 0x0e, 0xd9, 0x70, 0x4c, 0x46, 0x1e, 0x42, 0x55, 0x56,
+	0x18, 0xe6, 0x2e, 0xac, 0x30, 0x54, 0x4c, 0xac, 0x2a, 0x90, 0x8f, 0xa0, 0xc6, 0x57, 0x87, 0x44,
+	0x64, 0x92, 0x66, 0x90, 0xf6, 0x64, 0x0a, 0xb1, 0x91, 0xf0, 0x73, 0x68, 0xc8, 0x94, 0x75, 0xe2,
+	0x7d, 0xc3, 0x98, 0xb5, 0xc8, 0x1c, 0x36, 0x9a, 0xa3, 0xa3, 0xf0, 0x27, 0x1f, 0xda, 0x65, 0xde,
+	0x20, 0x51, 0x84, 0xbf, 0x35, 0xee, 0x05, 0x88, 0x37, 0xc0, 0xa1, 0x5d, 0xfe, 0xd7, 0x27, 0xef,
+	0xc3, 0x94, 0xa7, 0x0b, 0xba, 0x85, 0x49, 0x25, 0x75, 0xda, 0xde, 0xd9, 0x75, 0x69, 0xd5, 0xd3,
+	0x39, 0xd5, 0x3e, 0x10, 0xa4, 0x32, 0x1c, 0xcf, 0x63, 0x71, 0x4d, 0x7e, 0x71, 0xa5, 0x34, 0xbe,
+	0x62, 0x40, 0xdb, 0x3b, 0x1b, 0x11, 0xfa, 0xae, 0x4b, 0x5b, 0x9e, 0x3e, 0x48, 0x02, 0xfc, 0xcc,
+	0x23, 0x8f, 0xa5, 0xab, 0x3e, 0xf2, 0xf8, 0x1e, 0xd4, 0x03, 0x5e, 0x48, 0x42, 0x6a, 0x85, 0x53,
+	0xdf, 0x1e, 0x23, 0x5a, 0x88, 0xb6, 0xeb, 0xd2, 0x5a, 0x20, 0x7e, 0x60, 0xd9, 0x7c, 0x3a, 0xb2,
+	0xe6, 0x81, 0xc7, 0x98, 0x72, 0x63, 0x52, 0x41, 0x7e, 0x43, 0xa2, 0x3e, 0xea, 0xeb, 0x01, 0x26,
+	0x0e, 0x69, 0x33, 0x24, 0x3e, 0xf0, 0x18, 0x53, 0x7f, 0x59, 0x00, 0x65, 0xdc, 0x75, 0xfd, 0x9f,
+	0x9e, 0x59, 0x53, 0xff, 0xb2, 0x00, 0x55, 0x71, 0x8d, 0x51, 0xa1, 0xc8, 0x4c, 0xb5, 0xd4, 0xa4,
+	0x61, 0x33, 0x4a, 0x53, 0x16, 0x13, 0x69, 0xca, 0xc7, 0x30, 0x2d, 0x53, 0xd7, 0x3f, 0x16, 0x96,
+	0xa8, 0x34, 0x49, 0x1a, 0x50, 0x0c, 0x2d, 0x9e, 0x1b, 0xdb, 0x66, 0xa7, 0xac, 0x4f, 0xd3, 0xb4,
+	0xa8, 0xb1, 0xbe, 0xf1, 0x1d, 0x5b, 0xf8, 0x09, 0x32, 0xfd, 0x84, 0x00, 0x9e, 0x13, 0xbb, 0x01,
+	0x35, 0x4f, 0x7f, 0x21, 0xfa, 0x2a, 0x3c, 0x27, 0x34, 0xe5, 0xe9, 0x2f, 0xb8, 0xef, 0xf0, 0xab,
+	0x2a, 0x34, 0x12, 0x4a, 0x08, 0x73, 0x51, 0x5c, 0x3d, 0x9e, 0x32, 0x8f, 0xbb, 0xb2, 0x75, 0x1a,
+	0xb5, 0xc9, 0xa7, 0xd9, 0xf0, 0xf5, 0xd5, 0x89, 0x66, 0x3c, 0x1b, 0xb9, 0xbe, 0x0f, 0xd5, 0x54,
+	0x10, 0x35, 0xd9, 0x09, 0x90, 0xb8, 0x98, 0xd3, 0x4a, 0xfa, 0x22, 0xfc, 0x0c, 0x6a, 0xb4, 0x21,
+	0x61, 0xe8, 0x65, 0x24, 0xf5, 0x78, 0x39, 0xad, 0xc7, 0x15, 0x98, 0x32, 0x1c, 0xdb, 0x77, 0xfa,
+	0xe1, 0xdb, 0xc1, 0xb0, 0x49, 0x5e, 0x87, 0x99, 0x64, 0x00, 0x62, 0x99, 0x32, 0xf3, 0x36, 0x9d,
+	0x80, 0x66, 0x73, 0x44, 0x53, 0x19, 0x33, 0x98, 0x6b, 0xb5, 0x6a, 0xf9, 0x56, 0x2b, 0x6d, 0x1c,
+	0xeb, 0x57, 0x31, 0x8e, 0xfb, 0x40, 0xa4, 0x18, 0x69, 0xa8, 0x42, 0x4c, 0xd6, 0x0f, 0x74, 0x5f,
+	0x81, 0x49, 0xc2, 0xd2, 0x16, 0xf8, 0xb4, 0xbd, 0xd3, 0x41, 0x6c, 0x2c, 0xe1, 0x0b, 0x80, 0x3e,
+	0xe0, 0x00, 0xff, 0xdb, 0x35, 0x05, 0xf3, 0x59, 0x53, 0xf0, 0x3a, 0xcc, 0xc8, 0x8d, 0x75, 0x3c,
+	0xd3, 0xb2, 0xf5, 0x3e, 0xb7, 0x16, 0xd3, 0x54, 0x9a, 0xc6, 0x5d, 0x01, 0x24, 0xef, 0xc3, 0x22,
+	0xd7, 0x03, 0x8e, 0xa7, 0x65, 0xd0, 0xe7, 0xe4, 0xc5, 0x14, 0xbd, 0xed, 0x14, 0xd5, 0xf7, 0xe1,
+	0xbe, 0xd1, 0x77, 0x7c, 0xe6, 0x07, 0xda, 0xd0, 0xb6, 0x9d, 0xc0, 0x3a, 0xc2, 0x27, 0x85, 0xe8,
+	0xce, 0xfb, 0x39, 0x9c, 0x08, 0xe7, 0xf4, 0x86, 0xa4, 0x78, 0x12, 0x11, 0xb4, 0x25, 0x7e, 0x9a,
+	0xf7, 0x9b, 0xc9, 0x80, 0x4e, 0xf8, 0x38, 0xd7, 0x85, 0xe7, 0x16, 0x81, 0xb7, 0x10, 0x9a, 0x7e,
+	0x02, 0x31, 0xd0, 0x03, 0xb4, 0xff, 0xca, 0x9d, 0xcc, 0x13, 0x88, 0x1d, 0x01, 0x57, 0xff, 0xb4,
+	0x08, 0xd3, 0xa9, 0x4b, 0x91, 0xba, 0x66, 0x85, 0xcc, 0x35, 0x5b, 0x84, 0xaa, 0x69, 0x61, 0xe1,
+	0x5f, 0x6a, 0x0b, 0xd9, 0xc2, 0xb9, 0x1d, 0xf7, 0x9d, 0x43, 0xbd, 0xaf, 0xf9, 0xec, 0x47, 0x43,
+	0x66, 0x1b, 0xe2, 0x32, 0x94, 0xe9, 0x8c, 0x00, 0xef, 0x4b, 0x28, 0xf9, 0x42, 0x28, 0x96, 0x18,
+	0x4d, 0x78, 0xce, 0xea, 0x18, 0x59, 0x19, 0x06, 0x27, 0x21, 0x29, 0x6d, 0xea, 0x89, 0x16, 0x96,
+	0x6b, 0x3d, 0x66, 0x9c, 0xc6, 0x8c, 0x2a, 0x7c, 0xbc, 0x26, 0x02, 0x93, 0x48, 0xc8, 0x2b, 0x46,
+	0x12, 0x75, 0x91, 0x26, 0x02, 0x23, 0x24, 0xcc, 0x4c, 0x1f, 0x5a, 0x31, 0x8e, 0xb8, 0x4a, 0x0d,
+	0xfd, 0xd0, 0x0a, 0x51, 0xd4, 0x1d, 0x68, 0x26, 0xa7, 0x72, 0x99, 0x52, 0xe0, 0x32, 0xd4, 0x22,
+	0x8e, 0xd2, 0x47, 0x0d, 0xdb, 0x6a, 0x1b, 0x66, 0x33, 0xb7, 0x60, 0x82, 0x7a, 0x9e, 0x87, 0x0a,
+	0xbf, 0x56, 0x9c, 0x4b, 0x89, 0x8a, 0x86, 0xfa, 0x1e, 0xd4, 0xa3, 0xb0, 0x02, 0x35, 0x78, 0x70,
+	0xee, 0x32, 0x59, 0x25, 0xe3, 0xbf, 0x11, 0x66, 0xea, 0x92, 0xaa, 0x49, 0xf9, 0x6f, 0xf5, 0xa7,
+	0x45, 0xa8, 0x70, 0x67, 0x85, 0x6c, 0x40, 0xdd, 0x71, 0x59, 0x22, 0xca, 0x98, 0x19, 0xff, 0x36,
+	0xe0, 0x6c, 0xd7, 0x5d, 0xdd, 0x0d, 0x91, 0x69, 0x4c, 0x97, 0x6b, 0x38, 0x46, 0x75, 0x57, 0x29,
+	0x4f, 0x77, 0x65, 0x12, 0x21, 0xe5, 0x97, 0x4f, 0x84, 0xa8, 0xdf, 0x85, 0x7a, 0x34, 0x3b, 0xb2,
+	0x00, 0x73, 0xbb, 0x7b, 0x5d, 0xda, 0x3e, 0xd8, 0xda, 0xed, 0x69, 0x4f, 0x7a, 0x8f, 0x7b, 0xbb,
+	0xcf, 0x7a, 0xad, 0x6b, 0x64, 0x1e, 0x5a, 0x31, 0x78, 0x83, 0x76, 0xdb, 0x07, 0xdd, 0x56, 0x41,
+	0xfd, 0xb7, 0x12, 0x94, 0xd1, 0xe3, 0x23, 0xeb, 0xa3, 0xbb, 0xf1, 0xda, 0x78, 0x07, 0x31, 0x7f,
+	0x33, 0xe2, 0xfa, 0x86, 0xb8, 0x9a, 0x32, 0xf6, 0x92, 0x2b, 0x46, 0x10, 0xee, 0x17, 0x57, 0x49,
+	0x62, 0x47, 0xf8, 0x6f, 0x3c, 0x5d, 0xdf, 0x70, 0x5c, 0x26, 0xed, 0xa2, 0x68, 0xa0, 0x0a, 0x13,
+	0xde, 0x0f, 0xdf, 0x5f, 0x61, 0x1e, 0x84, 0x3f, 0xc4, 0x65, 0x0b, 0x73, 0x3f, 0x9e, 0x35, 0xd0,
+	0xbd, 0x73, 0x5e, 0x43, 0x17, 0xd6, 0x01, 0x24, 0x08, 0xab, 0xf1, 0x37, 0xa1, 0xee, 0xf4, 0x4d,
+	0xcd, 0xd5, 0xcf, 0x99, 0xc7, 0xe5, 0xb9, 0x4e, 0x6b, 0x4e, 0xdf, 0xdc, 0xc3, 0xb6, 0x08, 0x20,
+	0x5e, 0xc8, 0x4e, 0x61, 0x12, 0x6a, 0x58, 0xbe, 0xe0, 0x9d, 0x37, 0x00, 0x11, 0x85, 0x39, 0xae,
+	0x0b, 0x73, 0xec, 0xf4, 0xcd, 0xd0, 0x52, 0x23, 0x1d, 0xef, 0x02, 0xd1, 0x65, 0x33, 0x6e, 0xa9,
+	0x31, 0xf2, 0x0c, 0xa9, 0x34, 0xb4, 0xec, 0xd2, 0x3c, 0x37, 0x24, 0xe9, 0x97, 0xbe, 0x88, 0x4e,
+	0x43, 0x72, 0x81, 0xd3, 0x14, 0x38, 0x92, 0x07, 0xe2, 0xa8, 0xe6, 0x55, 0xcf, 0x72, 0xab, 0xb7,
+	0xdf, 0xa5, 0x07, 0xad, 0x42, 0x1a, 0xfa, 0x64, 0xaf, 0x83, 0x27, 0x5c, 0x4c, 0x43, 0x69, 0x77,
+	0x67, 0xf7, 0x69, 0xb7, 0x55, 0x52, 0xff, 0xba, 0x09, 0x15, 0xee, 0x01, 0x5f, 0xe1, 0x1a, 0x70,
+	0xfc, 0x97, 0x3e, 0xf9, 0x79, 0xa8, 0x88, 0xed, 0x16, 0x47, 0x2f, 0x1a, 0xf1, 0xcd, 0x2e, 0x27,
+	0x6e, 0x36, 0x42, 0x45, 0x6c, 0x27, 0x14, 0x9a, 0x68, 0xe0, 0x4c, 0x51, 0x16, 0x7c, 0x57, 0x97,
+	0x5a, 0xec, 0x82, 0x99, 0xf6, 0x42, 0x64, 0x1a, 0xd3, 0xa1, 0x58, 0x0d, 0x6d, 0xeb, 0x47, 0x43,
+	0xc6, 0xc5, 0x46, 0x1c, 0x7d, 0x5d, 0x40, 0x50, 0x6a, 0xbe, 0x17, 0x39, 0x41, 0x53, 0x7c, 0x00,
+	0x75, 0xd2, 0x00, 0x69, 0x57, 0x48, 0xfd, 0xd7, 0xea, 0x25, 0x8e, 0x6e, 0x19, 0x16, 0xb3, 0xd7,
+	0x50, 0x3b, 0x68, 0xaf, 0x6f, 0x77, 0x5b, 0x05, 0x72, 0x07, 0x96, 0xe3, 0xbe, 0x4e, 0xf7, 0x51,
+	0x97, 0xd2, 0x6e, 0x47, 0x3b, 0xa0, 0x5f, 0x69, 0xed, 0x4e, 0xa7, 0x55, 0x24, 0x77, 0xe1, 0xf6,
+	0x98, 0xfe, 0x8d, 0x76, 0x6f, 0xa3, 0xbb, 0xdd, 0x2a, 0x4d, 0x40, 0xd9, 0x7b, 0xb2, 0xbf, 0xd9,
+	0xed, 0xb4, 0xca, 0xe4, 0x2d, 0x78, 0x7d, 0x0c, 0x0a, 0x6d, 0xef, 0x68, 0x1b, 0xbb, 0x94, 0x76,
+	0x37, 0xb0, 0xaf, 0x55, 0x21, 0x2a, 0xdc, 0x19, 0x87, 0xca, 0x05, 0xa9, 0xd3, 0xaa, 0x12, 0x05,
+	0xe6, 0x93, 0x38, 0xdb, 0xdd, 0x83, 0x6e, 0xfb, 0xc9, 0xc1, 0x66, 0x6b, 0x8a, 0x2c, 0x02, 0x89,
+	0x7b, 0xb6, 0xb7, 0x7a, 0x8f, 0x39, 0xbc, 0x96, 0xa6, 0xe8, 0x75, 0x9f, 0xb5, 0x37, 0x36, 0x76,
+	0x9f, 0xf4, 0x0e, 0x5a, 0x75, 0xf2, 0x0a, 0xdc, 0x8c, 0x7b, 0xf6, 0xe8, 0xd6, 0x4e, 0x9b, 0x7e,
+	0xad, 0x6d, 0xf5, 0x3a, 0x5d, 0xb1, 0x03, 0x90, 0x9e, 0x50, 0x1a, 0x41, 0x8a, 0x76, 0x63, 0x12,
+	0x8e, 0xbc, 0x14, 0x4d, 0xf2, 0x2e, 0x7c, 0x67, 0x32, 0x0e, 0x8e, 0x87, 0x73, 0xd3, 0xf6, 0xda,
+	0x5f, 0x77, 0x69, 0x6b, 0x9a, 0xbc, 0x07, 0x0f, 0x2e, 0xa0, 0x10, 0x13, 0xd0, 0x76, 0xb7, 0x3b,
+	0x92, 0x68, 0x26, 0x7d, 0xd8, 0xb2, 0x5f, 0x1c, 0xf6, 0x6c, 0xfa, 0xa4, 0xf6, 0xbb, 0x1b, 0xbb,
+	0xbd, 0x4e, 0x7a, 0xb5, 0x2d, 0xf2, 0x1a, 0xac, 0x8c, 0x47, 0x91, 0xeb, 0x9d, 0x23, 0x6b, 0xb0,
+	0x3a, 0x1e, 0x2b, 0x77, 0x35, 0x84, 0x7c, 0x00, 0x0f, 0x2f, 0xa4, 0x19, 0x59, 0xcf, 0xf5, 0xb4,
+	0x2e, 0xd9, 0xef, 0x1e, 0xb4, 0xd7, 0xb7, 0x5a, 0xf3, 0x69, 0x49, 0xdf, 0xef, 0x1e, 0x6c, 0xec,
+	0x76, 0xba, 0xad, 0x85, 0xf4, 0x31, 0x3f, 0xe9, 0x45, 0x02, 0xb0, 0x98, 0x3e, 0x66, 0x31, 0x1a,
+	0xf6, 0x84, 0x56, 0x69, 0x69, 0x2c, 0x82, 0x3c, 0x3f, 0x45, 0xfd, 0xf7, 0x02, 0xd4, 0xa3, 0xeb,
+	0x8d, 0x13, 0xe8, 0xb5, 0x77, 0xba, 0xfb, 0x7b, 0xed, 0x8d, 0x6e, 0xe2, 0xaa, 0xcd, 0xc1, 0x74,
+	0x0c, 0xc6, 0xa9, 0x16, 0xd2, 0x98, 0xa1, 0xdc, 0x15, 0x09, 0x81, 0x99, 0x04, 0x18, 0x27, 0x59,
+	0x22, 0x4b, 0x70, 0x3d, 0x0d, 0xe3, 0x22, 0xdc, 0x2a, 0xa7, 0x91, 0xf9, 0x5a, 0x2b, 0x78, 0xd0,
+	0x31, 0x2c, 0x79, 0x51, 0x5a, 0x55, 0x72, 0x1b, 0x6e, 0xc4, 0x7d, 0x99, 0xbd, 0x6e, 0x4d, 0x91,
+	0xeb, 0x30, 0x1b, 0x77, 0x0b, 0xe1, 0xa8, 0xa5, 0x07, 0xe7, 0x40, 0x8d, 0xee, 0x3e, 0x6b, 0xd5,
+	0xd5, 0x9f, 0xc5, 0xd1, 0x2c, 0x81, 0x99, 0xf6, 0x46, 0x46, 0xbb, 0xcc, 0x00, 0x48, 0x18, 0x4a,
+	0x50, 0x01, 0xb7, 0x40, 0xb6, 0xa5, 0x86, 0x28, 0xe2, 0x16, 0x84, 0xa0, 0xf8, 0xaa, 0x97, 0xc8,
+	0x2c, 0x34, 0x24, 0x18, 0x15, 0x45, 0xab, 0x9c, 0x20, 0x95, 0x92, 0x56, 0x49, 0x80, 0xe4, 0x41,
+	0x54, 0xd5, 0xff, 0x5f, 0x80, 0xd9, 0x4c, 0x22, 0x44, 0x78, 0x9c, 0x61, 0x5b, 0x8b, 0xd2, 0x96,
+	0xcd, 0x18, 0xb8, 0x65, 0x66, 0xf4, 0x70, 0x31, 0xab, 0x87, 0xaf, 0x60, 0x2d, 0xd0, 0x7d, 0x9f,
+	0x92, 0x19, 0x10, 0x7c, 0xfd, 0x96, 0xb5, 0x66, 0x6f, 0x4e, 0xcc, 0x99, 0x7c, 0xcb, 0xf6, 0x2c,
+	0xf4, 0x6f, 0xca, 0x79, 0xfe, 0x4d, 0x65, 0xbc, 0x7f, 0x53, 0xcd, 0xf8, 0x37, 0x6a, 0xef, 0xdb,
+	0x71, 0x03, 0xe4, 0xd9, 0x15, 0xd5, 0xbf, 0x2b, 0x43, 0x55, 0x64, 0xe9, 0x48, 0x67, 0x74, 0x8f,
+	0xde, 0x98, 0x94, 0xd6, 0x7b, 0xe9, 0x2d, 0x5a, 0x84, 0xaa, 0xcf, 0x6c, 0x33, 0xda, 0x23, 0xd9,
+	0x42, 0xef, 0x4b, 0xfc, 0x8a, 0xd3, 0xc8, 0x35, 0x01, 0xd8, 0x32, 0xe3, 0x7d, 0xad, 0x24, 0xf7,
+	0xf5, 0x2e, 0x34, 0x79, 0x5d, 0xcf, 0x3f, 0xc1, 0x58, 0x32, 0x90, 0xfb, 0xd5, 0x88, 0x60, 0xed,
+	0x00, 0x3d, 0x42, 0x91, 0x54, 0x1f, 0xda, 0x81, 0xd5, 0x97, 0x2e, 0x1f, 0x70, 0xd0, 0x13, 0x84,
+	0xa0, 0x5c, 0xc6, 0x95, 0x02, 0x64, 0x22, 0xac, 0x7f, 0x33, 0x06, 0xb6, 0x83, 0x1c, 0xe7, 0xbd,
+	0x7e, 0x09, 0xe7, 0xfd, 0x37, 0xa8, 0x62, 0xaa, 0x7f, 0x51, 0x78, 0x59, 0xef, 0x9d, 0xdc, 0x80,
+	0x85, 0x18, 0x8a, 0xf7, 0x36, 0xec, 0xca, 0xb8, 0x7d, 0x8f, 0xda, 0x5b, 0xdb, 0xdd, 0x4e, 0xab,
+	0x94, 0x61, 0x23, 0x54, 0x42, 0x99, 0xdc, 0x84, 0xa5, 0x18, 0xba, 0xb3, 0xdb, 0xd9, 0x7a, 0xf4,
+	0x75, 0xd8, 0x59, 0xc9, 0xef, 0x14, 0xa3, 0x54, 0xd5, 0x5f, 0x17, 0x78, 0x0c, 0x26, 0x05, 0x6b,
+	0x0d, 0x16, 0xc4, 0x03, 0x79, 0x2d, 0xb3, 0x85, 0x42, 0x01, 0x5c, 0x17, 0x9d, 0x07, 0xe3, 0x33,
+	0x38, 0xd9, 0x42, 0x46, 0xf2, 0x75, 0x50, 0x29, 0xfd, 0x3a, 0x28, 0x9d, 0xb0, 0x29, 0x5f, 0x25,
+	0x61, 0xf3, 0x01, 0x4c, 0xc9, 0xac, 0xb6, 0x52, 0x99, 0x94, 0xe9, 0x12, 0xab, 0xa2, 0x55, 0x91,
+	0xd4, 0x56, 0xff, 0xb9, 0x00, 0xf5, 0x28, 0x57, 0x8d, 0x17, 0xfd, 0xb9, 0x65, 0x87, 0x4b, 0xe3,
+	0xbf, 0x2f, 0x73, 0x25, 0x5e, 0x87, 0x99, 0x30, 0x31, 0x2e, 0x93, 0x08, 0x32, 0x36, 0x94, 0xd0,
+	0x0e, 0x07, 0x92, 0x8f, 0x60, 0x4a, 0x02, 0xe4, 0xd2, 0x6e, 0x4f, 0xcc, 0x9d, 0xd3, 0x10, 0x5b,
+	0x5d, 0x87, 0xf2, 0x63, 0x9c, 0x4a, 0x0b, 0x9a, 0x8f, 0xb7, 0x7a, 0x9d, 0x84, 0x04, 0x2d, 0xc0,
+	0x1c, 0x87, 0xec, 0x51, 0xb4, 0x7c, 0x07, 0x5b, 0x4f, 0x85, 0x08, 0xcd, 0xc1, 0x34, 0x07, 0x47,
+	0xa0, 0xa2, 0xfa, 0x63, 0x68, 0x65, 0x13, 0xc2, 0xe4, 0x5d, 0x98, 0xcf, 0xa4, 0x82, 0xc4, 0x12,
+	0x71, 0xf9, 0x15, 0x4a, 0x52, 0x89, 0x20, 0xb1, 0xd2, 0xf7, 0x93, 0xa5, 0xe1, 0x9c, 0x6d, 0x89,
+	0xeb, 0xe0, 0x09, 0x2a, 0xf5, 0x1f, 0x8a, 0x50, 0x15, 0x19, 0xfd, 0x2b, 0xa8, 0x29, 0x41, 0xf0,
+	0xd2, 0x6a, 0xaa, 0x2d, 0xe2, 0x3d, 0x2c, 0x20, 0xc8, 0xc7, 0x51, 0x6f, 0x5c, 0x94, 0xe3, 0xdd,
+	0x3d, 0xfc, 0x86, 0x19, 0x01, 0x8f, 0x0b, 0x11, 0x88, 0x2c, 0x78, 0x3c, 0x89, 0x2c, 0xea, 0x57,
+	0x63, 0x81, 0x61, 0x27, 0xf3, 0x06, 0xff, 0x4d, 0x71, 0xdf, 0xbf, 0x14, 0xa0, 0x95, 0x9d, 0x83,
+	0x2c, 0x2e, 0x02, 0xbf, 0x7c, 0xb2, 0xb8, 0xe8, 0xea, 0x1e, 0xb3, 0x03, 0xbc, 0x77, 0x0d, 0x71,
+	0x27, 0x05, 0x40, 0xe8, 0x67, 0xe7, 0x85, 0x1d, 0xe5, 0xc5, 0x44, 0x23, 0x37, 0x0f, 0xf2, 0x29,
+	0x34, 0xf9, 0x3b, 0xdf, 0xa1, 0x2b, 0x3e, 0x82, 0xbd, 0xb8, 0xe4, 0xd8, 0x40, 0xfc, 0x27, 0x6e,
+	0xf8, 0x89, 0x6c, 0x3d, 0x7e, 0x3a, 0x5e, 0x9e, 0x94, 0x02, 0x4d, 0x3c, 0x60, 0x8f, 0x28, 0xd4,
+	0x9f, 0x00, 0xc4, 0x0b, 0xcd, 0x7d, 0x87, 0xbc, 0x08, 0x55, 0xb1, 0xaa, 0x30, 0x91, 0x27, 0x5a,
+	0xa4, 0x83, 0x69, 0xb5, 0x1f, 0x0d, 0x2d, 0xfc, 0xc8, 0x0d, 0xf9, 0x29, 0xa5, 0xcb, 0x0d, 0xde,
+	0x0c, 0xa9, 0x10, 0xa4, 0xfe, 0x53, 0x01, 0xea, 0x51, 0xdf, 0x7f, 0xc1, 0x83, 0x71, 0xf2, 0x05,
+	0xd4, 0x64, 0x7e, 0x2c, 0x2c, 0xdb, 0xbf, 0x7d, 0xa9, 0xd2, 0x84, 0x64, 0x12, 0x11, 0x93, 0x0f,
+	0xa1, 0xf2, 0x42, 0xb7, 0x82, 0xb0, 0x82, 0x3f, 0xe6, 0x85, 0xd9, 0x33, 0xdd, 0x0a, 0x24, 0xa9,
+	0x40, 0x57, 0xd7, 0xa1, 0x1e, 0xcd, 0x09, 0xdd, 0x99, 0xf8, 0x2d, 0x8e, 0xdc, 0xe6, 0x7a, 0xf4,
+	0x14, 0x07, 0xf7, 0xfa, 0x05, 0x47, 0x0c, 0xff, 0x3b, 0x81, 0x68, 0xa9, 0x5f, 0xc0, 0x6c, 0x66,
+	0x7a, 0x28, 0x60, 0xba, 0x11, 0x38, 0x91, 0x80, 0xf1, 0x06, 0x3e, 0xc8, 0x70, 0x23, 0x44, 0x79,
+	0x60, 0x09, 0x88, 0x7a, 0x0a, 0x0b, 0xb9, 0xeb, 0x24, 0xdd, 0x14, 0x61, 0x61, 0xd2, 0x37, 0x40,
+	0x19, 0x06, 0x49, 0xfe, 0x63, 0x17, 0xf0, 0x19, 0x40, 0xbc, 0x33, 0x68, 0xb0, 0x70, 0x6f, 0x78,
+	0x5d, 0x5f, 0x7e, 0x97, 0x81, 0xed, 0x7d, 0x66, 0x8c, 0x65, 0xf0, 0x37, 0x25, 0xa8, 0x85, 0xd5,
+	0x3f, 0xf2, 0x68, 0x54, 0xe7, 0xdd, 0x9b, 0x5c, 0x30, 0xcc, 0xd7, 0x7a, 0x1f, 0x43, 0xc5, 0x0f,
+	0xf4, 0x80, 0x4d, 0x7e, 0xa6, 0x27, 0x78, 0x60, 0x39, 0x8e, 0x6d, 0x5e, 0xa3, 0x82, 0x82, 0x7c,
+	0x02, 0x55, 0xfe, 0xf4, 0xf9, 0x58, 0x8a, 0xbd, 0x3a, 0x89, 0x76, 0x83, 0x63, 0x6e, 0x5e, 0xa3,
+	0x92, 0x86, 0x50, 0x98, 0x91, 0x72, 0xa5, 0x71, 0x84, 0xf0, 0x6b, 0xae, 0xb7, 0x26, 0x71, 0x91,
+	0x89, 0xe0, 0x6d, 0x4e, 0xb0, 0x79, 0x0d, 0xcb, 0x10, 0x09, 0x00, 0xd9, 0x85, 0x10, 0xa0, 0xc5,
+	0x59, 0xa1, 0xc6, 0xda, 0xbd, 0x4b, 0xb0, 0xe4, 0x75, 0xc0, 0xcd, 0x6b, 0xb4, 0xa9, 0x27, 0xda,
+	0x6a, 0xef, 0xdb, 0x55, 0xb5, 0xeb, 0x55, 0xe1, 0x0b, 0xa8, 0xff, 0x51, 0x82, 0x46, 0x62, 0x4f,
+	0xc9, 0x0f, 0x61, 0x49, 0x3f, 0x65, 0x1e, 0x16, 0x28, 0xa5, 0x8f, 0x13, 0x3d, 0x5a, 0x98, 0xf8,
+	0x04, 0x93, 0xcf, 0xb2, 0x6d, 0x18, 0xc3, 0xc1, 0xb0, 0x8f, 0x66, 0x95, 0xce, 0x4b, 0x36, 0xe2,
+	0x09, 0x4b, 0xf8, 0xd0, 0x61, 0x84, 0x7d, 0x54, 0x46, 0x55, 0x8a, 0x2f, 0xcf, 0x3e, 0x7c, 0xa6,
+	0xc2, 0xcb, 0x67, 0xf2, 0x7f, 0x31, 0xc4, 0xf3, 0x16, 0x15, 0x8d, 0xf0, 0xbf, 0x2b, 0x44, 0x53,
+	0x49, 0xe0, 0xc6, 0x93, 0x28, 0xa7, 0x70, 0x23, 0xbe, 0xf7, 0xa0, 0x25, 0xbe, 0x18, 0x46, 0xae,
+	0xf2, 0x4a, 0x88, 0x3c, 0xdf, 0x0c, 0x87, 0xf7, 0x58, 0x78, 0x9b, 0x22, 0x4c, 0xe4, 0x29, 0x31,
+	0xab, 0x09, 0xcc, 0x0d, 0x77, 0x28, 0x31, 0xdf, 0x80, 0x59, 0x81, 0x89, 0x15, 0xb8, 0xc3, 0xf3,
+	0x80, 0xf9, 0xb2, 0x84, 0x31, 0xcd, 0xc1, 0x54, 0x1f, 0xac, 0x23, 0x10, 0xe7, 0x79, 0x6a, 0x79,
+	0xc1, 0x50, 0x8e, 0xce, 0xcf, 0x8a, 0xdb, 0xfc, 0x32, 0x9d, 0x95, 0x1d, 0x3d, 0x26, 0xe4, 0x2e,
+	0x89, 0x8b, 0xe3, 0x0b, 0xdc, 0x7a, 0x0a, 0x77, 0xc3, 0x1d, 0x72, 0x5c, 0xf5, 0x1f, 0x8b, 0xd0,
+	0x4c, 0xde, 0x08, 0xf2, 0x7f, 0x61, 0x3e, 0x22, 0xd2, 0x5c, 0xdd, 0xd3, 0x07, 0x2c, 0xc0, 0x0f,
+	0xb2, 0x0a, 0x93, 0x1e, 0x88, 0x77, 0xd1, 0xfc, 0x59, 0x06, 0x67, 0xb9, 0x17, 0xd1, 0x50, 0x62,
+	0xb8, 0xc3, 0x0c, 0x0c, 0xf9, 0x47, 0x0b, 0x48, 0xf2, 0x2f, 0xbe, 0x0c, 0x7f, 0x9b, 0x05, 0x19,
+	0x18, 0x79, 0x04, 0x2b, 0xe1, 0x9d, 0x8b, 0x8b, 0xf3, 0xa1, 0xb4, 0xbd, 0xb0, 0x6c, 0xd3, 0x79,
+	0x21, 0xcb, 0xed, 0xb7, 0x24, 0x5e, 0x78, 0xbe, 0x6d, 0x81, 0xf4, 0x8c, 0xe3, 0x24, 0xf9, 0xc4,
+	0xd5, 0xfa, 0x0c, 0x9f, 0x72, 0x8a, 0x4f, 0x28, 0x53, 0x29, 0x3e, 0xea, 0x9f, 0x14, 0xe0, 0x7a,
+	0x8e, 0xb2, 0x18, 0xe3, 0x8d, 0x28, 0x30, 0x25, 0xa5, 0x8e, 0x6f, 0x48, 0x8d, 0x86, 0x4d, 0xfe,
+	0x49, 0x55, 0x2c, 0x76, 0x25, 0x9e, 0x46, 0xc0, 0xb7, 0x44, 0xb1, 0x15, 0x4b, 0xc8, 0x9a, 0xc8,
+	0x32, 0xd4, 0x8d, 0x48, 0xcc, 0x6e, 0x42, 0x3d, 0x16, 0xb0, 0x0a, 0xef, 0xad, 0x79, 0x52, 0xb6,
+	0xd4, 0xbf, 0x2d, 0x00, 0x19, 0x55, 0x3e, 0x63, 0x66, 0xb8, 0x91, 0x7c, 0xc1, 0x74, 0xb5, 0xdb,
+	0x1a, 0xbf, 0x74, 0xda, 0x80, 0x7a, 0x7c, 0xdb, 0x4a, 0x57, 0x63, 0x12, 0xbe, 0xa9, 0x08, 0xd7,
+	0x94, 0xbc, 0xb2, 0xb8, 0x26, 0xa1, 0x29, 0xfb, 0xd0, 0xca, 0x92, 0xa2, 0x47, 0xcd, 0xdd, 0xba,
+	0xb0, 0x82, 0x2b, 0xec, 0x1c, 0x77, 0xdd, 0xc2, 0x32, 0xed, 0x0d, 0xa8, 0x9d, 0xea, 0xfd, 0x21,
+	0xd3, 0xa4, 0xc3, 0x5d, 0xa6, 0x53, 0xbc, 0xdd, 0x3d, 0xc3, 0x9a, 0xa0, 0xe1, 0xd8, 0xfe, 0x70,
+	0x20, 0x1d, 0xc2, 0x32, 0x8d, 0xda, 0xf8, 0x11, 0xeb, 0x62, 0xbe, 0x8c, 0xa2, 0xf5, 0x0c, 0x74,
+	0xef, 0x98, 0x89, 0xd2, 0x60, 0x99, 0xca, 0x16, 0x69, 0x41, 0x69, 0xa0, 0x87, 0x83, 0xe0, 0x4f,
+	0x71, 0xf6, 0x9e, 0xe5, 0x44, 0xef, 0x41, 0xc2, 0x26, 0xc6, 0x5e, 0xf8, 0x3c, 0x6f, 0x30, 0xec,
+	0x07, 0x16, 0x7e, 0xa0, 0xe6, 0x29, 0xe5, 0xe8, 0x71, 0xde, 0x4e, 0x04, 0x24, 0x9f, 0xf3, 0x7f,
+	0x54, 0x13, 0x78, 0xba, 0x81, 0x05, 0xfd, 0x20, 0x34, 0x37, 0xe3, 0x9e, 0x11, 0xa1, 0x15, 0xa1,
+	0xcd, 0x90, 0x82, 0x0a, 0x13, 0xda, 0x60, 0x67, 0xae, 0x6e, 0x9b, 0x82, 0xbe, 0x7a, 0x31, 0x3d,
+	0x08, 0x7c, 0xa4, 0x56, 0xbf, 0x80, 0x0a, 0x07, 0xa2, 0xcf, 0x68, 0x0f, 0x07, 0x68, 0xa7, 0xa4,
+	0x33, 0x54, 0xa6, 0x31, 0x00, 0xbf, 0xd1, 0x32, 0x99, 0xed, 0x0c, 0x2c, 0x9b, 0xf7, 0x8b, 0x1d,
+	0x48, 0x82, 0xd4, 0x3f, 0x2b, 0x63, 0x70, 0x1e, 0x3e, 0x09, 0x08, 0x33, 0x53, 0x22, 0x62, 0xe3,
+	0xbf, 0x73, 0xbd, 0x76, 0x05, 0xa6, 0x06, 0xcc, 0x8f, 0x44, 0xaa, 0x4e, 0xc3, 0x26, 0xf9, 0x9c,
+	0x3b, 0x15, 0xc6, 0x73, 0xe9, 0x27, 0xde, 0xbf, 0xe0, 0x3d, 0xc2, 0xea, 0xb6, 0x73, 0xbc, 0x23,
+	0x48, 0xa9, 0x20, 0x5c, 0xfe, 0x09, 0x40, 0x0c, 0x24, 0x1d, 0x98, 0x92, 0xcf, 0x44, 0xa4, 0x5a,
+	0xbc, 0x0c, 0x47, 0xf9, 0x3d, 0x19, 0x0d, 0x49, 0x51, 0x32, 0x8e, 0x1c, 0x6f, 0xa0, 0x47, 0x5e,
+	0xbc, 0x68, 0x45, 0xc5, 0xdf, 0x72, 0x5c, 0xfc, 0x5d, 0xfe, 0xe3, 0x22, 0x40, 0xcc, 0x03, 0xaf,
+	0x66, 0x1f, 0x1d, 0xbd, 0xf0, 0x6a, 0xf2, 0x06, 0x12, 0x1e, 0x59, 0xfd, 0x68, 0x53, 0xf0, 0x37,
+	0xc2, 0xfa, 0x96, 0x2d, 0x76, 0xa4, 0x42, 0xf9, 0x6f, 0x1c, 0x78, 0xc0, 0x82, 0x13, 0x27, 0x4c,
+	0x61, 0xc9, 0x16, 0x4a, 0xf8, 0x89, 0xe3, 0x07, 0x89, 0xb2, 0x65, 0xd4, 0xc6, 0x1c, 0x15, 0x7a,
+	0xfd, 0xba, 0x99, 0xcc, 0xfa, 0x81, 0x00, 0xf1, 0xb2, 0x66, 0xea, 0xfb, 0xb6, 0xa9, 0xab, 0x7c,
+	0xdf, 0x96, 0xd8, 0xcd, 0xda, 0x4b, 0xef, 0xa6, 0xfa, 0xeb, 0x22, 0x4c, 0xc9, 0xa4, 0x42, 0x4e,
+	0xae, 0xa2, 0x90, 0x97, 0xab, 0x60, 0xb0, 0xe4, 0x0f, 0x79, 0x20, 0x89, 0x9f, 0xa2, 0x7a, 0xcc,
+	0x0f, 0x3c, 0x2b, 0x7a, 0x90, 0x3c, 0xc1, 0x1a, 0xed, 0x47, 0x44, 0x34, 0x41, 0x43, 0x17, 0xfd,
+	0x5c, 0x38, 0x7e, 0x38, 0x68, 0x32, 0xdf, 0xf0, 0x2c, 0x3e, 0xf9, 0x74, 0xf6, 0x64, 0x2e, 0xd1,
+	0x23, 0x67, 0xa5, 0x42, 0xd3, 0x64, 0xa8, 0xf5, 0x99, 0x6d, 0x58, 0x4c, 0xc4, 0x36, 0x75, 0x9a,
+	0x82, 0x61, 0xbe, 0x2a, 0xfb, 0x85, 0xbd, 0xc6, 0x1f, 0x11, 0x88, 0x63, 0xbb, 0x9e, 0xf9, 0xca,
+	0xfe, 0x00, 0xdf, 0x14, 0x6c, 0xc1, 0xb4, 0xef, 0x32, 0xc3, 0x3a, 0xb2, 0x0c, 0x5d, 0x7e, 0xf2,
+	0x55, 0x1a, 0xff, 0xd4, 0x6a, 0x3f, 0x89, 0x4a, 0xd3, 0x94, 0xea, 0x2f, 0x0a, 0xb0, 0x98, 0xbf,
+	0x09, 0x78, 0x09, 0x99, 0x8d, 0xb9, 0x60, 0x11, 0x2c, 0xd6, 0x68, 0xd8, 0xc4, 0x17, 0xf7, 0xae,
+	0xc7, 0xe4, 0xbf, 0x9d, 0x12, 0xdf, 0x66, 0x88, 0xa0, 0x53, 0x5a, 0xba, 0x85, 0x54, 0x2f, 0x95,
+	0x9d, 0xf8, 0x9f, 0x6f, 0x98, 0xee, 0xf5, 0x2d, 0xe6, 0x07, 0x9a, 0xde, 0xef, 0x3b, 0x2f, 0x30,
+	0xb6, 0x8d, 0x99, 0x44, 0x4f, 0x82, 0xeb, 0xf4, 0x76, 0x88, 0xd7, 0x16, 0x68, 0xed, 0x08, 0x0b,
+	0xc5, 0x4e, 0xfd, 0x18, 0xa6, 0x53, 0x8b, 0xca, 0x8d, 0xac, 0xe7, 0xa1, 0xc2, 0xf5, 0xbd, 0xbc,
+	0x43, 0xa2, 0xa1, 0xfe, 0xaa, 0x00, 0x44, 0x9a, 0xc6, 0x30, 0xbf, 0x44, 0xd9, 0xd1, 0x84, 0x67,
+	0x1f, 0xf8, 0x3c, 0x4c, 0x24, 0x96, 0xc2, 0x6f, 0x03, 0x65, 0x73, 0xf4, 0xdb, 0xc0, 0x71, 0x59,
+	0xc3, 0xf2, 0xa4, 0xac, 0x61, 0xe5, 0x2a, 0x59, 0xc3, 0xcb, 0xbd, 0x48, 0xbb, 0xff, 0xcb, 0x02,
+	0x10, 0xf1, 0x1d, 0xb7, 0xfc, 0x66, 0xc1, 0xea, 0x63, 0xfc, 0x7f, 0x13, 0x96, 0xd6, 0xb7, 0x77,
+	0x37, 0x1e, 0xd3, 0xee, 0xd3, 0x2e, 0xdd, 0xdf, 0x5a, 0xdf, 0xda, 0xde, 0x3a, 0xf8, 0x5a, 0xeb,
+	0xed, 0xf6, 0xba, 0xad, 0x6b, 0x58, 0x0a, 0xcc, 0xe9, 0x0c, 0x5b, 0xbc, 0x34, 0xfc, 0x2a, 0xbc,
+	0x92, 0x83, 0xb2, 0x45, 0x13, 0x48, 0x45, 0x72, 0x0b, 0x94, 0x1c, 0xa4, 0xfd, 0x83, 0xf6, 0x76,
+	0x57, 0x94, 0x86, 0x73, 0x7a, 0x77, 0xda, 0x5f, 0xaf, 0x77, 0x05, 0x4a, 0xf9, 0xfe, 0x4f, 0xd3,
+	0xef, 0xf1, 0xe5, 0xc7, 0x40, 0xcb, 0xb0, 0x78, 0x40, 0xdb, 0xbd, 0x7d, 0x51, 0xfb, 0xd9, 0x3f,
+	0x68, 0x1f, 0x3c, 0xd9, 0x0f, 0xa7, 0x7e, 0x07, 0x96, 0x47, 0xfb, 0xba, 0x5f, 0x75, 0x37, 0x9e,
+	0x1c, 0x74, 0x3b, 0xad, 0x42, 0x7e, 0xff, 0xfe, 0xee, 0xa3, 0x03, 0x4c, 0x49, 0xb7, 0x8a, 0xf9,
+	0xfd, 0x9b, 0x6d, 0xda, 0xe1, 0xfd, 0x25, 0x2c, 0x9e, 0x8d, 0xf6, 0x77, 0xba, 0xdb, 0xed, 0xaf,
+	0x79, 0x2d, 0x3b, 0xb7, 0xbb, 0xfb, 0xd5, 0xde, 0x16, 0xed, 0x76, 0x5a, 0x95, 0xfc, 0xee, 0x30,
+	0xc6, 0xab, 0xe6, 0x0f, 0x2e, 0x12, 0xdf, 0xdd, 0x4e, 0x6b, 0x6a, 0xbd, 0xfd, 0xfd, 0xcf, 0x8e,
+	0xad, 0xe0, 0x64, 0x78, 0xb8, 0x6a, 0x38, 0x83, 0x07, 0xfc, 0x82, 0xbf, 0x63, 0x39, 0xf2, 0x87,
+	0xf8, 0xdf, 0x8e, 0xee, 0xe1, 0x83, 0xbc, 0x7f, 0xf5, 0xf8, 0xbf, 0xdc, 0x43, 0xfe, 0xf3, 0xb0,
+	0xca, 0x85, 0xea, 0xbd, 0xff, 0x1c, 0x00, 0x0d, 0x07, 0x06, 0x8a, 0x11, 0x52, 0x00, 0x00,
 }
@@ -0,0 +1,237 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbcodec
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SkipChildren can be returned by a `CreationTree.Walk` visitor to skip the descendants
+// of the visited node, the walk continuing with its next sibling.
+var SkipChildren = errors.New("skip children")
+
+// CreationTree is the navigable form of the flat creation tree of a transaction trace, its
+// nodes being looked up by the execution index of their action trace.
+type CreationTree struct {
+	roots []*CreationNode
+	nodes map[uint32]*CreationNode
+}
+
+// CreationNode is an action trace in the creation tree, its parent being the action that
+// created it, either by notifying its receiver or by sending it as an inline action. Input
+// actions have no parent.
+type CreationNode struct {
+	ActionTrace *ActionTrace
+	Parent      *CreationNode
+	Children    []*CreationNode
+
+	executionIndex uint32
+}
+
+// ExecutionIndex is the execution index of the node action trace
+func (n *CreationNode) ExecutionIndex() uint32 {
+	return n.executionIndex
+}
+
+// IsNotification returns whether the node is the notification of an action to an account
+// other than the contract of the action.
+func (n *CreationNode) IsNotification() bool {
+	return n.ActionTrace.Action != nil && n.ActionTrace.Receiver != n.ActionTrace.Action.Account
+}
+
+// NewCreationTree builds the navigable creation tree of the transaction trace, failing when
+// its flat `CreationTree` is inconsistent (unknown execution index or creator, action trace
+// present twice or missing). Action traces are expected in execution order, like they are
+// in blocks.
+func NewCreationTree(trace *TransactionTrace) (*CreationTree, error) {
+	actionTraces := trace.ActionTraces
+
+	tree := &CreationTree{nodes: make(map[uint32]*CreationNode, len(trace.CreationTree))}
+
+	// The creator index of a flat node is the index of its parent in the flat tree, which
+	// is a depth-first walk of the tree so parents always come before their children.
+	walked := make([]*CreationNode, len(trace.CreationTree))
+	for i, flatNode := range trace.CreationTree {
+		if int(flatNode.ExecutionActionIndex) >= len(actionTraces) {
+			return nil, fmt.Errorf("creation node #%d refers to unknown action trace with execution index %d", i, flatNode.ExecutionActionIndex)
+		}
+
+		if _, found := tree.nodes[flatNode.ExecutionActionIndex]; found {
+			return nil, fmt.Errorf("creation node #%d refers to action trace with execution index %d already in tree", i, flatNode.ExecutionActionIndex)
+		}
+
+		node := &CreationNode{ActionTrace: actionTraces[flatNode.ExecutionActionIndex], executionIndex: flatNode.ExecutionActionIndex}
+		switch {
+		case flatNode.CreatorActionIndex == -1:
+			tree.roots = append(tree.roots, node)
+
+		case flatNode.CreatorActionIndex < 0 || int(flatNode.CreatorActionIndex) >= i:
+			return nil, fmt.Errorf("creation node #%d has invalid creator node #%d", i, flatNode.CreatorActionIndex)
+
+		default:
+			node.Parent = walked[flatNode.CreatorActionIndex]
+			node.Parent.Children = append(node.Parent.Children, node)
+		}
+
+		walked[i] = node
+		tree.nodes[flatNode.ExecutionActionIndex] = node
+	}
+
+	if len(tree.nodes) != len(actionTraces) {
+		return nil, fmt.Errorf("creation tree has %d nodes but transaction trace has %d action traces", len(tree.nodes), len(actionTraces))
+	}
+
+	return tree, nil
+}
+
+// Roots returns the nodes of the input actions of the transaction
+func (t *CreationTree) Roots() []*CreationNode {
+	return t.roots
+}
+
+// Node returns the node of the action trace at `executionIndex`, `nil` if not found
+func (t *CreationTree) Node(executionIndex uint32) *CreationNode {
+	return t.nodes[executionIndex]
+}
+
+// Parent returns the node of the action that created the action trace at `executionIndex`,
+// `nil` for input actions.
+func (t *CreationTree) Parent(executionIndex uint32) *CreationNode {
+	if node := t.nodes[executionIndex]; node != nil {
+		return node.Parent
+	}
+
+	return nil
+}
+
+// Children returns the nodes of the notifications and inline actions directly created by the
+// action trace at `executionIndex`.
+func (t *CreationTree) Children(executionIndex uint32) []*CreationNode {
+	if node := t.nodes[executionIndex]; node != nil {
+		return node.Children
+	}
+
+	return nil
+}
+
+// Ancestors returns the nodes of the creators of the action trace at `executionIndex`, from
+// its parent up to the input action.
+func (t *CreationTree) Ancestors(executionIndex uint32) (out []*CreationNode) {
+	node := t.nodes[executionIndex]
+	if node == nil {
+		return nil
+	}
+
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		out = append(out, parent)
+	}
+
+	return out
+}
+
+// Root returns the node of the input action from which the action trace at `executionIndex`
+// originates, the node itself for an input action.
+func (t *CreationTree) Root(executionIndex uint32) *CreationNode {
+	node := t.nodes[executionIndex]
+	for node != nil && node.Parent != nil {
+		node = node.Parent
+	}
+
+	return node
+}
+
+// Descendants returns the nodes created, directly or not, by the action trace at
+// `executionIndex`, in depth-first order.
+func (t *CreationTree) Descendants(executionIndex uint32) (out []*CreationNode) {
+	node := t.nodes[executionIndex]
+	if node == nil {
+		return nil
+	}
+
+	walkCreationNodes(node.Children, 1, func(node *CreationNode, depth int) error {
+		out = append(out, node)
+		return nil
+	})
+
+	return out
+}
+
+// InlinesCreatedBy returns the nodes of the inline actions sent by the `account` contract,
+// i.e. the non-notification children of the actions executed with `account` as receiver.
+func (t *CreationTree) InlinesCreatedBy(account string) (out []*CreationNode) {
+	t.Walk(func(node *CreationNode, depth int) error {
+		if node.Parent != nil && node.Parent.ActionTrace.Receiver == account && !node.IsNotification() {
+			out = append(out, node)
+		}
+
+		return nil
+	})
+
+	return out
+}
+
+// Walk calls `visitor` on each node of the tree in depth-first order, `depth` being 0 for
+// input actions. The walk stops on the first error returned by the visitor, which is then
+// returned, except for `SkipChildren` which only skips the descendants of the node.
+func (t *CreationTree) Walk(visitor func(node *CreationNode, depth int) error) error {
+	return walkCreationNodes(t.roots, 0, visitor)
+}
+
+func walkCreationNodes(nodes []*CreationNode, depth int, visitor func(node *CreationNode, depth int) error) error {
+	for _, node := range nodes {
+		err := visitor(node, depth)
+		if err == SkipChildren {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := walkCreationNodes(node.Children, depth+1, visitor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateCreationTree checks that the flat `CreationTree` of the transaction trace is
+// consistent and that it matches the `creator_action_ordinal` of its action traces. Traces
+// without a creation tree are considered valid.
+func (t *TransactionTrace) ValidateCreationTree() error {
+	if len(t.CreationTree) == 0 {
+		return nil
+	}
+
+	tree, err := NewCreationTree(t)
+	if err != nil {
+		return err
+	}
+
+	return tree.Walk(func(node *CreationNode, depth int) error {
+		expectedCreatorOrdinal := uint32(0)
+		if node.Parent != nil {
+			expectedCreatorOrdinal = node.Parent.ActionTrace.ActionOrdinal
+		}
+
+		if node.ActionTrace.CreatorActionOrdinal != expectedCreatorOrdinal {
+			return fmt.Errorf("action trace with execution index %d has creator action ordinal %d but its creator in creation tree has action ordinal %d", node.ExecutionIndex(), node.ActionTrace.CreatorActionOrdinal, expectedCreatorOrdinal)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbcodec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreationTree(t *testing.T) {
+	tree, err := NewCreationTree(creationTreeTrace())
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint32{0, 5}, executionIndexes(tree.Roots()))
+	assert.Equal(t, "bob", tree.Node(2).ActionTrace.Receiver)
+	assert.Nil(t, tree.Node(6))
+
+	assert.Nil(t, tree.Parent(0))
+	assert.Equal(t, uint32(2), tree.Parent(4).ExecutionIndex())
+	assert.Equal(t, uint32(0), tree.Parent(3).ExecutionIndex())
+
+	assert.Equal(t, []uint32{1, 2, 3}, executionIndexes(tree.Children(0)))
+	assert.Equal(t, []uint32{4}, executionIndexes(tree.Children(2)))
+	assert.Empty(t, tree.Children(5))
+
+	assert.Equal(t, []uint32{2, 0}, executionIndexes(tree.Ancestors(4)))
+	assert.Empty(t, tree.Ancestors(5))
+	assert.Equal(t, uint32(0), tree.Root(4).ExecutionIndex())
+	assert.Equal(t, uint32(5), tree.Root(5).ExecutionIndex())
+
+	assert.Equal(t, []uint32{1, 2, 4, 3}, executionIndexes(tree.Descendants(0)))
+	assert.Equal(t, []uint32{4}, executionIndexes(tree.InlinesCreatedBy("bob")))
+	assert.Equal(t, []uint32{3}, executionIndexes(tree.InlinesCreatedBy("eosio.token")))
+
+	var visited []uint32
+	var depths []int
+	require.NoError(t, tree.Walk(func(node *CreationNode, depth int) error {
+		visited = append(visited, node.ExecutionIndex())
+		depths = append(depths, depth)
+		if node.ActionTrace.Receiver == "bob" {
+			return SkipChildren
+		}
+
+		return nil
+	}))
+	assert.Equal(t, []uint32{0, 1, 2, 3, 5}, visited)
+	assert.Equal(t, []int{0, 1, 1, 1, 0}, depths)
+}
+
+func TestNewCreationTree_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		tamper        func(trace *TransactionTrace)
+		expectedError string
+	}{
+		{
+			name:          "unknown action trace",
+			tamper:        func(trace *TransactionTrace) { trace.CreationTree[5].ExecutionActionIndex = 6 },
+			expectedError: "creation node #5 refers to unknown action trace with execution index 6",
+		},
+		{
+			name:          "action trace twice",
+			tamper:        func(trace *TransactionTrace) { trace.CreationTree[5].ExecutionActionIndex = 0 },
+			expectedError: "creation node #5 refers to action trace with execution index 0 already in tree",
+		},
+		{
+			name:          "creator after node",
+			tamper:        func(trace *TransactionTrace) { trace.CreationTree[3].CreatorActionIndex = 4 },
+			expectedError: "creation node #3 has invalid creator node #4",
+		},
+		{
+			name:          "missing action trace",
+			tamper:        func(trace *TransactionTrace) { trace.CreationTree = trace.CreationTree[0:5] },
+			expectedError: "creation tree has 5 nodes but transaction trace has 6 action traces",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			trace := creationTreeTrace()
+			test.tamper(trace)
+
+			_, err := NewCreationTree(trace)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestTransactionTrace_ValidateCreationTree(t *testing.T) {
+	trace := creationTreeTrace()
+	require.NoError(t, trace.ValidateCreationTree())
+
+	trace.ActionTraces[4].CreatorActionOrdinal = 1
+	assert.EqualError(t, trace.ValidateCreationTree(), "action trace with execution index 4 has creator action ordinal 1 but its creator in creation tree has action ordinal 4")
+
+	trace.CreationTree[3].CreatorActionIndex = 7
+	assert.EqualError(t, trace.ValidateCreationTree(), "creation node #3 has invalid creator node #7")
+
+	assert.NoError(t, (&TransactionTrace{ActionTraces: trace.ActionTraces}).ValidateCreationTree())
+}
+
+// creationTreeTrace returns a transaction trace of two input actions, the first one notifying
+// `alice` and `bob`, sending an inline action, and `bob` sending an inline action when
+// notified, so execution order and the depth-first walk of the creation tree differ.
+func creationTreeTrace() *TransactionTrace {
+	actionTrace := func(receiver, account, name string, executionIndex, ordinal, creatorOrdinal uint32) *ActionTrace {
+		return &ActionTrace{
+			Receiver:             receiver,
+			Action:               &Action{Account: account, Name: name},
+			ExecutionIndex:       executionIndex,
+			ActionOrdinal:        ordinal,
+			CreatorActionOrdinal: creatorOrdinal,
+		}
+	}
+
+	return &TransactionTrace{
+		ActionTraces: []*ActionTrace{
+			actionTrace("eosio.token", "eosio.token", "transfer", 0, 1, 0),
+			actionTrace("alice", "eosio.token", "transfer", 1, 3, 1),
+			actionTrace("bob", "eosio.token", "transfer", 2, 4, 1),
+			actionTrace("eosio.token", "eosio.token", "issue", 3, 5, 1),
+			actionTrace("bob", "bob", "log", 4, 6, 4),
+			actionTrace("eosio", "eosio", "noop", 5, 2, 0),
+		},
+		CreationTree: []*CreationFlatNode{
+			{CreatorActionIndex: -1, ExecutionActionIndex: 0},
+			{CreatorActionIndex: 0, ExecutionActionIndex: 1},
+			{CreatorActionIndex: 0, ExecutionActionIndex: 2},
+			{CreatorActionIndex: 2, ExecutionActionIndex: 4},
+			{CreatorActionIndex: 0, ExecutionActionIndex: 3},
+			{CreatorActionIndex: -1, ExecutionActionIndex: 5},
+		},
+	}
+}
+
+func executionIndexes(nodes []*CreationNode) (out []uint32) {
+	for _, node := range nodes {
+		out = append(out, node.ExecutionIndex())
+	}
+
+	return out
+}
@@ -19,11 +19,33 @@ ROOT="$( cd "$( dirname "${BASH_SOURCE[0]}" )" && cd .. && pwd )"
 PROTO=${1:-"$ROOT/../proto"}
 PROTO_EOSIO=${2:-"$ROOT/../proto-eosio"}
 
+# Changes to the proto-eosio definitions that are not part of its revision yet, applied in order
+# on a copy of it, the ones already part of it being skipped
+PATCHES_DIR="$ROOT/pb/proto-eosio-patches"
+
 function main() {
   set -e
 
   current_dir="`pwd`"
+  proto_eosio="$PROTO_EOSIO"
   trap "cd \"$current_dir\"" EXIT
+
+  applied_patches=""
+  if ls "$PATCHES_DIR"/*.patch &> /dev/null; then
+    proto_eosio="`mktemp -d`"
+    trap "rm -rf \"$proto_eosio\"; cd \"$current_dir\"" EXIT
+    cp -R "$PROTO_EOSIO/." "$proto_eosio"
+
+    for patch_file in "$PATCHES_DIR"/*.patch; do
+      if patch -d "$proto_eosio" -p1 -R -f -s --dry-run < "$patch_file" &> /dev/null; then
+        continue
+      fi
+
+      patch -d "$proto_eosio" -p1 -f -s < "$patch_file"
+      applied_patches="$applied_patches `basename "$patch_file"`"
+    done
+  fi
+
   pushd "$ROOT/pb" &> /dev/null
 
   generate "dfuse/eosio/abicodec/v1/abicodec.proto"
@@ -36,10 +58,13 @@ function main() {
   echo "generate.sh - `date` - `whoami`" > $ROOT/pb/last_generate.txt
   echo "dfuse-io/proto revision: `GIT_DIR=$PROTO/.git git rev-parse HEAD`" >> $ROOT/pb/last_generate.txt
   echo "dfuse-io/proto-eosio revision: `GIT_DIR=$PROTO_EOSIO/.git git rev-parse HEAD`" >> $ROOT/pb/last_generate.txt
+  if [[ "$applied_patches" != "" ]]; then
+    echo "dfuse-io/proto-eosio patches:$applied_patches" >> $ROOT/pb/last_generate.txt
+  fi
 }
 
 function generate() {
-    protoc -I$PROTO -I$PROTO_EOSIO $1 --go_out=plugins=grpc,paths=source_relative:.
+    protoc -I$PROTO -I$proto_eosio $1 --go_out=plugins=grpc,paths=source_relative:.
 }
 
 main "$@"
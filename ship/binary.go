@@ -0,0 +1,189 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ship
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// binaryReader decodes the EOSIO binary serialization used by the state history plugin. The
+// first error encountered is sticky, every read following it returning a zero value, so that
+// a whole structure can be decoded before checking `err` once.
+type binaryReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func newBinaryReader(data []byte) *binaryReader {
+	return &binaryReader{data: data}
+}
+
+func (r *binaryReader) remaining() int {
+	return len(r.data) - r.pos
+}
+
+func (r *binaryReader) next(count int, what string) []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	if count < 0 || r.remaining() < count {
+		r.err = fmt.Errorf("%s: expected %d byte(s) at offset %d, only %d remaining", what, count, r.pos, r.remaining())
+		return nil
+	}
+
+	out := r.data[r.pos : r.pos+count]
+	r.pos += count
+
+	return out
+}
+
+func (r *binaryReader) uint8(what string) uint8 {
+	if data := r.next(1, what); data != nil {
+		return data[0]
+	}
+
+	return 0
+}
+
+func (r *binaryReader) bool(what string) bool {
+	return r.uint8(what) != 0
+}
+
+func (r *binaryReader) uint16(what string) uint16 {
+	if data := r.next(2, what); data != nil {
+		return binary.LittleEndian.Uint16(data)
+	}
+
+	return 0
+}
+
+func (r *binaryReader) uint32(what string) uint32 {
+	if data := r.next(4, what); data != nil {
+		return binary.LittleEndian.Uint32(data)
+	}
+
+	return 0
+}
+
+func (r *binaryReader) uint64(what string) uint64 {
+	if data := r.next(8, what); data != nil {
+		return binary.LittleEndian.Uint64(data)
+	}
+
+	return 0
+}
+
+func (r *binaryReader) int64(what string) int64 {
+	return int64(r.uint64(what))
+}
+
+func (r *binaryReader) varuint32(what string) uint32 {
+	var value uint64
+	for shift := uint(0); r.err == nil; shift += 7 {
+		if shift >= 35 {
+			r.err = fmt.Errorf("%s: varuint32 at offset %d is too long", what, r.pos)
+			return 0
+		}
+
+		b := r.uint8(what)
+		value |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	if value > math.MaxUint32 {
+		r.err = fmt.Errorf("%s: varuint32 at offset %d overflows", what, r.pos)
+		return 0
+	}
+
+	return uint32(value)
+}
+
+// count reads the length prefix of an array, failing when it could not possibly fit in the
+// remaining data, each element taking at least one byte.
+func (r *binaryReader) count(what string) int {
+	count := int(r.varuint32(what))
+	if r.err == nil && count > r.remaining() {
+		r.err = fmt.Errorf("%s: %d element(s) announced at offset %d, only %d byte(s) remaining", what, count, r.pos, r.remaining())
+		return 0
+	}
+
+	return count
+}
+
+func (r *binaryReader) bytes(what string) []byte {
+	length := int(r.varuint32(what))
+	if data := r.next(length, what); data != nil {
+		return append([]byte{}, data...)
+	}
+
+	return nil
+}
+
+func (r *binaryReader) string(what string) string {
+	return string(r.bytes(what))
+}
+
+func (r *binaryReader) name(what string) string {
+	return eos.NameToString(r.uint64(what))
+}
+
+func (r *binaryReader) checksum256(what string) string {
+	if data := r.next(32, what); data != nil {
+		return hex.EncodeToString(data)
+	}
+
+	return ""
+}
+
+func (r *binaryReader) optional(what string) bool {
+	return r.bool(what)
+}
+
+// variant reads the index of a variant, failing when it is greater than `max`
+func (r *binaryReader) variant(what string, max uint32) uint32 {
+	index := r.varuint32(what)
+	if r.err == nil && index > max {
+		r.err = fmt.Errorf("%s: unknown variant index %d, at most %d supported", what, index, max)
+	}
+
+	return index
+}
+
+// skipSignature skips a `signature`, the key type being followed by 65 bytes, along with the
+// authenticator data and client JSON for WebAuthn signatures.
+func (r *binaryReader) skipSignature(what string) {
+	keyType := r.uint8(what)
+	r.next(65, what)
+
+	switch keyType {
+	case 0, 1:
+	case 2:
+		r.bytes(what)
+		r.string(what)
+	default:
+		if r.err == nil {
+			r.err = fmt.Errorf("%s: unknown signature key type %d", what, keyType)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ship
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// Indexes of the messages in the `request` and `result` variants of the state history plugin
+// protocol.
+const (
+	getStatusRequestIndex    = 0
+	getBlocksRequestIndex    = 1
+	getBlocksAckRequestIndex = 2
+
+	getBlocksResultIndex = 1
+)
+
+// GetBlocksRequest is the `get_blocks_request_v0` sent to the state history plugin to start
+// streaming blocks, the block, traces and deltas being always fetched.
+type GetBlocksRequest struct {
+	StartBlockNum       uint32
+	EndBlockNum         uint32
+	MaxMessagesInFlight uint32
+	IrreversibleOnly    bool
+}
+
+// BlockPosition is the number and id of a block as reported by the state history plugin
+type BlockPosition struct {
+	Num uint32
+	ID  string
+}
+
+// getBlocksResult is a decoded `get_blocks_result_v0`, `Block`, `Traces` and `Deltas` being the
+// still serialized `signed_block`, `transaction_trace[]` and `table_delta[]`.
+type getBlocksResult struct {
+	Head             BlockPosition
+	LastIrreversible BlockPosition
+	ThisBlock        *BlockPosition
+	PrevBlock        *BlockPosition
+	Block            []byte
+	Traces           []byte
+	Deltas           []byte
+}
+
+func (r *GetBlocksRequest) encode() []byte {
+	buffer := bytes.NewBuffer(nil)
+	writeVaruint32(buffer, getBlocksRequestIndex)
+	writeUint32(buffer, r.StartBlockNum)
+	writeUint32(buffer, r.EndBlockNum)
+	writeUint32(buffer, r.MaxMessagesInFlight)
+	// No positions are sent since we do not keep the blocks we already have
+	writeVaruint32(buffer, 0)
+	writeBool(buffer, r.IrreversibleOnly)
+	writeBool(buffer, true) // fetch_block
+	writeBool(buffer, true) // fetch_traces
+	writeBool(buffer, true) // fetch_deltas
+
+	return buffer.Bytes()
+}
+
+func encodeGetBlocksAck(numMessages uint32) []byte {
+	buffer := bytes.NewBuffer(nil)
+	writeVaruint32(buffer, getBlocksAckRequestIndex)
+	writeUint32(buffer, numMessages)
+
+	return buffer.Bytes()
+}
+
+func decodeGetBlocksResult(message []byte) (*getBlocksResult, error) {
+	reader := newBinaryReader(message)
+	if index := reader.varuint32("result"); reader.err == nil && index != getBlocksResultIndex {
+		return nil, fmt.Errorf("expected get_blocks_result_v0 (result variant index %d), got variant index %d", getBlocksResultIndex, index)
+	}
+
+	result := &getBlocksResult{
+		Head:             reader.blockPosition("head"),
+		LastIrreversible: reader.blockPosition("last_irreversible"),
+	}
+
+	if reader.optional("this_block") {
+		position := reader.blockPosition("this_block")
+		result.ThisBlock = &position
+	}
+
+	if reader.optional("prev_block") {
+		position := reader.blockPosition("prev_block")
+		result.PrevBlock = &position
+	}
+
+	if reader.optional("block") {
+		result.Block = reader.bytes("block")
+	}
+
+	if reader.optional("traces") {
+		result.Traces = reader.bytes("traces")
+	}
+
+	if reader.optional("deltas") {
+		result.Deltas = reader.bytes("deltas")
+	}
+
+	if reader.err != nil {
+		return nil, fmt.Errorf("unable to decode get_blocks_result_v0: %w", reader.err)
+	}
+
+	return result, nil
+}
+
+func (r *binaryReader) blockPosition(what string) BlockPosition {
+	return BlockPosition{
+		Num: r.uint32(what),
+		ID:  r.checksum256(what),
+	}
+}
+
+func writeVaruint32(buffer *bytes.Buffer, value uint32) {
+	for value >= 0x80 {
+		buffer.WriteByte(byte(value) | 0x80)
+		value >>= 7
+	}
+	buffer.WriteByte(byte(value))
+}
+
+func writeUint32(buffer *bytes.Buffer, value uint32) {
+	var data [4]byte
+	binary.LittleEndian.PutUint32(data[:], value)
+	buffer.Write(data[:])
+}
+
+func writeBool(buffer *bytes.Buffer, value bool) {
+	if value {
+		buffer.WriteByte(1)
+	} else {
+		buffer.WriteByte(0)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ship
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dfuse-io/dfuse-eosio/codec"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	eos "github.com/eoscanada/eos-go"
+	"go.uber.org/zap"
+)
+
+// IngestionSource is the `ingestion_source` of the blocks produced by the `Reader`
+const IngestionSource = "ship"
+
+// UnavailableFields are the fields of the blocks produced by the `Reader` that the state
+// history plugin does not provide, all of them coming from the deep mind instrumentation of
+// nodeos. They are left empty and listed in the `unavailable_fields` of each block.
+//
+// The last irreversible block of the stream is the one when the block was sent, which is past
+// the block itself when catching up, so the `dpos_irreversible_blocknum` of the block is
+// unknown too.
+var UnavailableFields = []string{
+	"block.dpos_irreversible_blocknum",
+	"block.dpos_proposed_irreversible_blocknum",
+	"block.blockroot_merkle",
+	"block.producer_to_last_produced",
+	"block.producer_to_last_implied_irb",
+	"block.confirm_count",
+	"block.pending_schedule",
+	"block.activated_protocol_features",
+	"block.validated",
+	"block.rlimit_ops",
+	"block.unfiltered_implicit_transaction_ops",
+	"block.block_signing_key",
+	"block.active_schedule_v1",
+	"block.valid_block_signing_authority_v2",
+	"block.active_schedule_v2",
+	"transaction_trace.creation_tree",
+	"transaction_trace.db_ops",
+	"transaction_trace.dtrx_ops",
+	"transaction_trace.feature_ops",
+	"transaction_trace.perm_ops",
+	"transaction_trace.ram_ops",
+	"transaction_trace.ram_correction_ops",
+	"transaction_trace.rlimit_ops",
+	"transaction_trace.table_ops",
+	"action_trace.closest_unnotified_ancestor_action_ordinal",
+	"exception.code",
+	"exception.name",
+	"exception.stack",
+}
+
+// Reader turns the messages of a state history plugin stream into blocks. The database
+// operations of the blocks are taken from the `contract_row` deltas and cannot be attributed
+// to a transaction, they are available in the `unattributed_db_ops` of the block instead of
+// the `db_ops` of the transaction traces. Only fluxdb reads them, the table scopes and
+// permissions it tracks from the other operations of the transaction traces being missing for
+// such blocks.
+type Reader struct {
+	source  Source
+	abiRead bool
+}
+
+func NewReader(source Source) *Reader {
+	return &Reader{source: source}
+}
+
+// Read returns the next block of the stream, `io.EOF` once the stream is over
+func (r *Reader) Read() (*pbcodec.Block, error) {
+	if !r.abiRead {
+		if err := r.readABI(); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		message, err := r.source.ReadMessage()
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := decodeGetBlocksResult(message)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.ThisBlock == nil {
+			zlog.Debug("skipping result without block", zap.Uint32("head_num", result.Head.Num))
+			continue
+		}
+
+		block, err := toBlock(result)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert block #%d (%s): %w", result.ThisBlock.Num, result.ThisBlock.ID, err)
+		}
+
+		return block, nil
+	}
+}
+
+func (r *Reader) Close() error {
+	return r.source.Close()
+}
+
+// readABI consumes the protocol ABI starting the stream, only checking that it is JSON since
+// the protocol structures are decoded by hand.
+func (r *Reader) readABI() error {
+	message, err := r.source.ReadMessage()
+	if err != nil {
+		return fmt.Errorf("unable to read state history plugin ABI: %w", err)
+	}
+
+	var abi struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(message, &abi); err != nil {
+		return fmt.Errorf("first message of the stream is not the state history plugin ABI: %w", err)
+	}
+
+	zlog.Info("state history plugin stream started", zap.String("abi_version", abi.Version))
+	r.abiRead = true
+
+	return nil
+}
+
+func toBlock(result *getBlocksResult) (*pbcodec.Block, error) {
+	if result.Block == nil {
+		return nil, fmt.Errorf("signed block not sent by the state history plugin, is it running with 'trace-history' and 'chain-state-history'?")
+	}
+
+	signedBlock := &eos.SignedBlock{}
+	decoder := eos.NewDecoder(result.Block)
+	decoder.DecodeActions(false)
+	decoder.DecodeP2PMessage(false)
+	if err := decoder.Decode(signedBlock); err != nil {
+		return nil, fmt.Errorf("unable to decode signed block: %w", err)
+	}
+
+	block := &pbcodec.Block{
+		Id:                result.ThisBlock.ID,
+		Number:            result.ThisBlock.Num,
+		Version:           1,
+		Header:            codec.BlockHeaderToDEOS(&signedBlock.BlockHeader),
+		BlockExtensions:   codec.ExtensionsToDEOS(signedBlock.BlockExtensions),
+		ProducerSignature: signedBlock.ProducerSignature.String(),
+		IngestionSource:   IngestionSource,
+		UnavailableFields: UnavailableFields,
+	}
+
+	block.UnfilteredTransactionCount = uint32(len(signedBlock.Transactions))
+	for idx, transaction := range signedBlock.Transactions {
+		deosTransaction := codec.TransactionReceiptToDEOS(&transaction)
+		deosTransaction.Index = uint64(idx)
+
+		block.UnfilteredTransactions = append(block.UnfilteredTransactions, deosTransaction)
+	}
+
+	if result.Traces != nil {
+		traces, err := decodeTransactionTraces(result.Traces)
+		if err != nil {
+			return nil, err
+		}
+
+		block.UnfilteredTransactionTraces = traces
+	}
+
+	block.UnfilteredTransactionTraceCount = uint32(len(block.UnfilteredTransactionTraces))
+	for idx, trace := range block.UnfilteredTransactionTraces {
+		trace.Index = uint64(idx)
+		setBlockFields(trace, block)
+
+		for _, actionTrace := range trace.ActionTraces {
+			block.UnfilteredExecutedTotalActionCount++
+			if actionTrace.IsInput() {
+				block.UnfilteredExecutedInputActionCount++
+			}
+		}
+	}
+
+	if result.Deltas != nil {
+		dbOps, err := decodeContractRowDeltas(result.Deltas)
+		if err != nil {
+			return nil, err
+		}
+
+		block.UnattributedDbOps = dbOps
+	}
+
+	return block, nil
+}
+
+func setBlockFields(trace *pbcodec.TransactionTrace, block *pbcodec.Block) {
+	trace.BlockNum = uint64(block.Number)
+	trace.BlockTime = block.Header.Timestamp
+	trace.ProducerBlockId = block.Id
+
+	for _, actionTrace := range trace.ActionTraces {
+		actionTrace.BlockNum = trace.BlockNum
+		actionTrace.BlockTime = trace.BlockTime
+		actionTrace.ProducerBlockId = trace.ProducerBlockId
+	}
+
+	if trace.FailedDtrxTrace != nil {
+		setBlockFields(trace.FailedDtrxTrace, block)
+	}
+}
@@ -0,0 +1,381 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ship
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	reader := NewReader(NewRecordedSource(ioutil.NopCloser(bytes.NewReader(recordedStream(t)))))
+
+	block, err := reader.Read()
+	require.NoError(t, err)
+
+	assert.Equal(t, blockID(10), block.Id)
+	assert.Equal(t, uint32(10), block.Number)
+	assert.Equal(t, uint32(0), block.DposIrreversibleBlocknum)
+	assert.Contains(t, block.UnavailableFields, "block.dpos_irreversible_blocknum")
+	assert.Equal(t, "eosio", block.Header.Producer)
+	assert.Equal(t, IngestionSource, block.IngestionSource)
+	assert.Contains(t, block.UnavailableFields, "transaction_trace.creation_tree")
+	assert.Contains(t, block.UnavailableFields, "transaction_trace.ram_ops")
+
+	require.Len(t, block.UnfilteredTransactionTraces, 1)
+	assert.Equal(t, uint32(1), block.UnfilteredTransactionTraceCount)
+	assert.Equal(t, uint32(3), block.UnfilteredExecutedTotalActionCount)
+	assert.Equal(t, uint32(1), block.UnfilteredExecutedInputActionCount)
+
+	trace := block.UnfilteredTransactionTraces[0]
+	assert.Equal(t, trxID, trace.Id)
+	assert.Equal(t, uint64(10), trace.BlockNum)
+	assert.Equal(t, block.Id, trace.ProducerBlockId)
+	assert.Equal(t, pbcodec.TransactionStatus_TRANSACTIONSTATUS_EXECUTED, trace.Receipt.Status)
+	assert.Equal(t, uint32(120), trace.Receipt.CpuUsageMicroSeconds)
+	assert.Empty(t, trace.CreationTree)
+
+	var executed []string
+	for i, actionTrace := range trace.ActionTraces {
+		assert.Equal(t, uint32(i), actionTrace.ExecutionIndex)
+		assert.Equal(t, trxID, actionTrace.TransactionId)
+		assert.Equal(t, uint64(10), actionTrace.BlockNum)
+
+		executed = append(executed, actionTrace.Receiver+":"+actionTrace.Action.Account+":"+actionTrace.Action.Name)
+	}
+	assert.Equal(t, []string{"eosio.token:eosio.token:transfer", "alice:eosio.token:transfer", "eosio.token:eosio.token:issue"}, executed)
+
+	input := trace.ActionTraces[0]
+	assert.Equal(t, uint32(1), input.ActionOrdinal)
+	assert.Equal(t, []*pbcodec.PermissionLevel{{Actor: "alice", Permission: "active"}}, input.Action.Authorization)
+	assert.Equal(t, []byte{0x01, 0x02}, input.Action.RawData)
+	assert.Equal(t, "hello", input.Console)
+	assert.Equal(t, []*pbcodec.AccountRAMDelta{{Account: "alice", Delta: 128}}, input.AccountRamDeltas)
+	assert.Equal(t, uint64(1011), input.Receipt.GlobalSequence)
+
+	inline := trace.ActionTraces[2]
+	assert.Equal(t, uint32(2), inline.ActionOrdinal)
+	assert.Equal(t, uint32(1), inline.CreatorActionOrdinal)
+	assert.Equal(t, "failed", inline.Exception.Message)
+
+	require.Len(t, block.UnattributedDbOps, 2)
+	assert.Equal(t, &pbcodec.DBOp{
+		Operation:  pbcodec.DBOp_OPERATION_UPDATE,
+		Code:       "eosio.token",
+		Scope:      "alice",
+		TableName:  "accounts",
+		PrimaryKey: "eos",
+		NewPayer:   "alice",
+		NewData:    []byte{0xaa},
+	}, block.UnattributedDbOps[0])
+	assert.Equal(t, pbcodec.DBOp_OPERATION_REMOVE, block.UnattributedDbOps[1].Operation)
+	assert.Equal(t, "bob", block.UnattributedDbOps[1].OldPayer)
+	assert.Equal(t, []byte{0xbb}, block.UnattributedDbOps[1].OldData)
+
+	_, err = reader.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReader_NotAStream(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	writeRecordedMessage(buffer, []byte{0x01, 0x02})
+
+	_, err := NewReader(NewRecordedSource(ioutil.NopCloser(buffer))).Read()
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "first message of the stream is not the state history plugin ABI"), err.Error())
+}
+
+func TestRecorder(t *testing.T) {
+	stream := recordedStream(t)
+
+	recorded := bytes.NewBuffer(nil)
+	source := NewRecorder(NewRecordedSource(ioutil.NopCloser(bytes.NewReader(stream))), recorded)
+	for {
+		_, err := source.ReadMessage()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, stream, recorded.Bytes())
+}
+
+func TestGetBlocksRequest_Encode(t *testing.T) {
+	request := &GetBlocksRequest{StartBlockNum: 10, EndBlockNum: 0xffffffff, MaxMessagesInFlight: 5, IrreversibleOnly: true}
+
+	assert.Equal(t, []byte{
+		0x01,
+		0x0a, 0x00, 0x00, 0x00,
+		0xff, 0xff, 0xff, 0xff,
+		0x05, 0x00, 0x00, 0x00,
+		0x00,
+		0x01, 0x01, 0x01, 0x01,
+	}, request.encode())
+}
+
+var trxID = strings.Repeat("ab", 32)
+
+func blockID(num uint32) string {
+	var id [32]byte
+	binary.BigEndian.PutUint32(id[:], num)
+
+	return eos.Checksum256(id[:]).String()
+}
+
+// recordedStream builds a recorded stream made of the ABI, a result without block (sent by
+// nodeos when the requested blocks are not available yet) and the result of block #10.
+func recordedStream(t *testing.T) []byte {
+	signature, err := ecc.NewSignature("SIG_K1_K7kTcvsznS2pSQ2unjW9nduqHieWnc5B6rFdbVif4RM1DCTVhQUpzwng3XTGewDhVZqNvqSAEwHgB8yBnfDYAHquRX4fBo")
+	require.NoError(t, err)
+
+	signedBlock, err := eos.MarshalBinary(&eos.SignedBlock{
+		SignedBlockHeader: eos.SignedBlockHeader{
+			BlockHeader: eos.BlockHeader{
+				Timestamp: eos.BlockTimestamp{Time: time.Date(2020, 7, 1, 0, 0, 5, 0, time.UTC)},
+				Producer:  "eosio",
+				Previous:  checksum(t, blockID(9)),
+			},
+			ProducerSignature: signature,
+		},
+	})
+	require.NoError(t, err)
+
+	stream := bytes.NewBuffer(nil)
+	writeRecordedMessage(stream, []byte(`{"version":"eosio::abi/1.1","structs":[]}`))
+
+	noBlock := &testWriter{}
+	noBlock.varuint32(getBlocksResultIndex)
+	noBlock.blockPosition(9, blockID(9))
+	noBlock.blockPosition(8, blockID(8))
+	noBlock.bool(false)
+	noBlock.bool(false)
+	noBlock.bool(false)
+	noBlock.bool(false)
+	noBlock.bool(false)
+	writeRecordedMessage(stream, noBlock.Bytes())
+
+	result := &testWriter{}
+	result.varuint32(getBlocksResultIndex)
+	result.blockPosition(12, blockID(12))
+	result.blockPosition(11, blockID(11))
+	result.bool(true)
+	result.blockPosition(10, blockID(10))
+	result.bool(true)
+	result.blockPosition(9, blockID(9))
+	result.bool(true)
+	result.bytes(signedBlock)
+	result.bool(true)
+	result.bytes(transactionTraces(t))
+	result.bool(true)
+	result.bytes(tableDeltas(t))
+	writeRecordedMessage(stream, result.Bytes())
+
+	return stream.Bytes()
+}
+
+func transactionTraces(t *testing.T) []byte {
+	w := &testWriter{}
+	w.varuint32(1)
+
+	w.varuint32(0)
+	w.checksum(t, trxID)
+	w.uint8(0)
+	w.uint32(120)
+	w.varuint32(12)
+	w.uint64(250)
+	w.uint64(96)
+	w.bool(false)
+
+	// Action traces are sent in action ordinal order, the inline action created by the input
+	// action executing after the notification.
+	w.varuint32(3)
+	w.actionTrace(t, 1, 0, 1011, "eosio.token", "eosio.token", "transfer", "hello", "", 128)
+	w.actionTrace(t, 2, 1, 1013, "eosio.token", "eosio.token", "issue", "", "failed", 0)
+	w.actionTrace(t, 3, 1, 1012, "alice", "eosio.token", "transfer", "", "", 0)
+
+	w.bool(true)
+	w.name(t, "alice")
+	w.uint64(128)
+	w.bool(false)
+	w.bool(false)
+	w.bool(false)
+
+	// Partial transaction with a single signature
+	w.bool(true)
+	w.varuint32(0)
+	w.Write(make([]byte, 10))
+	w.varuint32(0)
+	w.uint8(0)
+	w.varuint32(0)
+	w.varuint32(0)
+	w.varuint32(1)
+	w.uint8(0)
+	w.Write(make([]byte, 65))
+	w.varuint32(0)
+
+	return w.Bytes()
+}
+
+func tableDeltas(t *testing.T) []byte {
+	w := &testWriter{}
+	w.varuint32(2)
+
+	w.varuint32(0)
+	w.string("account_metadata")
+	w.varuint32(1)
+	w.bool(true)
+	w.bytes([]byte{0x00, 0x01})
+
+	w.varuint32(0)
+	w.string("contract_row")
+	w.varuint32(2)
+	w.bool(true)
+	w.bytes(contractRow(t, "eosio.token", "alice", "accounts", "eos", "alice", []byte{0xaa}))
+	w.bool(false)
+	w.bytes(contractRow(t, "eosio.token", "bob", "accounts", "eos", "bob", []byte{0xbb}))
+
+	return w.Bytes()
+}
+
+func contractRow(t *testing.T, code, scope, table, primaryKey, payer string, value []byte) []byte {
+	w := &testWriter{}
+	w.varuint32(0)
+	w.name(t, code)
+	w.name(t, scope)
+	w.name(t, table)
+	w.name(t, primaryKey)
+	w.name(t, payer)
+	w.bytes(value)
+
+	return w.Bytes()
+}
+
+func writeRecordedMessage(buffer *bytes.Buffer, message []byte) {
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(message)))
+	buffer.Write(length[:])
+	buffer.Write(message)
+}
+
+func checksum(t *testing.T, id string) eos.Checksum256 {
+	w := &testWriter{}
+	w.checksum(t, id)
+
+	return eos.Checksum256(w.Bytes())
+}
+
+type testWriter struct {
+	bytes.Buffer
+}
+
+func (w *testWriter) varuint32(value uint32) { writeVaruint32(&w.Buffer, value) }
+func (w *testWriter) uint32(value uint32)    { writeUint32(&w.Buffer, value) }
+func (w *testWriter) bool(value bool)        { writeBool(&w.Buffer, value) }
+func (w *testWriter) uint8(value uint8)      { w.WriteByte(value) }
+
+func (w *testWriter) uint64(value uint64) {
+	var data [8]byte
+	binary.LittleEndian.PutUint64(data[:], value)
+	w.Write(data[:])
+}
+
+func (w *testWriter) bytes(value []byte) {
+	w.varuint32(uint32(len(value)))
+	w.Write(value)
+}
+
+func (w *testWriter) string(value string) {
+	w.bytes([]byte(value))
+}
+
+func (w *testWriter) name(t *testing.T, value string) {
+	name, err := eos.StringToName(value)
+	require.NoError(t, err)
+
+	w.uint64(name)
+}
+
+func (w *testWriter) checksum(t *testing.T, value string) {
+	data, err := hex.DecodeString(value)
+	require.NoError(t, err)
+	require.Len(t, data, 32)
+
+	w.Write(data)
+}
+
+func (w *testWriter) blockPosition(num uint32, id string) {
+	w.uint32(num)
+	data, _ := hex.DecodeString(id)
+	w.Write(data)
+}
+
+func (w *testWriter) actionTrace(t *testing.T, ordinal, creatorOrdinal uint32, globalSequence uint64, receiver, account, name, console, except string, ramDelta int64) {
+	w.varuint32(0)
+	w.varuint32(ordinal)
+	w.varuint32(creatorOrdinal)
+
+	w.bool(true)
+	w.varuint32(0)
+	w.name(t, receiver)
+	w.Write(make([]byte, 32))
+	w.uint64(globalSequence)
+	w.uint64(1)
+	w.varuint32(1)
+	w.name(t, "alice")
+	w.uint64(7)
+	w.varuint32(1)
+	w.varuint32(1)
+
+	w.name(t, receiver)
+	w.name(t, account)
+	w.name(t, name)
+	w.varuint32(1)
+	w.name(t, "alice")
+	w.name(t, "active")
+	w.bytes([]byte{0x01, 0x02})
+
+	w.bool(false)
+	w.uint64(42)
+	w.string(console)
+
+	if ramDelta != 0 {
+		w.varuint32(1)
+		w.name(t, "alice")
+		w.uint64(uint64(ramDelta))
+	} else {
+		w.varuint32(0)
+	}
+
+	if except != "" {
+		w.bool(true)
+		w.string(except)
+	} else {
+		w.bool(false)
+	}
+	w.bool(false)
+}
@@ -0,0 +1,389 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ship
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/dfuse-io/dfuse-eosio/codec"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The battlefield recording is a state history plugin stream derived from the battlefield
+// blocks of `filtering/testdata/blocks`, produced by a deep mind instrumented nodeos. Each
+// block is serialized like the state history plugin does, so the signed blocks, receipts,
+// traces and rows are the ones of a real chain. Run with `GOLDEN_UPDATE=true` to regenerate it.
+const battlefieldRecording = "testdata/battlefield.ship"
+
+func TestReader_BattlefieldRecording(t *testing.T) {
+	sourceBlocks := readBattlefieldBlocks(t)
+
+	if os.Getenv("GOLDEN_UPDATE") == "true" {
+		require.NoError(t, ioutil.WriteFile(battlefieldRecording, battlefieldStream(t, sourceBlocks), 0644))
+	}
+
+	file, err := os.Open(battlefieldRecording)
+	require.NoError(t, err)
+
+	reader := NewReader(NewRecordedSource(file))
+	defer reader.Close()
+
+	blockCount := 0
+	for {
+		block, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		source := sourceBlocks[blockCount]
+		blockCount++
+
+		assert.Equal(t, source.Id, block.Id)
+		assert.Equal(t, source.Number, block.Number)
+		assert.True(t, proto.Equal(source.Header, block.Header), "block #%d header", block.Number)
+		assert.Equal(t, source.ProducerSignature, block.ProducerSignature)
+
+		require.Len(t, block.UnfilteredTransactions, len(source.UnfilteredTransactions))
+		for i, receipt := range block.UnfilteredTransactions {
+			assert.True(t, proto.Equal(source.UnfilteredTransactions[i], receipt), "block #%d receipt %d", block.Number, i)
+		}
+
+		require.Len(t, block.UnfilteredTransactionTraces, len(source.UnfilteredTransactionTraces))
+		for i, trace := range block.UnfilteredTransactionTraces {
+			assertTransactionTrace(t, source.UnfilteredTransactionTraces[i], trace)
+		}
+
+		assert.Equal(t, finalRowOps(source), block.UnattributedDbOps, "block #%d rows", block.Number)
+
+		// The block root merkle and the schedules needed to check the producer signature are
+		// not sent by the state history plugin, they are taken from the source block
+		block.BlockrootMerkle = source.BlockrootMerkle
+		block.PendingSchedule = source.PendingSchedule
+		block.ActiveScheduleV1 = source.ActiveScheduleV1
+		block.ActiveScheduleV2 = source.ActiveScheduleV2
+		block.BlockSigningKey = source.BlockSigningKey
+		block.ValidBlockSigningAuthorityV2 = source.ValidBlockSigningAuthorityV2
+		assert.Empty(t, codec.VerifyBlock(block), "block #%d", block.Number)
+	}
+
+	assert.Equal(t, len(sourceBlocks), blockCount)
+}
+
+func assertTransactionTrace(t *testing.T, expected, actual *pbcodec.TransactionTrace) {
+	t.Helper()
+
+	assert.Equal(t, expected.Id, actual.Id)
+	assert.True(t, proto.Equal(expected.Receipt, actual.Receipt), "transaction %s receipt", expected.Id)
+	assert.Equal(t, expected.Elapsed, actual.Elapsed)
+	assert.Equal(t, expected.NetUsage, actual.NetUsage)
+	assert.Equal(t, expected.Scheduled, actual.Scheduled)
+	assert.Equal(t, expected.Exception != nil, actual.Exception != nil)
+
+	require.Len(t, actual.ActionTraces, len(expected.ActionTraces), "transaction %s action traces", expected.Id)
+	for i, actionTrace := range actual.ActionTraces {
+		expectedActionTrace := expected.ActionTraces[i]
+
+		assert.Equal(t, expectedActionTrace.ExecutionIndex, actionTrace.ExecutionIndex)
+		assert.Equal(t, expectedActionTrace.ActionOrdinal, actionTrace.ActionOrdinal)
+		assert.Equal(t, expectedActionTrace.CreatorActionOrdinal, actionTrace.CreatorActionOrdinal)
+		assert.Equal(t, expectedActionTrace.Receiver, actionTrace.Receiver)
+		assert.Equal(t, expectedActionTrace.Action.Account, actionTrace.Action.Account)
+		assert.Equal(t, expectedActionTrace.Action.Name, actionTrace.Action.Name)
+		assert.Equal(t, expectedActionTrace.Action.RawData, actionTrace.Action.RawData)
+		assert.True(t, proto.Equal(expectedActionTrace.Receipt, actionTrace.Receipt), "transaction %s action %d receipt", expected.Id, i)
+		assert.Equal(t, expectedActionTrace.Console, actionTrace.Console)
+	}
+}
+
+func readBattlefieldBlocks(t *testing.T) (out []*pbcodec.Block) {
+	files, err := filepath.Glob(filepath.Join("..", "filtering", "testdata", "blocks", "*.pb"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+
+		block := &pbcodec.Block{}
+		require.NoError(t, proto.Unmarshal(content, block))
+		block.MigrateV0ToV1()
+
+		out = append(out, block)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Number < out[j].Number })
+	return out
+}
+
+// battlefieldStream serializes the blocks as a recorded state history plugin stream, the
+// last block of the stream being its head block.
+func battlefieldStream(t *testing.T, blocks []*pbcodec.Block) []byte {
+	stream := bytes.NewBuffer(nil)
+	writeRecordedMessage(stream, []byte(`{"version":"eosio::abi/1.1","structs":[]}`))
+
+	head := blocks[len(blocks)-1]
+	for _, block := range blocks {
+		result := &testWriter{}
+		result.varuint32(getBlocksResultIndex)
+		result.blockPosition(head.Number, head.Id)
+		result.blockPosition(block.Number-1, block.Header.Previous)
+		result.bool(true)
+		result.blockPosition(block.Number, block.Id)
+		result.bool(true)
+		result.blockPosition(block.Number-1, block.Header.Previous)
+		result.bool(true)
+		result.bytes(signedBlockBytes(t, block))
+		result.bool(true)
+		result.bytes(transactionTracesBytes(t, block.UnfilteredTransactionTraces))
+		result.bool(true)
+		result.bytes(contractRowDeltasBytes(t, finalRowOps(block)))
+		writeRecordedMessage(stream, result.Bytes())
+	}
+
+	return stream.Bytes()
+}
+
+func signedBlockBytes(t *testing.T, block *pbcodec.Block) []byte {
+	w := &testWriter{}
+	w.marshal(t, codec.BlockHeaderToEOS(block.Header))
+	w.marshal(t, signature(t, block.ProducerSignature))
+
+	w.varuint32(uint32(len(block.UnfilteredTransactions)))
+	for _, receipt := range block.UnfilteredTransactions {
+		w.uint8(uint8(codec.TransactionStatusToEOS(receipt.Status)))
+		w.uint32(receipt.CpuUsageMicroSeconds)
+		w.varuint32(receipt.NetUsageWords)
+
+		if receipt.PackedTransaction == nil {
+			w.uint8(0)
+			w.checksum(t, receipt.Id)
+			continue
+		}
+
+		packed := receipt.PackedTransaction
+		w.uint8(1)
+		w.varuint32(uint32(len(packed.Signatures)))
+		for _, sig := range packed.Signatures {
+			w.marshal(t, signature(t, sig))
+		}
+		w.uint8(uint8(packed.Compression))
+		w.bytes(packed.PackedContextFreeData)
+		w.bytes(packed.PackedTransaction)
+	}
+
+	w.marshal(t, codec.ExtensionsToEOS(block.BlockExtensions))
+
+	return w.Bytes()
+}
+
+func transactionTracesBytes(t *testing.T, traces []*pbcodec.TransactionTrace) []byte {
+	w := &testWriter{}
+	w.varuint32(uint32(len(traces)))
+	for _, trace := range traces {
+		w.transactionTrace(t, trace)
+	}
+
+	return w.Bytes()
+}
+
+// finalRowOps returns the state of the rows changed by the block, like the `contract_row`
+// deltas of the state history plugin, in the order they were first changed. Rows created and
+// removed in the block are not part of them.
+func finalRowOps(block *pbcodec.Block) (out []*pbcodec.DBOp) {
+	type row struct {
+		created bool
+		op      *pbcodec.DBOp
+	}
+
+	rows := map[string]*row{}
+	var paths []string
+	for _, trace := range block.UnfilteredTransactionTraces {
+		for _, op := range trace.DbOps {
+			path := op.Code + "/" + op.Scope + "/" + op.TableName + "/" + op.PrimaryKey
+			if rows[path] == nil {
+				rows[path] = &row{created: op.Operation == pbcodec.DBOp_OPERATION_INSERT}
+				paths = append(paths, path)
+			}
+
+			rows[path].op = op
+		}
+	}
+
+	for _, path := range paths {
+		row := rows[path]
+		if row.op.Operation == pbcodec.DBOp_OPERATION_REMOVE {
+			if !row.created {
+				out = append(out, &pbcodec.DBOp{Operation: pbcodec.DBOp_OPERATION_REMOVE, Code: row.op.Code, Scope: row.op.Scope, TableName: row.op.TableName, PrimaryKey: row.op.PrimaryKey, OldPayer: row.op.OldPayer, OldData: row.op.OldData})
+			}
+			continue
+		}
+
+		out = append(out, &pbcodec.DBOp{Operation: pbcodec.DBOp_OPERATION_UPDATE, Code: row.op.Code, Scope: row.op.Scope, TableName: row.op.TableName, PrimaryKey: row.op.PrimaryKey, NewPayer: row.op.NewPayer, NewData: row.op.NewData})
+	}
+
+	return out
+}
+
+func contractRowDeltasBytes(t *testing.T, ops []*pbcodec.DBOp) []byte {
+	w := &testWriter{}
+	if len(ops) == 0 {
+		w.varuint32(0)
+		return w.Bytes()
+	}
+
+	w.varuint32(1)
+	w.varuint32(0)
+	w.string("contract_row")
+	w.varuint32(uint32(len(ops)))
+	for _, op := range ops {
+		if op.Operation == pbcodec.DBOp_OPERATION_REMOVE {
+			w.bool(false)
+			w.bytes(contractRow(t, op.Code, op.Scope, op.TableName, op.PrimaryKey, op.OldPayer, op.OldData))
+		} else {
+			w.bool(true)
+			w.bytes(contractRow(t, op.Code, op.Scope, op.TableName, op.PrimaryKey, op.NewPayer, op.NewData))
+		}
+	}
+
+	return w.Bytes()
+}
+
+func signature(t *testing.T, value string) ecc.Signature {
+	sig, err := ecc.NewSignature(value)
+	require.NoError(t, err)
+
+	return sig
+}
+
+func (w *testWriter) marshal(t *testing.T, value interface{}) {
+	data, err := eos.MarshalBinary(value)
+	require.NoError(t, err)
+
+	w.Write(data)
+}
+
+// transactionTrace writes a `transaction_trace_v0`, its action traces in action ordinal order
+func (w *testWriter) transactionTrace(t *testing.T, trace *pbcodec.TransactionTrace) {
+	w.varuint32(0)
+	w.checksum(t, trace.Id)
+	w.uint8(uint8(codec.TransactionStatusToEOS(trace.Receipt.Status)))
+	w.uint32(trace.Receipt.CpuUsageMicroSeconds)
+	w.varuint32(trace.Receipt.NetUsageWords)
+	w.uint64(uint64(trace.Elapsed))
+	w.uint64(trace.NetUsage)
+	w.bool(trace.Scheduled)
+
+	actionTraces := make([]*pbcodec.ActionTrace, len(trace.ActionTraces))
+	copy(actionTraces, trace.ActionTraces)
+	sort.SliceStable(actionTraces, func(i, j int) bool { return actionTraces[i].ActionOrdinal < actionTraces[j].ActionOrdinal })
+
+	w.varuint32(uint32(len(actionTraces)))
+	for _, actionTrace := range actionTraces {
+		w.fullActionTrace(t, actionTrace)
+	}
+
+	w.bool(false)
+	w.exception(trace.Exception)
+	w.errorCode(trace.ErrorCode)
+
+	if trace.FailedDtrxTrace != nil {
+		w.bool(true)
+		w.transactionTrace(t, trace.FailedDtrxTrace)
+	} else {
+		w.bool(false)
+	}
+
+	w.bool(false)
+}
+
+// fullActionTrace writes an `action_trace_v0`
+func (w *testWriter) fullActionTrace(t *testing.T, actionTrace *pbcodec.ActionTrace) {
+	w.varuint32(0)
+	w.varuint32(actionTrace.ActionOrdinal)
+	w.varuint32(actionTrace.CreatorActionOrdinal)
+
+	if receipt := actionTrace.Receipt; receipt != nil {
+		w.bool(true)
+		w.varuint32(0)
+		w.name(t, receipt.Receiver)
+		w.checksum(t, receipt.Digest)
+		w.uint64(receipt.GlobalSequence)
+		w.uint64(receipt.RecvSequence)
+		w.varuint32(uint32(len(receipt.AuthSequence)))
+		for _, authSequence := range receipt.AuthSequence {
+			w.name(t, authSequence.AccountName)
+			w.uint64(authSequence.Sequence)
+		}
+		w.varuint32(uint32(receipt.CodeSequence))
+		w.varuint32(uint32(receipt.AbiSequence))
+	} else {
+		w.bool(false)
+	}
+
+	w.name(t, actionTrace.Receiver)
+	w.name(t, actionTrace.Action.Account)
+	w.name(t, actionTrace.Action.Name)
+	w.varuint32(uint32(len(actionTrace.Action.Authorization)))
+	for _, authorization := range actionTrace.Action.Authorization {
+		w.name(t, authorization.Actor)
+		w.name(t, authorization.Permission)
+	}
+	w.bytes(actionTrace.Action.RawData)
+
+	w.bool(actionTrace.ContextFree)
+	w.uint64(uint64(actionTrace.Elapsed))
+	w.string(actionTrace.Console)
+
+	w.varuint32(uint32(len(actionTrace.AccountRamDeltas)))
+	for _, delta := range actionTrace.AccountRamDeltas {
+		w.name(t, delta.Account)
+		w.uint64(uint64(delta.Delta))
+	}
+
+	w.exception(actionTrace.Exception)
+	w.errorCode(actionTrace.ErrorCode)
+}
+
+func (w *testWriter) exception(exception *pbcodec.Exception) {
+	if exception == nil {
+		w.bool(false)
+		return
+	}
+
+	w.bool(true)
+	w.string(exception.Message)
+}
+
+func (w *testWriter) errorCode(errorCode uint64) {
+	if errorCode == 0 {
+		w.bool(false)
+		return
+	}
+
+	w.bool(true)
+	w.uint64(errorCode)
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ship
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// Source provides the raw messages of a state history plugin stream, the first one being the
+// protocol ABI (JSON) sent by nodeos on connection, each following one being a serialized
+// `result`. `ReadMessage` returns `io.EOF` once the stream is over.
+type Source interface {
+	ReadMessage() ([]byte, error)
+	Close() error
+}
+
+// NewRecordedSource replays a stream recorded by `NewRecorder`, each message being prefixed by
+// its length as a little-endian uint32.
+func NewRecordedSource(reader io.ReadCloser) Source {
+	return &recordedSource{reader: reader}
+}
+
+type recordedSource struct {
+	reader io.ReadCloser
+}
+
+func (s *recordedSource) ReadMessage() ([]byte, error) {
+	var length [4]byte
+	if _, err := io.ReadFull(s.reader, length[:]); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+
+		return nil, fmt.Errorf("unable to read message length: %w", err)
+	}
+
+	message := make([]byte, binary.LittleEndian.Uint32(length[:]))
+	if _, err := io.ReadFull(s.reader, message); err != nil {
+		return nil, fmt.Errorf("unable to read message of %d byte(s): %w", len(message), err)
+	}
+
+	return message, nil
+}
+
+func (s *recordedSource) Close() error {
+	return s.reader.Close()
+}
+
+// NewRecorder wraps `source`, writing each message read from it to `writer` in the format
+// replayed by `NewRecordedSource`.
+func NewRecorder(source Source, writer io.Writer) Source {
+	return &recorder{Source: source, writer: writer}
+}
+
+type recorder struct {
+	Source
+	writer io.Writer
+}
+
+func (r *recorder) ReadMessage() ([]byte, error) {
+	message, err := r.Source.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(message)))
+	if _, err := r.writer.Write(length[:]); err != nil {
+		return nil, fmt.Errorf("unable to record message length: %w", err)
+	}
+
+	if _, err := r.writer.Write(message); err != nil {
+		return nil, fmt.Errorf("unable to record message: %w", err)
+	}
+
+	return message, nil
+}
+
+// NewWebsocketSource connects to the state history plugin listening at `addr` (i.e.
+// `ws://localhost:8080`) and requests the blocks described by `request`, each result read
+// being acknowledged so that nodeos keeps sending them.
+func NewWebsocketSource(ctx context.Context, addr string, request *GetBlocksRequest) (Source, error) {
+	zlog.Info("connecting to state history plugin", zap.String("addr", addr))
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, addr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to state history plugin at %q: %w", addr, err)
+	}
+
+	_, abi, err := conn.ReadMessage()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to read state history plugin ABI: %w", err)
+	}
+
+	if err := conn.WriteMessage(websocket.BinaryMessage, request.encode()); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to send get blocks request: %w", err)
+	}
+
+	return &websocketSource{conn: conn, abi: abi}, nil
+}
+
+type websocketSource struct {
+	conn *websocket.Conn
+
+	// abi is the first message of the stream, returned by the first `ReadMessage` call
+	abi []byte
+}
+
+func (s *websocketSource) ReadMessage() ([]byte, error) {
+	if s.abi != nil {
+		abi := s.abi
+		s.abi = nil
+
+		return abi, nil
+	}
+
+	_, message, err := s.conn.ReadMessage()
+	if err != nil {
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			return nil, io.EOF
+		}
+
+		return nil, fmt.Errorf("unable to read state history plugin message: %w", err)
+	}
+
+	if err := s.conn.WriteMessage(websocket.BinaryMessage, encodeGetBlocksAck(1)); err != nil {
+		return nil, fmt.Errorf("unable to acknowledge state history plugin message: %w", err)
+	}
+
+	return message, nil
+}
+
+func (s *websocketSource) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,312 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ship
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dfuse-io/dfuse-eosio/codec"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	eos "github.com/eoscanada/eos-go"
+)
+
+// decodeTransactionTraces decodes the serialized `transaction_trace[]` of a block, the block
+// related fields of the traces being left to the caller.
+func decodeTransactionTraces(data []byte) ([]*pbcodec.TransactionTrace, error) {
+	reader := newBinaryReader(data)
+
+	count := reader.count("transaction_traces")
+	traces := make([]*pbcodec.TransactionTrace, 0, count)
+	for i := 0; i < count && reader.err == nil; i++ {
+		traces = append(traces, reader.transactionTrace())
+	}
+
+	if reader.err != nil {
+		return nil, fmt.Errorf("unable to decode transaction traces: %w", reader.err)
+	}
+
+	if reader.remaining() != 0 {
+		return nil, fmt.Errorf("unable to decode transaction traces: %d trailing byte(s)", reader.remaining())
+	}
+
+	return traces, nil
+}
+
+func (r *binaryReader) transactionTrace() *pbcodec.TransactionTrace {
+	r.variant("transaction_trace", 0)
+
+	trace := &pbcodec.TransactionTrace{
+		Id: r.checksum256("transaction_trace.id"),
+		Receipt: &pbcodec.TransactionReceiptHeader{
+			Status:               codec.TransactionStatusToDEOS(eos.TransactionStatus(r.uint8("transaction_trace.status"))),
+			CpuUsageMicroSeconds: r.uint32("transaction_trace.cpu_usage_us"),
+			NetUsageWords:        r.varuint32("transaction_trace.net_usage_words"),
+		},
+		Elapsed:   r.int64("transaction_trace.elapsed"),
+		NetUsage:  r.uint64("transaction_trace.net_usage"),
+		Scheduled: r.bool("transaction_trace.scheduled"),
+	}
+
+	count := r.count("transaction_trace.action_traces")
+	actionTraces := make([]*pbcodec.ActionTrace, 0, count)
+	for i := 0; i < count && r.err == nil; i++ {
+		actionTrace := r.actionTrace()
+		actionTrace.TransactionId = trace.Id
+
+		actionTraces = append(actionTraces, actionTrace)
+	}
+	trace.ActionTraces = sortActionTraces(actionTraces)
+
+	if r.optional("transaction_trace.account_ram_delta") {
+		// Not part of our transaction trace model, the per action deltas are kept
+		r.accountDelta("transaction_trace.account_ram_delta")
+	}
+
+	trace.Exception = r.exception("transaction_trace.except")
+	trace.ErrorCode = r.errorCode("transaction_trace.error_code")
+
+	if r.optional("transaction_trace.failed_dtrx_trace") {
+		trace.FailedDtrxTrace = r.transactionTrace()
+	}
+
+	if r.optional("transaction_trace.partial") {
+		r.skipPartialTransaction()
+	}
+
+	return trace
+}
+
+func (r *binaryReader) actionTrace() *pbcodec.ActionTrace {
+	version := r.variant("action_trace", 1)
+
+	actionTrace := &pbcodec.ActionTrace{
+		ActionOrdinal:        r.varuint32("action_trace.action_ordinal"),
+		CreatorActionOrdinal: r.varuint32("action_trace.creator_action_ordinal"),
+	}
+
+	if r.optional("action_trace.receipt") {
+		actionTrace.Receipt = r.actionReceipt()
+	}
+
+	actionTrace.Receiver = r.name("action_trace.receiver")
+	actionTrace.Action = r.action()
+	actionTrace.ContextFree = r.bool("action_trace.context_free")
+	actionTrace.Elapsed = r.int64("action_trace.elapsed")
+	actionTrace.Console = r.string("action_trace.console")
+
+	count := r.count("action_trace.account_ram_deltas")
+	for i := 0; i < count && r.err == nil; i++ {
+		actionTrace.AccountRamDeltas = append(actionTrace.AccountRamDeltas, r.accountDelta("action_trace.account_ram_deltas"))
+	}
+
+	actionTrace.Exception = r.exception("action_trace.except")
+	actionTrace.ErrorCode = r.errorCode("action_trace.error_code")
+
+	if version == 1 {
+		// The return value of `action_trace_v1` is not part of our action trace model
+		r.bytes("action_trace.return_value")
+	}
+
+	return actionTrace
+}
+
+func (r *binaryReader) actionReceipt() *pbcodec.ActionReceipt {
+	r.variant("action_receipt", 0)
+
+	receipt := &pbcodec.ActionReceipt{
+		Receiver:       r.name("action_receipt.receiver"),
+		Digest:         r.checksum256("action_receipt.act_digest"),
+		GlobalSequence: r.uint64("action_receipt.global_sequence"),
+		RecvSequence:   r.uint64("action_receipt.recv_sequence"),
+	}
+
+	count := r.count("action_receipt.auth_sequence")
+	for i := 0; i < count && r.err == nil; i++ {
+		receipt.AuthSequence = append(receipt.AuthSequence, &pbcodec.AuthSequence{
+			AccountName: r.name("action_receipt.auth_sequence.account"),
+			Sequence:    r.uint64("action_receipt.auth_sequence.sequence"),
+		})
+	}
+
+	receipt.CodeSequence = uint64(r.varuint32("action_receipt.code_sequence"))
+	receipt.AbiSequence = uint64(r.varuint32("action_receipt.abi_sequence"))
+
+	return receipt
+}
+
+func (r *binaryReader) action() *pbcodec.Action {
+	action := &pbcodec.Action{
+		Account: r.name("action.account"),
+		Name:    r.name("action.name"),
+	}
+
+	count := r.count("action.authorization")
+	for i := 0; i < count && r.err == nil; i++ {
+		action.Authorization = append(action.Authorization, &pbcodec.PermissionLevel{
+			Actor:      r.name("action.authorization.actor"),
+			Permission: r.name("action.authorization.permission"),
+		})
+	}
+
+	action.RawData = r.bytes("action.data")
+
+	return action
+}
+
+func (r *binaryReader) accountDelta(what string) *pbcodec.AccountRAMDelta {
+	return &pbcodec.AccountRAMDelta{
+		Account: r.name(what),
+		Delta:   r.int64(what),
+	}
+}
+
+// exception reads an optional exception, the state history plugin only providing its message
+func (r *binaryReader) exception(what string) *pbcodec.Exception {
+	if !r.optional(what) {
+		return nil
+	}
+
+	return &pbcodec.Exception{Message: r.string(what)}
+}
+
+func (r *binaryReader) errorCode(what string) uint64 {
+	if !r.optional(what) {
+		return 0
+	}
+
+	return r.uint64(what)
+}
+
+// skipPartialTransaction skips a `partial_transaction_v0`, the transaction being already
+// available in the block.
+func (r *binaryReader) skipPartialTransaction() {
+	r.variant("partial_transaction", 0)
+	r.next(4+2+4, "partial_transaction.header")
+	r.varuint32("partial_transaction.max_net_usage_words")
+	r.uint8("partial_transaction.max_cpu_usage_ms")
+	r.varuint32("partial_transaction.delay_sec")
+
+	count := r.count("partial_transaction.transaction_extensions")
+	for i := 0; i < count && r.err == nil; i++ {
+		r.uint16("partial_transaction.transaction_extensions.type")
+		r.bytes("partial_transaction.transaction_extensions.data")
+	}
+
+	count = r.count("partial_transaction.signatures")
+	for i := 0; i < count && r.err == nil; i++ {
+		r.skipSignature("partial_transaction.signatures")
+	}
+
+	count = r.count("partial_transaction.context_free_data")
+	for i := 0; i < count && r.err == nil; i++ {
+		r.bytes("partial_transaction.context_free_data")
+	}
+}
+
+// sortActionTraces puts the action traces in execution order, like the deep mind instrumented
+// nodeos does, the state history plugin sending them in action ordinal order.
+func sortActionTraces(actionTraces []*pbcodec.ActionTrace) []*pbcodec.ActionTrace {
+	globalSequence := func(actionTrace *pbcodec.ActionTrace) uint64 {
+		if actionTrace.Receipt != nil && actionTrace.Receipt.GlobalSequence != 0 {
+			return actionTrace.Receipt.GlobalSequence
+		}
+
+		return math.MaxUint64
+	}
+
+	sort.SliceStable(actionTraces, func(i, j int) bool {
+		return globalSequence(actionTraces[i]) < globalSequence(actionTraces[j])
+	})
+
+	for i, actionTrace := range actionTraces {
+		actionTrace.ExecutionIndex = uint32(i)
+	}
+
+	return actionTraces
+}
+
+// decodeContractRowDeltas decodes the `contract_row` table of the serialized `table_delta[]`
+// of a block into database operations. The state history plugin only sends the final state of
+// each row modified in the block, so rows present are reported as updates and absent ones as
+// removals, the old payer and data of updated rows being unknown.
+func decodeContractRowDeltas(data []byte) ([]*pbcodec.DBOp, error) {
+	reader := newBinaryReader(data)
+
+	var dbOps []*pbcodec.DBOp
+	count := reader.count("table_deltas")
+	for i := 0; i < count && reader.err == nil; i++ {
+		reader.variant("table_delta", 0)
+		tableName := reader.string("table_delta.name")
+
+		rowCount := reader.count("table_delta.rows")
+		for j := 0; j < rowCount && reader.err == nil; j++ {
+			present := reader.bool("table_delta.rows.present")
+			rowData := reader.bytes("table_delta.rows.data")
+
+			if tableName != "contract_row" || reader.err != nil {
+				continue
+			}
+
+			dbOp, err := decodeContractRow(rowData, present)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode contract row #%d: %w", j, err)
+			}
+
+			dbOps = append(dbOps, dbOp)
+		}
+	}
+
+	if reader.err != nil {
+		return nil, fmt.Errorf("unable to decode table deltas: %w", reader.err)
+	}
+
+	return dbOps, nil
+}
+
+func decodeContractRow(data []byte, present bool) (*pbcodec.DBOp, error) {
+	reader := newBinaryReader(data)
+	reader.variant("contract_row", 0)
+
+	dbOp := &pbcodec.DBOp{
+		Code:      reader.name("contract_row.code"),
+		Scope:     reader.name("contract_row.scope"),
+		TableName: reader.name("contract_row.table"),
+	}
+
+	primaryKey := reader.uint64("contract_row.primary_key")
+	payer := reader.name("contract_row.payer")
+	value := reader.bytes("contract_row.value")
+
+	if reader.err != nil {
+		return nil, reader.err
+	}
+
+	// Primary keys are reported as names, like deep mind does
+	dbOp.PrimaryKey = eos.NameToString(primaryKey)
+
+	if present {
+		dbOp.Operation = pbcodec.DBOp_OPERATION_UPDATE
+		dbOp.NewPayer = payer
+		dbOp.NewData = value
+	} else {
+		dbOp.Operation = pbcodec.DBOp_OPERATION_REMOVE
+		dbOp.OldPayer = payer
+		dbOp.OldData = value
+	}
+
+	return dbOp, nil
+}
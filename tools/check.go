@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/dfuse-io/bstream"
+	"github.com/dfuse-io/dfuse-eosio/filtering"
 	"github.com/dfuse-io/dfuse-eosio/fluxdb"
 	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
 	"github.com/dfuse-io/dfuse-eosio/trxdb/kv"
@@ -56,6 +57,16 @@ func init() {
 
 	checkTrxdbBlocksCmd.Flags().Int64P("start-block", "s", 0, "Block number to start at")
 	checkTrxdbBlocksCmd.Flags().Int64P("end-block", "e", 4294967296, "Block number to end at")
+	checkTrxdbBlocksCmd.Flags().Bool("deep", false, "Also streams the merged blocks of the range from --blocks-store-url, verifying that every transaction receipt, trace, implicit transaction and deferred transaction operation of irreversible blocks exists in trxdb with matching content hash")
+	checkTrxdbBlocksCmd.Flags().String("blocks-store-url", "", "[DEEP] Merged blocks store URL, required with --deep")
+	checkTrxdbBlocksCmd.Flags().String("chain-id", "", "[DEEP] Chain ID in hex, required with --deep since the public keys stored along transactions are recovered with it")
+	checkTrxdbBlocksCmd.Flags().String("include-filter-expr", "", "[DEEP] CEL program used by trxdb-loader to determine if a given action should be included, must match the one used when loading")
+	checkTrxdbBlocksCmd.Flags().String("exclude-filter-expr", "", "[DEEP] CEL program used by trxdb-loader to determine if an included action should be excluded, must match the one used when loading")
+	checkTrxdbBlocksCmd.Flags().StringSlice("filter-sets", nil, "[DEEP] Named sets usable with the in_set function, each in the form <name>=<path>")
+	checkTrxdbBlocksCmd.Flags().String("filter-schedule-file", "", "[DEEP] YAML filter schedule file, takes precedence over --include-filter-expr and --exclude-filter-expr when set")
+	checkTrxdbBlocksCmd.Flags().StringSlice("always-include", filtering.DefaultAlwaysIncludedActions, "[DEEP] System actions, each in the form <account>:<action>, that are always included regardless of the filter programs")
+	checkTrxdbBlocksCmd.Flags().Bool("strict", false, "[DEEP] Whether trxdb-loader filtered blocks in strict mode")
+	checkTrxdbBlocksCmd.Flags().String("repair-plan-file", "trxdb-repair-plan.json", "[DEEP] File where the repair plan is written when missing or corrupted rows are found, consumed by --trxdb-loader-repair-plan-file")
 }
 
 func checkFluxShardsE(cmd *cobra.Command, args []string) error {
@@ -243,6 +254,15 @@ func checkTrxdbBlocksE(cmd *cobra.Command, args []string) error {
 	startBlock := uint64(viper.GetInt64("start-block"))
 	endBlock := uint64(viper.GetInt64("end-block"))
 
+	deep := viper.GetBool("deep")
+	if deep && viper.GetString("blocks-store-url") == "" {
+		return fmt.Errorf("--blocks-store-url is required with --deep")
+	}
+
+	if deep && viper.GetString("chain-id") == "" {
+		return fmt.Errorf("--chain-id is required with --deep")
+	}
+
 	fmt.Printf("Checking block holes in trxdb at %s, from %d to %d\n", dsn, startBlock, endBlock)
 
 	store, err := store.New(dsn)
@@ -313,5 +333,14 @@ func checkTrxdbBlocksE(cmd *cobra.Command, args []string) error {
 		fmt.Printf("🆗 No hole found\n")
 	}
 
-	return nil
+	if !deep {
+		return nil
+	}
+
+	// The deep check opens the database on its own, some stores cannot be opened twice
+	if err := store.Close(); err != nil {
+		return fmt.Errorf("unable to close store: %w", err)
+	}
+
+	return checkTrxdbBlocksDeep(dsn, startBlock, endBlock)
 }
@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dfuse-io/bstream"
+	"github.com/dfuse-io/dfuse-eosio/codec"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dstore"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var checkBlockIntegrityCmd = &cobra.Command{
+	Use:   "block-integrity {merged-blocks-store-url}",
+	Short: "Verifies block ids, transaction and action merkle roots and producer signatures of a range of merged blocks",
+	Args:  cobra.ExactArgs(1),
+	RunE:  checkBlockIntegrityE,
+}
+
+func init() {
+	checkCmd.AddCommand(checkBlockIntegrityCmd)
+
+	checkBlockIntegrityCmd.Flags().Uint64("start-block", 0, "Block number where to start the verification (inclusive)")
+	checkBlockIntegrityCmd.Flags().Uint64("stop-block", 1000, "Block number where to stop the verification (exclusive)")
+}
+
+func checkBlockIntegrityE(cmd *cobra.Command, args []string) error {
+	startBlock := viper.GetUint64("start-block")
+	stopBlock := viper.GetUint64("stop-block")
+	if stopBlock <= startBlock {
+		return fmt.Errorf("stop block %d must be greater than start block %d", stopBlock, startBlock)
+	}
+
+	blocksStore, err := dstore.NewDBinStore(args[0])
+	if err != nil {
+		return fmt.Errorf("unable to create blocks store: %w", err)
+	}
+
+	var blockCount, filteredBlockCount, invalidBlockCount uint64
+
+	stoppedAt, err := streamMergedBlocks(context.Background(), blocksStore, startBlock, stopBlock, func(blk *bstream.Block) error {
+		block := blk.ToNative().(*pbcodec.Block)
+
+		blockCount++
+		if block.FilteringApplied {
+			filteredBlockCount++
+		}
+
+		issues := codec.VerifyBlock(block)
+		if len(issues) == 0 {
+			return nil
+		}
+
+		invalidBlockCount++
+		fmt.Printf("❌ Block %s has %d integrity issue(s)\n", blk, len(issues))
+		for _, issue := range issues {
+			fmt.Printf("  - %s\n", issue)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to process merged blocks: %w", err)
+	}
+
+	if stoppedAt < stopBlock {
+		fmt.Printf("Base file %010d does not exist, stopped verification before block #%d\n", stoppedAt, stoppedAt)
+	}
+
+	if filteredBlockCount > 0 {
+		fmt.Printf("%d filtered block(s) were only partially verified, their merkle roots cannot be recomputed\n", filteredBlockCount)
+	}
+
+	if invalidBlockCount > 0 {
+		return fmt.Errorf("%d block(s) out of %d failed integrity verification", invalidBlockCount, blockCount)
+	}
+
+	fmt.Printf("🆗 %d block(s) verified, no integrity issue found\n", blockCount)
+	return nil
+}
@@ -0,0 +1,129 @@
+package tools
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dfuse-io/bstream"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	trxdbloader "github.com/dfuse-io/dfuse-eosio/trxdb-loader"
+	"github.com/dfuse-io/dfuse-eosio/trxdb/kv"
+	"github.com/dfuse-io/dstore"
+	"github.com/spf13/viper"
+)
+
+// trxdbIntegrityChecker is implemented by the trxdb drivers able to verify the rows they wrote
+type trxdbIntegrityChecker interface {
+	IsIrreversibleBlock(ctx context.Context, blockID string) (bool, error)
+	CheckBlockIntegrity(ctx context.Context, blk *pbcodec.Block) ([]*kv.IntegrityIssue, error)
+}
+
+// checkTrxdbBlocksDeep verifies the rows of the irreversible merged blocks of the range
+// against trxdb, writing the repair plan of the blocks having missing or corrupted rows.
+func checkTrxdbBlocksDeep(dsn string, startBlock, endBlock uint64) error {
+	blockFilter, err := newToolBlockFilter()
+	if err != nil {
+		return fmt.Errorf("unable to create block filter: %w", err)
+	}
+
+	chainID, err := hex.DecodeString(viper.GetString("chain-id"))
+	if err != nil {
+		return fmt.Errorf("unable to decode chain id: %w", err)
+	}
+
+	blocksStore, err := dstore.NewDBinStore(viper.GetString("blocks-store-url"))
+	if err != nil {
+		return fmt.Errorf("unable to create blocks store: %w", err)
+	}
+
+	db, err := trxdb.New(dsn, trxdb.WithLogger(zlog))
+	if err != nil {
+		return fmt.Errorf("unable to create trxdb: %w", err)
+	}
+	defer db.Close()
+
+	db.SetWriterChainID(chainID)
+
+	checker, ok := db.(trxdbIntegrityChecker)
+	if !ok {
+		return fmt.Errorf("trxdb driver of dsn %q does not support the deep check", dsn)
+	}
+
+	fmt.Printf("Checking trxdb rows against merged blocks, from %d to %d\n", startBlock, endBlock)
+
+	plan := &trxdbloader.RepairPlan{}
+	var blockCount, skippedBlockCount, issueCount uint64
+
+	ctx := context.Background()
+	stopBlock := endBlock + 1
+	stoppedAt, err := streamMergedBlocks(ctx, blocksStore, startBlock, stopBlock, func(blk *bstream.Block) error {
+		if blk.Num()%100000 == 0 {
+			fmt.Println("Checking block", blk.Num())
+		}
+
+		// Rows are written from the blocks filtered by trxdb-loader
+		if err := blockFilter.TransformInPlace(blk); err != nil {
+			return fmt.Errorf("unable to filter block %s: %w", blk, err)
+		}
+
+		block := blk.ToNative().(*pbcodec.Block)
+
+		// Forked blocks are not necessarily in trxdb, holes are reported by the regular check
+		irreversible, err := checker.IsIrreversibleBlock(ctx, block.Id)
+		if err != nil {
+			return err
+		}
+
+		if !irreversible {
+			skippedBlockCount++
+			return nil
+		}
+
+		blockCount++
+		issues, err := checker.CheckBlockIntegrity(ctx, block)
+		if err != nil {
+			return fmt.Errorf("unable to check block %s: %w", blk, err)
+		}
+
+		for _, issue := range issues {
+			issueCount++
+			fmt.Printf("❌ %s\n", issue)
+			plan.Add(issue.BlockNum, issue.BlockID, issue.Table)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to process merged blocks: %w", err)
+	}
+
+	if stoppedAt < stopBlock {
+		fmt.Printf("Base file %010d does not exist, stopped deep check before block #%d\n", stoppedAt, stoppedAt)
+	}
+
+	if skippedBlockCount > 0 {
+		fmt.Printf("%d block(s) not marked irreversible in trxdb were skipped\n", skippedBlockCount)
+	}
+
+	if plan.Empty() {
+		fmt.Printf("🆗 %d irreversible block(s) checked, no missing or corrupted row found\n", blockCount)
+		return nil
+	}
+
+	planFile := viper.GetString("repair-plan-file")
+	if err := plan.Write(planFile); err != nil {
+		return err
+	}
+
+	fmt.Printf("🆘 %d missing or corrupted row(s) found in %d block(s) out of %d irreversible block(s) checked\n", issueCount, len(plan.Blocks), blockCount)
+	fmt.Printf("Repair plan written to %s, covering block range(s):\n", planFile)
+	for _, blockRange := range plan.Ranges() {
+		fmt.Printf("  - #%d - #%d\n", blockRange[0], blockRange[1]-1)
+	}
+
+	fmt.Println("Apply it with trxdb-loader patch mode:")
+	fmt.Printf("  dfuseeos start trxdb-loader --trxdb-loader-processing-type=patch --trxdb-loader-repair-plan-file=%s\n", planFile)
+	return nil
+}
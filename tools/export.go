@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dfuse-io/bstream"
+	"github.com/dfuse-io/dfuse-eosio/export"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dstore"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export {merged-blocks-store-url} {output-store-url}",
+	Short: "Exports a range of merged blocks to blocks, transactions, action_traces, db_ops, ram_ops and perm_ops tables files, partitioned by block range",
+	Args:  cobra.ExactArgs(2),
+	RunE:  exportE,
+}
+
+func init() {
+	Cmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringSlice("formats", []string{string(export.FormatJSONLines), string(export.FormatParquet)}, "Formats of the files to write, any of 'jsonl' (JSON Lines) and 'parquet'")
+	exportCmd.Flags().Uint64("partition-size", 100000, "Number of blocks per partition file, a multiple of 100 is recommended")
+	exportCmd.Flags().Uint64("start-block", 0, "Block number where to start the export (inclusive), should be aligned on --partition-size to produce complete partitions")
+	exportCmd.Flags().Uint64("stop-block", 1000, "Block number where to stop the export (exclusive), should be aligned on --partition-size to produce complete partitions")
+}
+
+func exportE(cmd *cobra.Command, args []string) error {
+	startBlock := viper.GetUint64("start-block")
+	stopBlock := viper.GetUint64("stop-block")
+	if stopBlock <= startBlock {
+		return fmt.Errorf("stop block %d must be greater than start block %d", stopBlock, startBlock)
+	}
+
+	partitionSize := viper.GetUint64("partition-size")
+	if partitionSize == 0 {
+		return fmt.Errorf("partition size must be greater than 0")
+	}
+
+	var formats []export.Format
+	for _, in := range viper.GetStringSlice("formats") {
+		format, err := export.ParseFormat(in)
+		if err != nil {
+			return err
+		}
+
+		formats = append(formats, format)
+	}
+
+	blocksStore, err := dstore.NewDBinStore(args[0])
+	if err != nil {
+		return fmt.Errorf("unable to create blocks store: %w", err)
+	}
+
+	outputStore, err := dstore.NewStore(args[1], "", "", true)
+	if err != nil {
+		return fmt.Errorf("unable to create output store: %w", err)
+	}
+
+	exporter := export.NewExporter(outputStore, formats, partitionSize)
+
+	var blockCount uint64
+	ctx := context.Background()
+	stoppedAt, err := streamMergedBlocks(ctx, blocksStore, startBlock, stopBlock, func(blk *bstream.Block) error {
+		blockCount++
+		return exporter.ProcessBlock(ctx, blk.ToNative().(*pbcodec.Block))
+	})
+	if err != nil {
+		return fmt.Errorf("unable to process merged blocks: %w", err)
+	}
+
+	if stoppedAt < stopBlock {
+		fmt.Printf("Base file %010d does not exist, stopped export before block #%d\n", stoppedAt, stoppedAt)
+	}
+
+	if err := exporter.Close(); err != nil {
+		return fmt.Errorf("unable to complete export: %w", err)
+	}
+
+	fmt.Printf("Exported %d block(s)\n", blockCount)
+	return nil
+}
@@ -0,0 +1,263 @@
+package tools
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/dfuse-io/bstream"
+	"github.com/dfuse-io/dfuse-eosio/filtering"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dstore"
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var filterReportCmd = &cobra.Command{
+	Use:   "filter-report {merged-blocks-store-url}",
+	Short: "Dry-runs filter expressions over a range of merged blocks and reports what would be kept and excluded",
+	Args:  cobra.ExactArgs(1),
+	RunE:  filterReportE,
+}
+
+func init() {
+	Cmd.AddCommand(filterReportCmd)
+
+	filterReportCmd.Flags().String("include-filter-expr", "*", "CEL program to determine if a given action should be included for processing purposes")
+	filterReportCmd.Flags().String("exclude-filter-expr", "", "CEL program to determine if an included action should be excluded")
+	filterReportCmd.Flags().StringSlice("filter-sets", nil, "Named sets usable with the in_set function, each in the form <name>=<path>")
+	filterReportCmd.Flags().String("filter-schedule-file", "", "YAML filter schedule file, takes precedence over --include-filter-expr and --exclude-filter-expr when set")
+	filterReportCmd.Flags().StringSlice("always-include", filtering.DefaultAlwaysIncludedActions, "System actions, each in the form <account>:<action>, that are always included regardless of the filter programs")
+	filterReportCmd.Flags().Bool("strict", false, "Removes the state operations of kept transactions performed by actions that did not match the filter")
+	filterReportCmd.Flags().String("chain-id", "", "Chain ID in hex, required for filter programs using trx.signers")
+	filterReportCmd.Flags().Uint64("start-block", 0, "Block number where to start the report (inclusive)")
+	filterReportCmd.Flags().Uint64("stop-block", 1000, "Block number where to stop the report (exclusive)")
+	filterReportCmd.Flags().Int("top", 50, "Number of actions to print, sorted by decreasing excluded count")
+	filterReportCmd.Flags().Int("sample-count", 10, "Number of excluded transactions to print as samples")
+}
+
+func filterReportE(cmd *cobra.Command, args []string) error {
+	startBlock := viper.GetUint64("start-block")
+	stopBlock := viper.GetUint64("stop-block")
+	if stopBlock <= startBlock {
+		return fmt.Errorf("stop block %d must be greater than start block %d", stopBlock, startBlock)
+	}
+
+	blockFilter, err := newToolBlockFilter()
+	if err != nil {
+		return fmt.Errorf("unable to create block filter: %w", err)
+	}
+
+	blocksStore, err := dstore.NewDBinStore(args[0])
+	if err != nil {
+		return fmt.Errorf("unable to create blocks store: %w", err)
+	}
+
+	report := newFilterReport(viper.GetInt("sample-count"))
+
+	stoppedAt, err := streamMergedBlocks(context.Background(), blocksStore, startBlock, stopBlock, func(blk *bstream.Block) error {
+		return report.process(blockFilter, blk)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to process merged blocks: %w", err)
+	}
+
+	if stoppedAt < stopBlock {
+		fmt.Printf("Base file %010d does not exist, stopped report before block #%d\n", stoppedAt, stoppedAt)
+	}
+
+	report.print(viper.GetInt("top"))
+	return nil
+}
+
+// newToolBlockFilter creates the block filter defined by the filtering flags of the running
+// command, the flags the command does not define being treated as unset.
+func newToolBlockFilter() (*filtering.BlockFilter, error) {
+	if err := filtering.LoadNamedSetFiles(viper.GetStringSlice("filter-sets")); err != nil {
+		return nil, err
+	}
+
+	alwaysIncluded := filtering.WithAlwaysIncludedActions(viper.GetStringSlice("always-include"))
+
+	var blockFilter *filtering.BlockFilter
+	var err error
+
+	scheduleFile := viper.GetString("filter-schedule-file")
+	if scheduleFile == "" {
+		blockFilter, err = filtering.NewBlockFilter(viper.GetString("include-filter-expr"), viper.GetString("exclude-filter-expr"), alwaysIncluded)
+	} else {
+		var schedule filtering.FilterSchedule
+		schedule, err = filtering.LoadFilterSchedule(scheduleFile)
+		if err != nil {
+			return nil, err
+		}
+
+		blockFilter, err = filtering.NewScheduledBlockFilter(schedule, alwaysIncluded)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if chainID := viper.GetString("chain-id"); chainID != "" {
+		hexChainID, err := hex.DecodeString(chainID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode chain id %q: %w", chainID, err)
+		}
+
+		blockFilter.SetChainID(hexChainID)
+	}
+
+	blockFilter.SetStrict(viper.GetBool("strict"))
+	return blockFilter, nil
+}
+
+type filterReport struct {
+	blockCount uint64
+
+	trxTraceCount     uint64
+	keptTrxTraceCount uint64
+
+	actionCount        uint64
+	matchedActionCount uint64
+
+	originalBytes uint64
+	filteredBytes uint64
+
+	actions map[string]*filterReportActionStats
+
+	maxSampleCount int
+	samples        []*filterReportSample
+}
+
+type filterReportActionStats struct {
+	name     string
+	matched  uint64
+	excluded uint64
+}
+
+type filterReportSample struct {
+	blockNum uint64
+	trxID    string
+	actions  []string
+}
+
+func newFilterReport(maxSampleCount int) *filterReport {
+	return &filterReport{
+		actions:        map[string]*filterReportActionStats{},
+		maxSampleCount: maxSampleCount,
+	}
+}
+
+func (r *filterReport) process(blockFilter *filtering.BlockFilter, blk *bstream.Block) error {
+	r.blockCount++
+
+	block := blk.ToNative().(*pbcodec.Block)
+	r.originalBytes += uint64(proto.Size(block))
+
+	// We keep a reference to all traces prior filtering, the filtering only removes
+	// them from the block and flags the action traces that matched the filter.
+	trxTraces := block.TransactionTraces()
+
+	if err := blockFilter.TransformInPlace(blk); err != nil {
+		return err
+	}
+
+	// When the filter version is a no-op for this block, the block is kept untouched
+	filteringApplied := block.FilteringApplied
+	r.filteredBytes += uint64(proto.Size(block))
+
+	keptTrxTraces := map[string]bool{}
+	for _, trxTrace := range block.TransactionTraces() {
+		keptTrxTraces[trxTrace.Id] = true
+	}
+
+	for _, trxTrace := range trxTraces {
+		r.trxTraceCount++
+		kept := keptTrxTraces[trxTrace.Id]
+		if kept {
+			r.keptTrxTraceCount++
+		}
+
+		actTraces := trxTrace.ActionTraces
+		if trxTrace.FailedDtrxTrace != nil {
+			actTraces = append(actTraces[:len(actTraces):len(actTraces)], trxTrace.FailedDtrxTrace.ActionTraces...)
+		}
+
+		for _, actTrace := range actTraces {
+			r.actionCount++
+
+			stats, found := r.actions[actTrace.FullName()]
+			if !found {
+				stats = &filterReportActionStats{name: actTrace.FullName()}
+				r.actions[actTrace.FullName()] = stats
+			}
+
+			if !filteringApplied || actTrace.FilteringMatched {
+				r.matchedActionCount++
+				stats.matched++
+			} else {
+				stats.excluded++
+			}
+		}
+
+		if !kept && len(r.samples) < r.maxSampleCount {
+			sample := &filterReportSample{blockNum: block.Num(), trxID: trxTrace.Id}
+			for _, actTrace := range actTraces {
+				sample.actions = append(sample.actions, actTrace.FullName())
+			}
+
+			r.samples = append(r.samples, sample)
+		}
+	}
+
+	return nil
+}
+
+func (r *filterReport) print(top int) {
+	fmt.Printf("Processed %d blocks\n", r.blockCount)
+	fmt.Printf("Transactions: %d/%d kept (%d excluded)\n", r.keptTrxTraceCount, r.trxTraceCount, r.trxTraceCount-r.keptTrxTraceCount)
+	fmt.Printf("Actions: %d/%d matched (%d excluded)\n", r.matchedActionCount, r.actionCount, r.actionCount-r.matchedActionCount)
+	fmt.Printf("Bytes: %d -> %d (%d saved, %.2f%%)\n", r.originalBytes, r.filteredBytes, int64(r.originalBytes)-int64(r.filteredBytes), percentOf(int64(r.originalBytes)-int64(r.filteredBytes), r.originalBytes))
+
+	actions := make([]*filterReportActionStats, 0, len(r.actions))
+	for _, stats := range r.actions {
+		actions = append(actions, stats)
+	}
+
+	sort.Slice(actions, func(i, j int) bool {
+		if actions[i].excluded == actions[j].excluded {
+			return actions[i].name < actions[j].name
+		}
+
+		return actions[i].excluded > actions[j].excluded
+	})
+
+	if top >= 0 && len(actions) > top {
+		actions = actions[:top]
+	}
+
+	fmt.Println()
+	fmt.Printf("%-60s %12s %12s\n", "Action (receiver:account:action)", "Matched", "Excluded")
+	for _, stats := range actions {
+		fmt.Printf("%-60s %12d %12d\n", stats.name, stats.matched, stats.excluded)
+	}
+
+	if len(r.samples) > 0 {
+		fmt.Println()
+		fmt.Println("Excluded transactions samples")
+		for _, sample := range r.samples {
+			fmt.Printf("- #%d %s %v\n", sample.blockNum, sample.trxID, sample.actions)
+		}
+	}
+}
+
+func percentOf(value int64, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(value) * 100 / float64(total)
+}
@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/dfuse-io/bstream"
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	"github.com/dfuse-io/dfuse-eosio/filtering"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterReport(t *testing.T) {
+	newBlock := func() *bstream.Block {
+		return ct.NewBlock(10).
+			Trx("trx1").Action("spamcoin", "transfer", nil).
+			Trx("trx2").Action("eosio.token", "transfer", nil).Inline("spamcoin", "transfer", nil).
+			ToBstreamBlock(t)
+	}
+
+	blockFilter, err := filtering.NewBlockFilter("", "receiver == 'spamcoin'")
+	require.NoError(t, err)
+
+	report := newFilterReport(10)
+	require.NoError(t, report.process(blockFilter, newBlock()))
+
+	assert.Equal(t, uint64(1), report.blockCount)
+	assert.Equal(t, uint64(2), report.trxTraceCount)
+	assert.Equal(t, uint64(1), report.keptTrxTraceCount)
+	assert.Equal(t, uint64(3), report.actionCount)
+	assert.Equal(t, uint64(1), report.matchedActionCount)
+
+	assert.Equal(t, &filterReportActionStats{name: "spamcoin:spamcoin:transfer", excluded: 2}, report.actions["spamcoin:spamcoin:transfer"])
+	assert.Equal(t, &filterReportActionStats{name: "eosio.token:eosio.token:transfer", matched: 1}, report.actions["eosio.token:eosio.token:transfer"])
+
+	require.Len(t, report.samples, 1)
+	assert.Equal(t, &filterReportSample{blockNum: 10, trxID: "trx1", actions: []string{"spamcoin:spamcoin:transfer"}}, report.samples[0])
+
+	assert.Greater(t, report.filteredBytes, uint64(0))
+	assert.Less(t, report.filteredBytes, report.originalBytes)
+}
+
+func TestFilterReport_NoopFilterSavesNoBytes(t *testing.T) {
+	blockFilter, err := filtering.NewBlockFilter("", "")
+	require.NoError(t, err)
+
+	report := newFilterReport(10)
+	require.NoError(t, report.process(blockFilter, ct.NewBlock(10).Trx("trx1").Action("eosio.token", "transfer", nil).ToBstreamBlock(t)))
+
+	assert.Equal(t, uint64(1), report.matchedActionCount)
+	assert.Equal(t, report.originalBytes, report.filteredBytes)
+}
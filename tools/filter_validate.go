@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dfuse-io/dfuse-eosio/abicodec"
+	"github.com/dfuse-io/dfuse-eosio/filtering"
+	"github.com/dfuse-io/dstore"
+	"github.com/eoscanada/eos-go"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var filterValidateCmd = &cobra.Command{
+	Use:   "filter-validate",
+	Short: "Statically validates filter expressions, reporting unknown data fields, mismatched types and always true/false subexpressions",
+	Args:  cobra.NoArgs,
+	RunE:  filterValidateE,
+}
+
+func init() {
+	Cmd.AddCommand(filterValidateCmd)
+
+	filterValidateCmd.Flags().String("include-filter-expr", "*", "CEL program to determine if a given action should be included for processing purposes")
+	filterValidateCmd.Flags().String("exclude-filter-expr", "", "CEL program to determine if an included action should be excluded")
+	filterValidateCmd.Flags().String("filter-schedule-file", "", "YAML filter schedule file, takes precedence over --include-filter-expr and --exclude-filter-expr when set")
+	filterValidateCmd.Flags().String("abi-cache-base-url", "", "Store URL of the abicodec cache used to validate data fields, like the --abicodec-cache-base-url of abicodec")
+	filterValidateCmd.Flags().String("abi-cache-file-name", "abicodec_cache.bin", "File name of the abicodec cache in --abi-cache-base-url")
+	filterValidateCmd.Flags().String("fluxdb-addr", "", "HTTP address of fluxdb used to retrieve the ABIs missing from --abi-cache-base-url, like http://localhost:13029")
+}
+
+func filterValidateE(cmd *cobra.Command, args []string) error {
+	blockFilter, err := newToolBlockFilter()
+	if err != nil {
+		return fmt.Errorf("unable to create block filter: %w", err)
+	}
+
+	getABI, err := newFilterValidateABIGetter()
+	if err != nil {
+		return err
+	}
+
+	issues, err := blockFilter.Validate(getABI)
+	if err != nil {
+		return fmt.Errorf("unable to validate filter: %w", err)
+	}
+
+	if getABI == nil {
+		fmt.Println("No ABI source configured, data fields were not validated")
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("No issue found")
+		return nil
+	}
+
+	for _, issue := range issues {
+		fmt.Printf("- %s\n", issue)
+	}
+
+	return fmt.Errorf("found %d issue(s)", len(issues))
+}
+
+// newFilterValidateABIGetter returns the ABI getter reading the abicodec cache of
+// `--abi-cache-base-url`, falling back to the fluxdb of `--fluxdb-addr` for the accounts
+// missing from the cache, `nil` when none of them is set.
+func newFilterValidateABIGetter() (filtering.ABIGetter, error) {
+	var cacheGetter, fluxdbGetter filtering.ABIGetter
+	if cacheBaseURL := viper.GetString("abi-cache-base-url"); cacheBaseURL != "" {
+		store, err := dstore.NewSimpleStore(cacheBaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create abi cache store: %w", err)
+		}
+
+		cache, err := abicodec.NewABICache(store, viper.GetString("abi-cache-file-name"))
+		if err != nil {
+			return nil, fmt.Errorf("unable to load abi cache: %w", err)
+		}
+
+		cacheGetter = func(account string) (*eos.ABI, error) {
+			item := cache.ABIAtBlockNum(account, math.MaxUint32)
+			if item == nil {
+				return nil, nil
+			}
+
+			return item.ABI, nil
+		}
+	}
+
+	if fluxdbAddr := viper.GetString("fluxdb-addr"); fluxdbAddr != "" {
+		fluxdbGetter = filtering.NewFluxDBABIGetter(fluxdbAddr)
+	}
+
+	return filtering.FirstABIGetter(cacheGetter, fluxdbGetter), nil
+}
@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/dfuse-io/bstream"
+	"github.com/dfuse-io/dstore"
+	"go.uber.org/zap"
+)
+
+// streamMergedBlocks runs `process` on each merged block of `store` in the [startBlock, stopBlock)
+// range, in order, the blocks being read through a `bstream.FileSource`.
+//
+// Instead of waiting for it to appear, streaming stops at the first missing merged blocks file of
+// the range once all the blocks of the files before it were processed. The returned `stoppedAt` is
+// the first block of that missing file, or `stopBlock` when the whole range was processed.
+func streamMergedBlocks(ctx context.Context, store dstore.Store, startBlock, stopBlock uint64, process func(blk *bstream.Block) error) (stoppedAt uint64, err error) {
+	streamer := &mergedBlocksStreamer{Store: store, stoppedAt: stopBlock}
+
+	streamer.source = bstream.NewFileSource(streamer, startBlock, 2, streamer.preprocess, bstream.HandlerFunc(func(blk *bstream.Block, obj interface{}) error {
+		if blk.Num() >= stopBlock {
+			streamer.source.Shutdown(nil)
+			return nil
+		}
+
+		if err := process(blk); err != nil {
+			return err
+		}
+
+		streamer.processed()
+		return nil
+	}), bstream.FileSourceWithLogger(zlog))
+	streamer.source.SetNotFoundCallback(streamer.fileNotFound)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			streamer.source.Shutdown(ctx.Err())
+		case <-streamer.source.Terminating():
+		}
+	}()
+
+	streamer.source.Run()
+	if err := streamer.source.Err(); err != nil {
+		return 0, err
+	}
+
+	streamer.lock.Lock()
+	defer streamer.lock.Unlock()
+
+	return streamer.stoppedAt, nil
+}
+
+// mergedBlocksStreamer wraps the store read by the file source to know when all the blocks of
+// the files found before a missing one were processed, the file source itself waiting forever
+// for missing files.
+type mergedBlocksStreamer struct {
+	dstore.Store
+
+	source *bstream.FileSource
+
+	lock               sync.Mutex
+	missingFile        bool
+	stoppedAt          uint64
+	foundFileCount     int
+	closedFileCount    int
+	preprocessedBlocks int
+	processedBlocks    int
+}
+
+func (s *mergedBlocksStreamer) FileExists(ctx context.Context, base string) (bool, error) {
+	exists, err := s.Store.FileExists(ctx, base)
+	if exists {
+		s.lock.Lock()
+		s.foundFileCount++
+		s.lock.Unlock()
+	}
+
+	return exists, err
+}
+
+func (s *mergedBlocksStreamer) OpenObject(ctx context.Context, name string) (io.ReadCloser, error) {
+	reader, err := s.Store.OpenObject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mergedBlocksFileReader{ReadCloser: reader, streamer: s}, nil
+}
+
+func (s *mergedBlocksStreamer) preprocess(blk *bstream.Block) (interface{}, error) {
+	s.lock.Lock()
+	s.preprocessedBlocks++
+	s.lock.Unlock()
+
+	return nil, nil
+}
+
+func (s *mergedBlocksStreamer) processed() {
+	s.lock.Lock()
+	s.processedBlocks++
+	s.lock.Unlock()
+
+	s.shutdownWhenDrained()
+}
+
+// fileNotFound is called by the file source each time it looks for a missing file, which only
+// happens once all the files before it were found.
+func (s *mergedBlocksStreamer) fileNotFound(missingBlock uint64) {
+	// The file source reports the first streamable block of the chain for the first file
+	baseBlock := missingBlock - (missingBlock % 100)
+
+	s.lock.Lock()
+	s.missingFile = true
+	if baseBlock < s.stoppedAt {
+		zlog.Debug("merged blocks file not found, stopping once previous blocks are processed", zap.Uint64("base_block", baseBlock))
+		s.stoppedAt = baseBlock
+	}
+	s.lock.Unlock()
+
+	s.shutdownWhenDrained()
+}
+
+func (s *mergedBlocksStreamer) shutdownWhenDrained() {
+	s.lock.Lock()
+	// A found file is closed once all its blocks were preprocessed and queued to the handler
+	drained := s.missingFile && s.closedFileCount == s.foundFileCount && s.processedBlocks == s.preprocessedBlocks
+	s.lock.Unlock()
+
+	if drained {
+		s.source.Shutdown(nil)
+	}
+}
+
+type mergedBlocksFileReader struct {
+	io.ReadCloser
+	streamer *mergedBlocksStreamer
+}
+
+func (r *mergedBlocksFileReader) Close() error {
+	r.streamer.lock.Lock()
+	r.streamer.closedFileCount++
+	r.streamer.lock.Unlock()
+
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dfuse-io/bstream"
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	"github.com/dfuse-io/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamMergedBlocks(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	store.SetFile("0000000100", ct.MergedBlocksFile(t, ct.NewBlock(100).Build(t), ct.NewBlock(101).Build(t), ct.NewBlock(102).Build(t)))
+	store.SetFile("0000000200", ct.MergedBlocksFile(t, ct.NewBlock(200).Build(t), ct.NewBlock(201).Build(t)))
+
+	tests := []struct {
+		name              string
+		startBlock        uint64
+		stopBlock         uint64
+		expectedBlocks    []uint64
+		expectedStoppedAt uint64
+	}{
+		{"stops at missing file", 101, 1000, []uint64{101, 102, 200, 201}, 300},
+		{"stops at stop block", 101, 201, []uint64{101, 102, 200}, 201},
+		{"stops at stop block aligned on missing file", 100, 300, []uint64{100, 101, 102, 200, 201}, 300},
+		{"starts in missing file", 300, 1000, nil, 300},
+		{"missing first file", 0, 1000, nil, 0},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var blocks []uint64
+			stoppedAt, err := streamMergedBlocks(context.Background(), store, test.startBlock, test.stopBlock, func(blk *bstream.Block) error {
+				blocks = append(blocks, blk.Num())
+				return nil
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBlocks, blocks)
+			assert.Equal(t, test.expectedStoppedAt, stoppedAt)
+		})
+	}
+}
+
+func TestStreamMergedBlocks_ProcessError(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	store.SetFile("0000000100", ct.MergedBlocksFile(t, ct.NewBlock(100).Build(t), ct.NewBlock(101).Build(t)))
+
+	_, err := streamMergedBlocks(context.Background(), store, 100, 200, func(blk *bstream.Block) error {
+		return assert.AnError
+	})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dfuse-io/dfuse-eosio/codec"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var readDeepMindCmd = &cobra.Command{
+	Use:   "read-deep-mind {deep-mind-log-file}",
+	Short: "Reads a nodeos deep-mind log file ('-' for standard input) printing the blocks it contains, along with the blocks undone by each fork switch",
+	Args:  cobra.ExactArgs(1),
+	RunE:  readDeepMindE,
+}
+
+func init() {
+	Cmd.AddCommand(readDeepMindCmd)
+
+	readDeepMindCmd.Flags().Bool("fork-aware", true, "Report the blocks undone by fork switches, failing when the fork point of a switch is not among the reversible blocks read")
+}
+
+func readDeepMindE(cmd *cobra.Command, args []string) error {
+	var input io.Reader = os.Stdin
+	if args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("unable to open deep-mind log file: %w", err)
+		}
+		defer file.Close()
+
+		input = file
+	}
+
+	var opts []codec.ConsoleReaderOption
+	if viper.GetBool("fork-aware") {
+		opts = append(opts, codec.WithForkAwareness())
+	}
+
+	reader, err := codec.NewConsoleReader(input, opts...)
+	if err != nil {
+		return fmt.Errorf("unable to create console reader: %w", err)
+	}
+
+	var blockCount, forkSwitchCount, undoneBlockCount uint64
+	for {
+		out, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to read deep-mind log: %w", err)
+		}
+
+		switch v := out.(type) {
+		case *pbcodec.Block:
+			blockCount++
+			fmt.Printf("Block %s (%d transaction trace(s), LIB #%d)\n", v.AsRef(), len(v.UnfilteredTransactionTraces), v.LIBNum())
+
+		case *codec.ForkSwitch:
+			forkSwitchCount++
+			undoneBlockCount += uint64(len(v.UndoneBlocks))
+
+			fmt.Printf("Fork switch to block %s, fork point %s\n", v.ToBlockID, v.ForkPoint)
+			for _, undone := range v.UndoneBlocks {
+				fmt.Printf("  Undone block %s\n", undone)
+			}
+		}
+	}
+
+	fmt.Printf("Read %d block(s), %d fork switch(es) undoing %d block(s)\n", blockCount, forkSwitchCount, undoneBlockCount)
+	return nil
+}
@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/dfuse-io/bstream"
+	"github.com/dfuse-io/dfuse-eosio/codec"
+	"github.com/dfuse-io/dfuse-eosio/ship"
+	"github.com/dfuse-io/dstore"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var shipToMergedBlocksCmd = &cobra.Command{
+	Use:   "ship-to-merged-blocks {state-history-addr|recorded-file} {merged-blocks-store-url}",
+	Short: "Reads irreversible blocks from a nodeos state history plugin (i.e. 'ws://localhost:8080') or a recorded stream file, writing them as merged blocks files",
+	Long: `Reads irreversible blocks from a nodeos state history plugin (i.e. 'ws://localhost:8080') or a recorded
+stream file, writing them as merged blocks files.
+
+The state history plugin does not provide everything the deep mind instrumentation does, the fields
+left empty being listed in the 'unavailable_fields' of each block. Notably, transaction traces have no
+creation tree and no RAM operations, and database operations cannot be attributed to transactions.
+`,
+	Args: cobra.ExactArgs(2),
+	RunE: shipToMergedBlocksE,
+}
+
+func init() {
+	Cmd.AddCommand(shipToMergedBlocksCmd)
+
+	shipToMergedBlocksCmd.Flags().Uint64("start-block", 0, "Block number where to start reading (inclusive), should be aligned on a 100-blocks boundary to produce complete merged blocks files")
+	shipToMergedBlocksCmd.Flags().Uint64("stop-block", 0, "Block number where to stop reading (exclusive), 0 to read until the stream ends")
+	shipToMergedBlocksCmd.Flags().String("record-to", "", "When reading from a state history plugin, also record the stream to this file so it can be replayed later")
+}
+
+func shipToMergedBlocksE(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	startBlock := viper.GetUint64("start-block")
+	stopBlock := viper.GetUint64("stop-block")
+	if stopBlock == 0 || stopBlock > math.MaxUint32 {
+		stopBlock = math.MaxUint32
+	}
+
+	if stopBlock <= startBlock {
+		return fmt.Errorf("stop block %d must be greater than start block %d", stopBlock, startBlock)
+	}
+
+	source, err := newShipSource(ctx, args[0], &ship.GetBlocksRequest{
+		StartBlockNum:       uint32(startBlock),
+		EndBlockNum:         uint32(stopBlock),
+		MaxMessagesInFlight: 100,
+		IrreversibleOnly:    true,
+	})
+	if err != nil {
+		return err
+	}
+
+	if recordTo := viper.GetString("record-to"); recordTo != "" {
+		file, err := os.Create(recordTo)
+		if err != nil {
+			return fmt.Errorf("unable to create record file: %w", err)
+		}
+		defer file.Close()
+
+		source = ship.NewRecorder(source, file)
+	}
+
+	reader := ship.NewReader(source)
+	defer reader.Close()
+
+	blocksStore, err := dstore.NewDBinStore(args[1])
+	if err != nil {
+		return fmt.Errorf("unable to create blocks store: %w", err)
+	}
+
+	var bundle []*bstream.Block
+	var blockCount, fileCount uint64
+	for {
+		block, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to read state history stream: %w", err)
+		}
+
+		blockNum := uint64(block.Number)
+		if blockNum < startBlock {
+			continue
+		}
+
+		if blockNum >= stopBlock {
+			break
+		}
+
+		if len(bundle) > 0 && bundle[0].Num()/100 != blockNum/100 {
+			fmt.Printf("Discarding %d block(s) of incomplete merged blocks file %010d\n", len(bundle), bundle[0].Num()-bundle[0].Num()%100)
+			bundle = nil
+		}
+
+		blk, err := codec.BlockFromProto(block)
+		if err != nil {
+			return fmt.Errorf("unable to convert block %s: %w", block.AsRef(), err)
+		}
+
+		blockCount++
+		bundle = append(bundle, blk)
+
+		if blockNum%100 == 99 {
+			if err := writeMergedBlocksFile(ctx, blocksStore, bundle); err != nil {
+				return err
+			}
+
+			fileCount++
+			bundle = nil
+		}
+	}
+
+	if len(bundle) > 0 {
+		fmt.Printf("Discarding %d block(s) of incomplete merged blocks file %010d\n", len(bundle), bundle[0].Num()-bundle[0].Num()%100)
+	}
+
+	fmt.Printf("Read %d block(s), wrote %d merged blocks file(s)\n", blockCount, fileCount)
+	return nil
+}
+
+func newShipSource(ctx context.Context, addr string, request *ship.GetBlocksRequest) (ship.Source, error) {
+	if strings.HasPrefix(addr, "ws://") || strings.HasPrefix(addr, "wss://") {
+		return ship.NewWebsocketSource(ctx, addr, request)
+	}
+
+	file, err := os.Open(addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open recorded stream file: %w", err)
+	}
+
+	return ship.NewRecordedSource(file), nil
+}
+
+func writeMergedBlocksFile(ctx context.Context, store dstore.Store, blocks []*bstream.Block) error {
+	baseNum := blocks[0].Num() - blocks[0].Num()%100
+
+	buffer := bytes.NewBuffer(nil)
+	writer, err := codec.NewBlockWriter(buffer)
+	if err != nil {
+		return fmt.Errorf("unable to create block writer: %w", err)
+	}
+
+	for _, block := range blocks {
+		if err := writer.Write(block); err != nil {
+			return fmt.Errorf("unable to write block #%d: %w", block.Num(), err)
+		}
+	}
+
+	baseFile := fmt.Sprintf("%010d", baseNum)
+	if err := store.WriteObject(ctx, baseFile, buffer); err != nil {
+		return fmt.Errorf("unable to write merged blocks file %s: %w", baseFile, err)
+	}
+
+	fmt.Printf("Wrote merged blocks file %s (%d block(s))\n", baseFile, len(blocks))
+	return nil
+}
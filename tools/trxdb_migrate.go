@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dfuse-io/dfuse-eosio/trxdb/kv"
+	"github.com/dfuse-io/kvdb/store"
+	_ "github.com/dfuse-io/kvdb/store/badger"
+	_ "github.com/dfuse-io/kvdb/store/bigkv"
+	_ "github.com/dfuse-io/kvdb/store/tikv"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var trxdbCmd = &cobra.Command{Use: "trxdb", Short: "trxdb maintenance operations"}
+var trxdbMigrateCmd = &cobra.Command{
+	Use:   "migrate {source-dsn} {destination-dsn}",
+	Short: "Copies every virtual table of a trxdb to another one, then verifies the copy by comparing row counts and sampled values",
+	Long: `Copies every virtual table of a trxdb to another one, then verifies the copy by comparing row counts and sampled values.
+
+Tables are copied as raw key ranges, so both DSNs must point to a single kv store (badger, tikv or bigkv).
+Split DSNs (many space separated DSNs) and the SQL driver are not supported.`,
+	Args: cobra.ExactArgs(2),
+	RunE: trxdbMigrateE,
+}
+
+func init() {
+	Cmd.AddCommand(trxdbCmd)
+	trxdbCmd.AddCommand(trxdbMigrateCmd)
+
+	trxdbMigrateCmd.Flags().StringSlice("tables", nil, "Tables to migrate, all of them when empty (blocks, irr_blocks, trxs, trx_traces, implicit_trxs, dtrxs, accounts, account_history, timeline_fwd, timeline_bck)")
+	trxdbMigrateCmd.Flags().Int("chunks", 16, "Number of key-range chunks each table is split in, must stay the same when resuming a migration")
+	trxdbMigrateCmd.Flags().Int("parallelism", 4, "Number of chunks scanned or verified in parallel, the rows being written to the destination one batch at a time")
+	trxdbMigrateCmd.Flags().Int("batch-size", 1000, "Number of rows written between each flush and checkpoint")
+	trxdbMigrateCmd.Flags().String("checkpoint-dir", "", "Directory where the progress of each chunk is recorded, re-running the migration with the same directory resumes it")
+	trxdbMigrateCmd.Flags().Uint64("sample-every", 1000, "Verification compares the value of one row out of this many")
+	trxdbMigrateCmd.Flags().Bool("verify-only", false, "Only run the verification pass")
+}
+
+func trxdbMigrateE(cmd *cobra.Command, args []string) error {
+	source, err := newTrxdbMigrationStore(args[0])
+	if err != nil {
+		return fmt.Errorf("unable to open source: %w", err)
+	}
+	defer source.Close()
+
+	destination, err := newTrxdbMigrationStore(args[1])
+	if err != nil {
+		return fmt.Errorf("unable to open destination: %w", err)
+	}
+	defer destination.Close()
+
+	opts := []kv.MigrationOption{
+		kv.WithMigrationChunks(viper.GetInt("chunks"), viper.GetInt("parallelism")),
+		kv.WithMigrationBatchSize(viper.GetInt("batch-size")),
+		kv.WithMigrationCheckpointDir(viper.GetString("checkpoint-dir")),
+		kv.WithMigrationSampling(viper.GetUint64("sample-every")),
+	}
+
+	if tables := viper.GetStringSlice("tables"); len(tables) > 0 {
+		opts = append(opts, kv.WithMigrationTables(tables...))
+	}
+
+	migration, err := kv.NewMigration(source, destination, opts...)
+	if err != nil {
+		return fmt.Errorf("invalid migration: %w", err)
+	}
+
+	if !viper.GetBool("verify-only") {
+		fmt.Printf("Migrating trxdb from %s to %s\n", args[0], args[1])
+		rowCounts, err := migration.Run(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+
+		var tables []string
+		for table := range rowCounts {
+			tables = append(tables, table)
+		}
+		sort.Strings(tables)
+
+		for _, table := range tables {
+			fmt.Printf("Copied %d row(s) of table %s\n", rowCounts[table], table)
+		}
+	}
+
+	fmt.Println("Verifying migration")
+	verifications, err := migration.Verify(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+
+	failed := false
+	for _, verification := range verifications {
+		if verification.Ok() {
+			fmt.Printf("✅ Table %s has %d row(s) in both databases, %d sampled value(s) match\n", verification.Table, verification.SourceCount, verification.SampleCount)
+			continue
+		}
+
+		failed = true
+		fmt.Printf("❌ Table %s has %d row(s) in source and %d row(s) in destination, %d of %d sampled value(s) differ\n", verification.Table, verification.SourceCount, verification.DestinationCount, len(verification.MismatchedKeys), verification.SampleCount)
+		for _, key := range verification.MismatchedKeys {
+			fmt.Printf("  Mismatched key %x\n", key)
+		}
+	}
+
+	if failed {
+		return fmt.Errorf("migrated trxdb differs from source")
+	}
+
+	return nil
+}
+
+// newTrxdbMigrationStore opens the store of a kv driver DSN, ignoring its read and write
+// options since the migration copies tables regardless. The tables being copied as raw key
+// ranges, only DSNs of a single kv store are accepted.
+func newTrxdbMigrationStore(dsn string) (store.KVStore, error) {
+	if len(strings.Fields(dsn)) != 1 {
+		return nil, fmt.Errorf("dsn %q must be a single kv store, split dsns are not supported", dsn)
+	}
+
+	scheme := strings.SplitN(dsn, "://", 2)[0]
+	if store.ByName(scheme) == nil {
+		return nil, fmt.Errorf("dsn %q must be a single kv store, unsupported scheme %q (expected badger, tikv or bigkv)", dsn, scheme)
+	}
+
+	cleanDSN, err := store.RemoveDSNOptions(dsn, "read", "write", "blk_marker")
+	if err != nil {
+		return nil, fmt.Errorf("invalid dsn: %w", err)
+	}
+
+	return store.New(cleanDSN)
+}
@@ -0,0 +1,28 @@
+package tools
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTrxdbMigrationStore_SingleKVStoreOnly(t *testing.T) {
+	_, err := newTrxdbMigrationStore("badger:///tmp/a?read=blk badger:///tmp/b?read=trx&write=all")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "split dsns are not supported")
+
+	_, err = newTrxdbMigrationStore("sqlite3:///tmp/trxdb.db")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported scheme "sqlite3"`)
+
+	dir, err := ioutil.TempDir("", "trxdb-migrate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	kvStore, err := newTrxdbMigrationStore("badger://" + dir + "?read=all&write=all")
+	require.NoError(t, err)
+	require.NoError(t, kvStore.Close())
+}
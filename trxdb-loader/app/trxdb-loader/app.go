@@ -26,6 +26,7 @@ import (
 	"github.com/dfuse-io/dfuse-eosio/trxdb"
 	trxdbloader "github.com/dfuse-io/dfuse-eosio/trxdb-loader"
 	"github.com/dfuse-io/dfuse-eosio/trxdb-loader/metrics"
+	trxdbmetrics "github.com/dfuse-io/dfuse-eosio/trxdb/metrics"
 	"github.com/dfuse-io/dmetrics"
 	"github.com/dfuse-io/dstore"
 	"github.com/dfuse-io/shutter"
@@ -48,6 +49,9 @@ type Config struct {
 	EnableTruncationMarker    bool   // Enables the storage of truncation markers
 	TruncationWindow          uint64 // Truncate date within this duration
 	PurgerInterval            uint64 // Purger at every X block
+	EnableAccountHistory      bool   // Enables the writing of the account history index
+	RetentionPolicy           string // Per-table TTLs of the retention policy, as parsed by `trxdb.ParseRetentionPolicy`
+	RepairPlanFile            string // [PATCH] Repair plan file of the blocks to write again, overrides the start and stop block numbers
 }
 
 type App struct {
@@ -72,6 +76,7 @@ func (a *App) Run() error {
 	zlog.Info("launching trxdb loader", zap.Reflect("config", a.config))
 
 	dmetrics.Register(metrics.Metricset)
+	dmetrics.Register(trxdbmetrics.Metricset)
 
 	switch a.config.ProcessingType {
 	case "live", "batch", "patch":
@@ -94,6 +99,19 @@ func (a *App) Run() error {
 		trxdbOption = append(trxdbOption, trxdb.WithPurgeableStoreOption(a.config.TruncationWindow, a.config.PurgerInterval))
 	}
 
+	if a.config.EnableAccountHistory {
+		trxdbOption = append(trxdbOption, trxdb.WithAccountHistoryOption())
+	}
+
+	if a.config.RetentionPolicy != "" {
+		ttls, err := trxdb.ParseRetentionPolicy(a.config.RetentionPolicy)
+		if err != nil {
+			return fmt.Errorf("invalid retention policy: %w", err)
+		}
+
+		trxdbOption = append(trxdbOption, trxdb.WithRetentionPolicyOption(ttls, a.config.PurgerInterval))
+	}
+
 	db, err := trxdb.New(a.config.KvdbDsn, trxdbOption...)
 	if err != nil {
 		return fmt.Errorf("unable to create trxdb: %w", err)
@@ -121,6 +139,7 @@ func (a *App) Run() error {
 	zlog.Info("starting webserver", zap.String("http_addr", a.config.HTTPListenAddr))
 	go httpSrv.ListenAndServe()
 
+	launch := loader.Launch
 	switch a.config.ProcessingType {
 	case "live":
 		err := loader.BuildPipelineLive(a.config.AllowLiveOnEmptyTable)
@@ -131,6 +150,21 @@ func (a *App) Run() error {
 		loader.StopBeforeBlock(uint64(a.config.StopBlockNum))
 		loader.BuildPipelineBatch(uint64(a.config.StartBlockNum), uint64(a.config.NumBlocksBeforeStart))
 	case "patch":
+		if a.config.RepairPlanFile != "" {
+			plan, err := trxdbloader.LoadRepairPlan(a.config.RepairPlanFile)
+			if err != nil {
+				return err
+			}
+
+			if plan.Empty() {
+				return fmt.Errorf("repair plan %q has no block to repair", a.config.RepairPlanFile)
+			}
+
+			zlog.Info("repairing blocks of repair plan", zap.Int("block_count", len(plan.Blocks)), zap.Int("block_range_count", len(plan.Ranges())))
+			launch = func() { loader.LaunchRepairPlan(plan, uint64(a.config.NumBlocksBeforeStart)) }
+			break
+		}
+
 		loader.StopBeforeBlock(uint64(a.config.StopBlockNum))
 		loader.BuildPipelinePatch(uint64(a.config.StartBlockNum), uint64(a.config.NumBlocksBeforeStart))
 	}
@@ -145,7 +179,7 @@ func (a *App) Run() error {
 		a.Shutdown(err)
 	})
 
-	go loader.Launch()
+	go launch()
 	return nil
 }
 
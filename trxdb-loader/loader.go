@@ -49,6 +49,7 @@ type TrxDBLoader struct {
 	parallelFileDownloadCount int
 	healthy                   bool
 	truncationWindow          uint64
+	repairPlan                *RepairPlan
 
 	forkDB *forkable.ForkDB
 }
@@ -245,6 +246,36 @@ func (l *TrxDBLoader) InitLIB(libID string) {
 	l.forkDB.InitLIB(bstream.NewBlockRefFromID(libID))
 }
 
+// LaunchRepairPlan runs the `patch` processing type over each block range of the plan in
+// turn, writing again the blocks it lists, and shuts down the loader once the last range is done.
+func (l *TrxDBLoader) LaunchRepairPlan(plan *RepairPlan, numBlocksBeforeStart uint64) {
+	l.repairPlan = plan
+
+	for _, blockRange := range plan.Ranges() {
+		if l.IsTerminating() {
+			return
+		}
+
+		zlog.Info("repairing block range", zap.Uint64("start_block_num", blockRange[0]), zap.Uint64("stop_block_num", blockRange[1]))
+		l.StopBeforeBlock(blockRange[1])
+		l.BuildPipelinePatch(blockRange[0], numBlocksBeforeStart)
+
+		source := l.source
+		l.OnTerminating(func(err error) {
+			source.Shutdown(err)
+		})
+
+		source.Run()
+		if err := source.Err(); err != nil {
+			l.Shutdown(fmt.Errorf("repair block range [%d, %d): %w", blockRange[0], blockRange[1], err))
+			return
+		}
+	}
+
+	l.setUnhealthy()
+	l.Shutdown(nil)
+}
+
 // StopBeforeBlock indicates the stop block (exclusive), means that
 // block num will not be inserted.
 func (l *TrxDBLoader) StopBeforeBlock(blockNum uint64) {
@@ -418,10 +449,22 @@ func (l *TrxDBLoader) UpdateIrreversibleData(nowIrreversibleBlocks []*bstream.Pr
 // `patch-<tag>-<date>` where the tag is giving an overview of the patch and the date
 // is the effective date (`<year>-<month>-<day>`): `patch-add-trx-meta-written-2019-06-30`.
 // The branch is then deleted and the tag is pushed to the remote repository.
+//
+// When a repair plan is launched, the blocks it lists are written again, restoring their missing
+// or corrupted rows, and reaching the end of one of its block ranges only stops the pipeline of
+// that range.
 func (l *TrxDBLoader) PatchJob(blockNum uint64, blk *pbcodec.Block, fObj *forkable.ForkableObject) (err error) {
 	switch fObj.Step {
 	case forkable.StepNew:
 		l.ShowProgress(blockNum)
+
+		if l.repairPlan != nil && l.repairPlan.Contains(blk.Id) {
+			zlog.Info("repairing block", zap.Stringer("block", blk.AsRef()))
+			if err := l.db.PutBlock(context.Background(), blk); err != nil {
+				return fmt.Errorf("repair block: %w", err)
+			}
+		}
+
 		return l.FlushIfNeeded(blockNum, blk.MustTime())
 
 	case forkable.StepIrreversible:
@@ -431,6 +474,11 @@ func (l *TrxDBLoader) PatchJob(blockNum uint64, blk *pbcodec.Block, fObj *forkab
 				return err
 			}
 
+			if l.repairPlan != nil {
+				l.source.Shutdown(nil)
+				return nil
+			}
+
 			l.Shutdown(nil)
 			return nil
 		}
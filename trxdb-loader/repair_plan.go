@@ -0,0 +1,141 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trxdb_loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+)
+
+// RepairPlan lists the blocks that must be written again to trxdb, as produced by
+// `dfuseeos tools check trxdb-blocks --deep` and consumed by the `patch` processing type.
+type RepairPlan struct {
+	Blocks []*RepairPlanBlock `json:"blocks"`
+
+	blocksByID map[string]*RepairPlanBlock
+}
+
+// RepairPlanBlock is a block to write again, `Tables` being the tables where rows of the
+// block are missing or corrupted. The whole block is written again regardless of them.
+type RepairPlanBlock struct {
+	Num    uint64   `json:"num"`
+	ID     string   `json:"id"`
+	Tables []string `json:"tables"`
+}
+
+// Add records that the rows of the block in `table` must be written again
+func (p *RepairPlan) Add(blockNum uint64, blockID string, table string) {
+	if block, found := p.blocksByID[blockID]; found {
+		for _, existing := range block.Tables {
+			if existing == table {
+				return
+			}
+		}
+
+		block.Tables = append(block.Tables, table)
+		return
+	}
+
+	if p.blocksByID == nil {
+		p.blocksByID = map[string]*RepairPlanBlock{}
+	}
+
+	block := &RepairPlanBlock{Num: blockNum, ID: blockID, Tables: []string{table}}
+	p.blocksByID[blockID] = block
+	p.Blocks = append(p.Blocks, block)
+	sort.SliceStable(p.Blocks, func(i, j int) bool { return p.Blocks[i].Num < p.Blocks[j].Num })
+}
+
+func (p *RepairPlan) Empty() bool {
+	return len(p.Blocks) == 0
+}
+
+// Contains returns whether the block with `blockID` must be written again
+func (p *RepairPlan) Contains(blockID string) bool {
+	_, found := p.blocksByID[blockID]
+	return found
+}
+
+// StartBlockNum is the lowest block number of the plan, 0 when the plan is empty
+func (p *RepairPlan) StartBlockNum() uint64 {
+	if p.Empty() {
+		return 0
+	}
+
+	return p.Blocks[0].Num
+}
+
+// StopBlockNum is the block number following the highest one of the plan, 0 when the plan is empty
+func (p *RepairPlan) StopBlockNum() uint64 {
+	if p.Empty() {
+		return 0
+	}
+
+	return p.Blocks[len(p.Blocks)-1].Num + 1
+}
+
+// Ranges returns the contiguous ranges of block numbers of the plan, each one being a pair
+// of start (inclusive) and stop (exclusive) block numbers.
+func (p *RepairPlan) Ranges() (out [][2]uint64) {
+	for _, block := range p.Blocks {
+		if count := len(out); count > 0 && block.Num <= out[count-1][1] {
+			// Forked blocks share the number of the previous block of the plan
+			if block.Num+1 > out[count-1][1] {
+				out[count-1][1] = block.Num + 1
+			}
+			continue
+		}
+
+		out = append(out, [2]uint64{block.Num, block.Num + 1})
+	}
+
+	return out
+}
+
+func (p *RepairPlan) Write(path string) error {
+	content, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal repair plan: %w", err)
+	}
+
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("unable to write repair plan %q: %w", path, err)
+	}
+
+	return nil
+}
+
+func LoadRepairPlan(path string) (*RepairPlan, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read repair plan %q: %w", path, err)
+	}
+
+	plan := &RepairPlan{}
+	if err := json.Unmarshal(content, plan); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal repair plan %q: %w", path, err)
+	}
+
+	sort.SliceStable(plan.Blocks, func(i, j int) bool { return plan.Blocks[i].Num < plan.Blocks[j].Num })
+
+	plan.blocksByID = make(map[string]*RepairPlanBlock, len(plan.Blocks))
+	for _, block := range plan.Blocks {
+		plan.blocksByID[block.ID] = block
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trxdb_loader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepairPlan(t *testing.T) {
+	plan := &RepairPlan{}
+	assert.True(t, plan.Empty())
+	assert.Equal(t, uint64(0), plan.StopBlockNum())
+
+	plan.Add(12, "0000000caa", "trxs")
+	plan.Add(10, "0000000aaa", "trx_traces")
+	plan.Add(10, "0000000aaa", "dtrxs")
+	plan.Add(10, "0000000aaa", "trx_traces")
+	plan.Add(11, "0000000bbb", "blocks")
+	plan.Add(11, "0000000baa", "blocks")
+	plan.Add(20, "00000014aa", "implicit_trxs")
+
+	require.Len(t, plan.Blocks, 5)
+	assert.Equal(t, []string{"trx_traces", "dtrxs"}, plan.Blocks[0].Tables)
+	assert.Equal(t, uint64(10), plan.StartBlockNum())
+	assert.Equal(t, uint64(21), plan.StopBlockNum())
+	assert.Equal(t, [][2]uint64{{10, 13}, {20, 21}}, plan.Ranges())
+
+	assert.True(t, plan.Contains("0000000baa"))
+	assert.False(t, plan.Contains("0000000daa"))
+
+	dir, err := ioutil.TempDir("", "dfuse-trxdb-repair-plan")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "plan.json")
+	require.NoError(t, plan.Write(path))
+
+	loaded, err := LoadRepairPlan(path)
+	require.NoError(t, err)
+	assert.Equal(t, plan, loaded)
+	assert.True(t, loaded.Contains("0000000caa"))
+}
@@ -50,6 +50,15 @@ type AccountsReader interface {
 	ListAccountNames(ctx context.Context, concurrentReadCount uint32) ([]string, error)
 }
 
+// AccountHistoryReader is implemented by the drivers able to index the history of accounts,
+// which is only written when enabled on the writer with `WithAccountHistoryOption`.
+type AccountHistoryReader interface {
+	// ListAccountHistory returns at most `limit` entries of the history of `accountName`, the
+	// most recent first, starting right after `cursor` (empty to start with the most recent
+	// entry). The returned cursor is empty when there is no more entries.
+	ListAccountHistory(ctx context.Context, accountName string, cursor string, limit int) (entries []*AccountHistoryEntry, nextCursor string, err error)
+}
+
 type TransactionsReader interface {
 	GetLastWrittenIrreversibleBlockRef(ctx context.Context) (ref bstream.BlockRef, err error)
 	// It's not the job of the Storage layer to discriminate events, just get the data
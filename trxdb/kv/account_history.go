@@ -0,0 +1,166 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"go.uber.org/zap"
+)
+
+// putAccountHistory indexes the actions of the block under their receiver and authorizers.
+// When filtering was applied to the block, only the actions that matched the filter are
+// indexed.
+func (db *DB) putAccountHistory(ctx context.Context, blk *pbcodec.Block) error {
+	for _, trxTrace := range blk.TransactionTraces() {
+		for _, actTrace := range trxTrace.ActionTraces {
+			if actTrace.Receipt == nil {
+				// Actions without a receipt were not applied
+				continue
+			}
+
+			if blk.FilteringApplied && !actTrace.FilteringMatched {
+				continue
+			}
+
+			value := packAccountHistoryValue(trxTrace.Id, blk.Id, actTrace.ExecutionIndex)
+			for _, account := range actionAccounts(actTrace) {
+				if traceEnabled {
+					db.logger.Debug("put account history row", zap.String("account", account), zap.Uint64("global_sequence", actTrace.Receipt.GlobalSequence))
+				}
+
+				// NOTE: This function is guarded by the parent with db.enableAccountHistoryWrite
+				key := Keys.PackAccountHistoryKey(account, actTrace.Receipt.GlobalSequence)
+				if err := db.writeStore.Put(ctx, key, value); err != nil {
+					return fmt.Errorf("put account history row: write to db: %w", err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// actionAccounts returns the receiver and the authorizers of the action, without duplicates.
+// Notifications carry the authorizations of the notifying action, the authorizers are only
+// indexed on the action executed by its own account so they get a single entry per action.
+func actionAccounts(actTrace *pbcodec.ActionTrace) (out []string) {
+	seen := map[string]bool{}
+	add := func(account string) {
+		if account != "" && !seen[account] {
+			seen[account] = true
+			out = append(out, account)
+		}
+	}
+
+	add(actTrace.Receiver)
+	if actTrace.Action != nil && actTrace.Action.Account == actTrace.Receiver {
+		for _, authorization := range actTrace.Action.Authorization {
+			add(authorization.Actor)
+		}
+	}
+
+	return out
+}
+
+func (db *DB) ListAccountHistory(ctx context.Context, accountName string, cursor string, limit int) (entries []*trxdb.AccountHistoryEntry, nextCursor string, err error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("invalid limit %d, must be greater than 0", limit)
+	}
+
+	if db.trxReadStore == nil {
+		return nil, "", fmt.Errorf("account history is not readable, the trxdb dsn must read 'trx' or 'all'")
+	}
+
+	startKey := Keys.PackAccountHistoryPrefix(accountName)
+	if cursor != "" {
+		lastGlobalSequence, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid cursor %q: %w", cursor, err)
+		}
+
+		if lastGlobalSequence == 0 {
+			return nil, "", nil
+		}
+
+		startKey = Keys.PackAccountHistoryKey(accountName, lastGlobalSequence-1)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// One more entry than requested is read to know if there is a next page
+	it := db.trxReadStore.Scan(ctx, startKey, Keys.EndOfAccountHistoryPrefix(accountName), limit+1)
+	for it.Next() {
+		if len(entries) == limit {
+			nextCursor = strconv.FormatUint(entries[len(entries)-1].GlobalSequence, 10)
+			break
+		}
+
+		kv := it.Item()
+		entry, err := unpackAccountHistoryValue(kv.Value)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid account history row %x: %w", kv.Key, err)
+		}
+
+		entry.AccountName, entry.GlobalSequence = Keys.UnpackAccountHistoryKey(kv.Key)
+		entries = append(entries, entry)
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return entries, nextCursor, nil
+}
+
+// packAccountHistoryValue packs the action execution index (4 bytes), the transaction id
+// length (1 byte), the transaction id and the block id of an account history row.
+func packAccountHistoryValue(trxID, blockID string, actionIndex uint32) []byte {
+	id, err := hex.DecodeString(trxID)
+	if err != nil {
+		panic(fmt.Errorf("invalid trx ID %q: %w", trxID, err))
+	}
+
+	blkID, err := hex.DecodeString(blockID)
+	if err != nil {
+		panic(fmt.Errorf("invalid block ID %q: %w", blockID, err))
+	}
+
+	value := make([]byte, 5, 5+len(id)+len(blkID))
+	binary.BigEndian.PutUint32(value, actionIndex)
+	value[4] = byte(len(id))
+	value = append(value, id...)
+	return append(value, blkID...)
+}
+
+func unpackAccountHistoryValue(value []byte) (*trxdb.AccountHistoryEntry, error) {
+	if len(value) < 5 || len(value) < 5+int(value[4]) {
+		return nil, fmt.Errorf("value of length %d is too short", len(value))
+	}
+
+	trxIDEnd := 5 + int(value[4])
+	return &trxdb.AccountHistoryEntry{
+		ActionIndex: binary.BigEndian.Uint32(value[0:4]),
+		TrxID:       hex.EncodeToString(value[5:trxIDEnd]),
+		BlockID:     hex.EncodeToString(value[trxIDEnd:]),
+	}, nil
+}
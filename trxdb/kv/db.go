@@ -42,6 +42,12 @@ type DB struct {
 	enableTrxWrite        bool
 	writeStore            store.KVStore
 
+	// Optional, enabled through `trxdb.WithAccountHistoryOption`
+	enableAccountHistoryWrite bool
+
+	// Optional, enabled through `trxdb.WithRetentionPolicyOption`, wraps the `writeStore`
+	retentionStore *retentionStore
+
 	// Required only when writing
 	writerChainID []byte
 
@@ -0,0 +1,158 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/kvdb/store"
+)
+
+// IntegrityIssue is a row of a block that is either missing from the database or whose
+// content differs from the one the block produces when written.
+type IntegrityIssue struct {
+	BlockNum uint64
+	BlockID  string
+	Table    string
+	Key      []byte
+
+	// ExpectedHash and ActualHash are the hex encoded SHA-256 of the row value, `ActualHash`
+	// being empty when the row is missing.
+	ExpectedHash string
+	ActualHash   string
+}
+
+func (i *IntegrityIssue) Missing() bool {
+	return i.ActualHash == ""
+}
+
+func (i *IntegrityIssue) String() string {
+	if i.Missing() {
+		return fmt.Sprintf("block #%d (%s) %s row %x is missing", i.BlockNum, i.BlockID, i.Table, i.Key)
+	}
+
+	return fmt.Sprintf("block #%d (%s) %s row %x has content hash %s, expected %s", i.BlockNum, i.BlockID, i.Table, i.Key, i.ActualHash, i.ExpectedHash)
+}
+
+type integrityRow struct {
+	table string
+	store store.KVStore
+	key   []byte
+	value []byte
+}
+
+// CheckBlockIntegrity verifies that the block row along with the rows of every transaction
+// receipt, transaction trace, implicit transaction and deferred transaction operation of
+// the block exist in the database, with the content the block produces when written.
+//
+// The block must be the one that was written, so the same filtering must have been applied
+// to it, and the writer chain ID must be set to the one used when writing since it is needed
+// to recover the public keys of transactions. Rows purged by a retention policy are reported
+// as missing.
+func (db *DB) CheckBlockIntegrity(ctx context.Context, blk *pbcodec.Block) (out []*IntegrityIssue, err error) {
+	if db.blkReadStore == nil || db.trxReadStore == nil {
+		return nil, fmt.Errorf("integrity check requires both blk and trx read stores")
+	}
+
+	rows, err := db.integrityRows(blk)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		value, err := row.store.Get(ctx, row.key)
+		if err != nil && err != store.ErrNotFound {
+			return nil, fmt.Errorf("unable to get %s row %x: %w", row.table, row.key, err)
+		}
+
+		issue := &IntegrityIssue{
+			BlockNum:     blk.Num(),
+			BlockID:      blk.Id,
+			Table:        row.table,
+			Key:          row.key,
+			ExpectedHash: contentHash(row.value),
+		}
+
+		if err == nil {
+			issue.ActualHash = contentHash(value)
+		}
+
+		if issue.ActualHash != issue.ExpectedHash {
+			out = append(out, issue)
+		}
+	}
+
+	return out, nil
+}
+
+// IsIrreversibleBlock returns whether the block was marked irreversible in the database
+func (db *DB) IsIrreversibleBlock(ctx context.Context, blockID string) (bool, error) {
+	_, err := db.irrReadStore.Get(ctx, Keys.PackIrrBlocksKey(blockID))
+	if err == store.ErrNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("unable to get irr block: %w", err)
+	}
+
+	return true, nil
+}
+
+// integrityRows returns the rows written by `PutBlock` for the block
+func (db *DB) integrityRows(blk *pbcodec.Block) (out []*integrityRow, err error) {
+	for _, trxReceipt := range blk.Transactions() {
+		if trxReceipt.PackedTransaction == nil {
+			// Deferred transaction receipt, handled through the dtrx ops
+			continue
+		}
+
+		value, err := db.encodeTrxRow(trxReceipt)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, &integrityRow{"trxs", db.trxReadStore, Keys.PackTrxsKey(trxReceipt.Id, blk.Id), value})
+	}
+
+	for _, trxTrace := range blk.TransactionTraces() {
+		for _, dtrxOp := range trxTrace.DtrxOps {
+			key, value, err := db.encodeDtrxRow(blk, trxTrace, dtrxOp)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, &integrityRow{"dtrxs", db.trxReadStore, key, value})
+		}
+
+		out = append(out, &integrityRow{"trx_traces", db.trxReadStore, Keys.PackTrxTracesKey(trxTrace.Id, blk.Id), db.encodeTrxTraceRow(blk, trxTrace)})
+	}
+
+	for _, trxOp := range blk.ImplicitTransactionOps() {
+		out = append(out, &integrityRow{"implicit_trxs", db.trxReadStore, Keys.PackImplicitTrxsKey(trxOp.TransactionId, blk.Id), db.encodeImplicitTrxRow(trxOp)})
+	}
+
+	out = append(out, &integrityRow{"blocks", db.blkReadStore, Keys.PackBlocksKey(blk.Id), db.encodeBlockRow(blk)})
+	return out, nil
+}
+
+func contentHash(value []byte) string {
+	hash := sha256.Sum256(value)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,75 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBlockIntegrity(t *testing.T) {
+	ctx := context.Background()
+	db, clean := newTestRetentionDB(t, nil, 0)
+	defer clean()
+
+	blk := ct.Block(t, testRetentionBlkID,
+		ct.TrxTrace(t, ct.TrxID(testRetentionTrxID),
+			ct.DtrxOp(t, "create", testRetentionDtrxID, ct.DtrxOpPayer("eoscanada1"), &pbcodec.SignedTransaction{
+				Signatures: []string{"signature"},
+			}),
+		),
+		&pbcodec.TrxOp{
+			Operation:     pbcodec.TrxOp_OPERATION_CREATE,
+			Name:          "onblock",
+			TransactionId: "abc999aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			Transaction:   &pbcodec.SignedTransaction{Transaction: &pbcodec.Transaction{}},
+		},
+	)
+
+	require.NoError(t, db.PutBlock(ctx, blk))
+	require.NoError(t, db.Flush(ctx))
+
+	irreversible, err := db.IsIrreversibleBlock(ctx, blk.Id)
+	require.NoError(t, err)
+	assert.False(t, irreversible)
+
+	require.NoError(t, db.UpdateNowIrreversibleBlock(ctx, blk))
+	require.NoError(t, db.Flush(ctx))
+
+	irreversible, err = db.IsIrreversibleBlock(ctx, blk.Id)
+	require.NoError(t, err)
+	assert.True(t, irreversible)
+
+	issues, err := db.CheckBlockIntegrity(ctx, blk)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	traceKey := Keys.PackTrxTracesKey(testRetentionTrxID, testRetentionBlkID)
+	dtrxKey := Keys.PackDtrxsKeyCreated(testRetentionDtrxID, testRetentionBlkID)
+
+	require.NoError(t, db.writeStore.BatchDelete(ctx, [][]byte{traceKey}))
+	require.NoError(t, db.writeStore.Put(ctx, dtrxKey, []byte("corrupted")))
+	require.NoError(t, db.Flush(ctx))
+
+	issues, err = db.CheckBlockIntegrity(ctx, blk)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+
+	assert.Equal(t, "dtrxs", issues[0].Table)
+	assert.Equal(t, dtrxKey, issues[0].Key)
+	assert.False(t, issues[0].Missing())
+	assert.Equal(t, contentHash([]byte("corrupted")), issues[0].ActualHash)
+
+	assert.Equal(t, "trx_traces", issues[1].Table)
+	assert.Equal(t, traceKey, issues[1].Key)
+	assert.True(t, issues[1].Missing())
+	assert.Equal(t, uint64(2), issues[1].BlockNum)
+
+	// The block is left untouched by the check, so checking it again yields the same issues
+	issues, err = db.CheckBlockIntegrity(ctx, blk)
+	require.NoError(t, err)
+	assert.Len(t, issues, 2)
+}
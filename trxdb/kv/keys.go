@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/dfuse-io/kvdb"
@@ -18,7 +19,11 @@ const (
 	TblPrefixDtrxs     = 0x04
 	TblPrefixTrxTraces = 0x05
 	TblPrefixAccts     = 0x06
-	TblTTL             = 0x10
+	// Optional, only written when account history is enabled on the writer
+	TblPrefixAcctHistory = 0x07
+	TblTTL               = 0x10
+	// Purge schedule of the per-table retention policy, only written when one is configured
+	TblRetention = 0x11
 
 	idxPrefixTimelineFwd = 0x80
 	idxPrefixTimelineBck = 0x81
@@ -26,6 +31,9 @@ const (
 	dtrxSuffixCreated   = 0x90
 	dtrxSuffixCancelled = 0x91
 	dtrxSuffixFailed    = 0x92
+
+	retentionTargetKey    = 0x00
+	retentionTargetPrefix = 0x01
 )
 
 var Keys Keyer
@@ -191,6 +199,72 @@ func (Keyer) UnpackAccountKey(key []byte) string {
 func (Keyer) StartOfAccountTable() []byte { return []byte{TblPrefixAccts} }
 func (Keyer) EndOfAccountTable() []byte   { return []byte{TblPrefixAccts + 1} }
 
+// Account history virt table, the most recent actions of an account coming first
+
+func (Keyer) PackAccountHistoryKey(accountName string, globalSequence uint64) []byte {
+	name, err := eos.StringToName(accountName)
+	if err != nil {
+		panic(fmt.Errorf("invalid account name %q: %w", accountName, err))
+	}
+	b := make([]byte, 17)
+	b[0] = TblPrefixAcctHistory
+	binary.BigEndian.PutUint64(b[1:], name)
+	binary.BigEndian.PutUint64(b[9:], math.MaxUint64-globalSequence)
+	return b
+}
+
+func (Keyer) UnpackAccountHistoryKey(key []byte) (accountName string, globalSequence uint64) {
+	if len(key) != 17 {
+		panic(fmt.Errorf("invalid key %q length, expected length 17 got %d", string(key), len(key)))
+	}
+	return eos.NameToString(binary.BigEndian.Uint64(key[1:9])), math.MaxUint64 - binary.BigEndian.Uint64(key[9:])
+}
+
+func (k Keyer) PackAccountHistoryPrefix(accountName string) []byte {
+	return k.PackAccountHistoryKey(accountName, math.MaxUint64)[0:9]
+}
+
+// EndOfAccountHistoryPrefix is right after the oldest possible key of the account, which is
+// the one of global sequence 0.
+func (k Keyer) EndOfAccountHistoryPrefix(accountName string) []byte {
+	return append(k.PackAccountHistoryKey(accountName, 0), 0x00)
+}
+
+func (Keyer) StartOfAccountHistoryTable() []byte { return []byte{TblPrefixAcctHistory} }
+func (Keyer) EndOfAccountHistoryTable() []byte   { return []byte{TblPrefixAcctHistory + 1} }
+
+// Retention virt table, the keys (or prefixes) to purge ordered by the block height at which
+// they must be purged
+
+func (Keyer) PackRetentionKey(purgeHeight uint64, target []byte, isPrefix bool) []byte {
+	key := make([]byte, 10+len(target))
+	key[0] = TblRetention
+	binary.BigEndian.PutUint64(key[1:], purgeHeight)
+	key[9] = retentionTargetKey
+	if isPrefix {
+		key[9] = retentionTargetPrefix
+	}
+	copy(key[10:], target)
+	return key
+}
+
+func (Keyer) UnpackRetentionKey(key []byte) (purgeHeight uint64, target []byte, isPrefix bool) {
+	if len(key) < 10 {
+		panic(fmt.Errorf("invalid key %q length, expected at least length 10 got %d", string(key), len(key)))
+	}
+	return binary.BigEndian.Uint64(key[1:9]), key[10:], key[9] == retentionTargetPrefix
+}
+
+func (Keyer) PackRetentionHeightPrefix(purgeHeight uint64) []byte {
+	key := make([]byte, 9)
+	key[0] = TblRetention
+	binary.BigEndian.PutUint64(key[1:], purgeHeight)
+	return key
+}
+
+func (Keyer) StartOfRetentionTable() []byte { return []byte{TblRetention} }
+func (Keyer) EndOfRetentionTable() []byte   { return []byte{TblRetention + 1} }
+
 // Timeline indexes
 
 func (Keyer) PackTimelineKey(fwd bool, blockTime time.Time, blockID string) []byte {
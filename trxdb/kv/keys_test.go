@@ -1,6 +1,8 @@
 package kv
 
 import (
+	"bytes"
+	"math"
 	"testing"
 	"time"
 
@@ -21,6 +23,32 @@ func TestKeyer_PackAccountKey(t *testing.T) {
 	require.Equal(t, key, unpacked)
 }
 
+func TestKeyer_PackAccountHistoryKey(t *testing.T) {
+	packed := Keys.PackAccountHistoryKey("eoscanadacom", 42)
+	account, globalSequence := Keys.UnpackAccountHistoryKey(packed)
+	require.Equal(t, "eoscanadacom", account)
+	require.Equal(t, uint64(42), globalSequence)
+
+	// Most recent actions come first, and stay within the account range
+	require.Equal(t, -1, bytes.Compare(Keys.PackAccountHistoryKey("eoscanadacom", 43), packed))
+	require.Equal(t, -1, bytes.Compare(Keys.PackAccountHistoryPrefix("eoscanadacom"), Keys.PackAccountHistoryKey("eoscanadacom", math.MaxUint64)))
+	require.Equal(t, 1, bytes.Compare(Keys.EndOfAccountHistoryPrefix("eoscanadacom"), Keys.PackAccountHistoryKey("eoscanadacom", 0)))
+	require.Equal(t, -1, bytes.Compare(Keys.EndOfAccountHistoryPrefix("eoscanadacom"), Keys.PackAccountHistoryKey("eoscanadacon", math.MaxUint64)))
+}
+
+func TestKeyer_PackRetentionKey(t *testing.T) {
+	target := Keys.PackDtrxsPrefix("00112233")
+	packed := Keys.PackRetentionKey(42, target, true)
+	purgeHeight, unpackedTarget, isPrefix := Keys.UnpackRetentionKey(packed)
+	require.Equal(t, uint64(42), purgeHeight)
+	require.Equal(t, target, unpackedTarget)
+	require.True(t, isPrefix)
+
+	// Keys to purge at a given height are before the prefix of the next height
+	require.Equal(t, -1, bytes.Compare(packed, Keys.PackRetentionHeightPrefix(43)))
+	require.Equal(t, 1, bytes.Compare(packed, Keys.PackRetentionHeightPrefix(42)))
+}
+
 func TestKeyer_PackTimelineKey(t *testing.T) {
 	expectedBlockID := "00000002aa"
 	expectedBlockTime := time.Unix(0, 0).UTC()
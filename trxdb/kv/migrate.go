@@ -0,0 +1,520 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/abourget/llerrgroup"
+	"github.com/dfuse-io/kvdb/store"
+	"go.uber.org/zap"
+)
+
+// MigrationTable is a virtual table copied by a `Migration`, identified by its key range
+type MigrationTable struct {
+	Name  string
+	Start []byte
+	End   []byte
+}
+
+// MigrationTables are all the virtual tables of the driver, the purge schedules of the
+// purgeable store and of the retention policy being bound to the source store they are not
+// copied.
+var MigrationTables = []*MigrationTable{
+	{"blocks", Keys.StartOfBlocksTable(), Keys.EndOfBlocksTable()},
+	{"irr_blocks", Keys.StartOfIrrBlockTable(), Keys.EndOfIrrBlockTable()},
+	{"trxs", Keys.StartOfTrxsTable(), Keys.EndOfTrxsTable()},
+	{"trx_traces", Keys.StartOfTrxTracesTable(), Keys.EndOfTrxTracesTable()},
+	{"implicit_trxs", Keys.StartOfImplicitTrxsTable(), Keys.EndOfImplicitTrxsTable()},
+	{"dtrxs", Keys.StartOfDtrxsTable(), Keys.EndOfDtrxsTable()},
+	{"accounts", Keys.StartOfAccountTable(), Keys.EndOfAccountTable()},
+	{"account_history", Keys.StartOfAccountHistoryTable(), Keys.EndOfAccountHistoryTable()},
+	{"timeline_fwd", Keys.StartOfTimelineIndex(true), Keys.EndOfTimelineIndex(true)},
+	{"timeline_bck", Keys.StartOfTimelineIndex(false), Keys.EndOfTimelineIndex(false)},
+}
+
+// Migration copies the virtual tables of a trxdb from one store to another. Each table is
+// split in key-range chunks scanned in parallel, the progress of each chunk being recorded
+// in a checkpoint file so an interrupted migration resumes where it stopped. Rows are copied
+// as raw keys and values, the source and destination being whole kv stores of the driver.
+//
+// The destination store buffers its puts in a single batch shared by all callers, so the
+// batches of the chunks are written and flushed one at a time: parallelism speeds up the
+// scans of the source, not the writes to the destination.
+type Migration struct {
+	source      store.KVStore
+	destination store.KVStore
+
+	tables        []*MigrationTable
+	chunkCount    int
+	parallelism   int
+	batchSize     int
+	sampleEvery   uint64
+	checkpointDir string
+
+	// Puts are buffered by the destination store, so batches are written and flushed one
+	// at a time, see `Migration`
+	writeLock sync.Mutex
+}
+
+type MigrationOption func(m *Migration) error
+
+// WithMigrationTables restricts the migration to the `MigrationTables` with those names
+func WithMigrationTables(names ...string) MigrationOption {
+	return func(m *Migration) error {
+		m.tables = nil
+		for _, name := range names {
+			table := findMigrationTable(name)
+			if table == nil {
+				return fmt.Errorf("unknown table %q", name)
+			}
+
+			m.tables = append(m.tables, table)
+		}
+
+		return nil
+	}
+}
+
+// WithMigrationChunks sets the number of key-range chunks each table is split in, and
+// the number of chunks scanned or verified in parallel.
+func WithMigrationChunks(chunkCount, parallelism int) MigrationOption {
+	return func(m *Migration) error {
+		if chunkCount < 1 || chunkCount > 65536 {
+			return fmt.Errorf("chunk count must be between 1 and 65536, got %d", chunkCount)
+		}
+
+		if parallelism < 1 {
+			return fmt.Errorf("parallelism must be at least 1, got %d", parallelism)
+		}
+
+		m.chunkCount = chunkCount
+		m.parallelism = parallelism
+		return nil
+	}
+}
+
+// WithMigrationBatchSize sets the number of rows written between each flush and checkpoint
+func WithMigrationBatchSize(batchSize int) MigrationOption {
+	return func(m *Migration) error {
+		if batchSize < 1 {
+			return fmt.Errorf("batch size must be at least 1, got %d", batchSize)
+		}
+
+		m.batchSize = batchSize
+		return nil
+	}
+}
+
+// WithMigrationCheckpointDir records the progress of each chunk in `dir`, a migration
+// re-run with the same tables and chunk count resuming from it.
+func WithMigrationCheckpointDir(dir string) MigrationOption {
+	return func(m *Migration) error {
+		m.checkpointDir = dir
+		return nil
+	}
+}
+
+// WithMigrationSampling makes the verification compare the value of one row out of
+// every `sampleEvery` in both stores.
+func WithMigrationSampling(sampleEvery uint64) MigrationOption {
+	return func(m *Migration) error {
+		if sampleEvery < 1 {
+			return fmt.Errorf("sampling must be at least 1, got %d", sampleEvery)
+		}
+
+		m.sampleEvery = sampleEvery
+		return nil
+	}
+}
+
+func NewMigration(source, destination store.KVStore, opts ...MigrationOption) (*Migration, error) {
+	m := &Migration{
+		source:      source,
+		destination: destination,
+		tables:      MigrationTables,
+		chunkCount:  16,
+		parallelism: 4,
+		batchSize:   1000,
+		sampleEvery: 1000,
+	}
+
+	for _, opt := range opts {
+		if err := opt(m); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.checkpointDir != "" {
+		if err := os.MkdirAll(m.checkpointDir, 0755); err != nil {
+			return nil, fmt.Errorf("unable to create checkpoint directory: %w", err)
+		}
+	}
+
+	return m, nil
+}
+
+func findMigrationTable(name string) *MigrationTable {
+	for _, table := range MigrationTables {
+		if table.Name == name {
+			return table
+		}
+	}
+
+	return nil
+}
+
+type migrationChunk struct {
+	table *MigrationTable
+	index int
+	start []byte
+	end   []byte
+}
+
+// chunks splits the tables on the two bytes following their prefix, which are the start of
+// hashes for transaction tables, so chunks are evenly filled for those.
+func (m *Migration) chunks() (out []*migrationChunk) {
+	for _, table := range m.tables {
+		for i := 0; i < m.chunkCount; i++ {
+			chunk := &migrationChunk{table: table, index: i, start: table.Start, end: table.End}
+			if i > 0 {
+				chunk.start = chunkBoundary(table, i, m.chunkCount)
+			}
+
+			if i < m.chunkCount-1 {
+				chunk.end = chunkBoundary(table, i+1, m.chunkCount)
+			}
+
+			out = append(out, chunk)
+		}
+	}
+
+	return
+}
+
+func chunkBoundary(table *MigrationTable, index, chunkCount int) []byte {
+	boundary := index * 65536 / chunkCount
+	return append(append([]byte{}, table.Start...), byte(boundary>>8), byte(boundary))
+}
+
+// Run copies the chunks not completed yet, returning the number of rows copied per table,
+// including the ones copied by previous runs.
+func (m *Migration) Run(ctx context.Context) (rowCounts map[string]uint64, err error) {
+	rowCounts = make(map[string]uint64)
+	var rowCountsLock sync.Mutex
+
+	err = m.forEachChunk(func(chunk *migrationChunk) error {
+		count, err := m.copyChunk(ctx, chunk)
+		if err != nil {
+			return fmt.Errorf("copying %s chunk #%d: %w", chunk.table.Name, chunk.index, err)
+		}
+
+		rowCountsLock.Lock()
+		rowCounts[chunk.table.Name] += count
+		rowCountsLock.Unlock()
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rowCounts, nil
+}
+
+func (m *Migration) forEachChunk(f func(chunk *migrationChunk) error) error {
+	group := llerrgroup.New(m.parallelism)
+	for _, chunk := range m.chunks() {
+		if group.Stop() {
+			break
+		}
+
+		chunk := chunk
+		group.Go(func() error {
+			return f(chunk)
+		})
+	}
+
+	return group.Wait()
+}
+
+func (m *Migration) copyChunk(ctx context.Context, chunk *migrationChunk) (uint64, error) {
+	checkpoint, err := m.loadCheckpoint(chunk)
+	if err != nil {
+		return 0, err
+	}
+
+	if checkpoint.Done {
+		zlog.Debug("chunk already migrated", zap.String("table", chunk.table.Name), zap.Int("chunk", chunk.index))
+		return checkpoint.RowCount, nil
+	}
+
+	start := chunk.start
+	if checkpoint.LastKey != "" {
+		lastKey, err := hex.DecodeString(checkpoint.LastKey)
+		if err != nil {
+			return 0, fmt.Errorf("invalid checkpoint last key: %w", err)
+		}
+
+		start = append(lastKey, 0x00)
+		zlog.Info("resuming chunk migration", zap.String("table", chunk.table.Name), zap.Int("chunk", chunk.index), zap.Uint64("row_count", checkpoint.RowCount))
+	}
+
+	for {
+		var batch []*migrationRow
+		it := m.source.Scan(ctx, start, chunk.end, m.batchSize)
+		for it.Next() {
+			item := it.Item()
+			batch = append(batch, newMigrationRow(item.Key, item.Value))
+		}
+		if err := it.Err(); err != nil {
+			return 0, fmt.Errorf("scanning source: %w", err)
+		}
+
+		if len(batch) > 0 {
+			if err := m.writeBatch(ctx, batch); err != nil {
+				return 0, err
+			}
+
+			lastKey := batch[len(batch)-1].key
+			checkpoint.LastKey = hex.EncodeToString(lastKey)
+			checkpoint.RowCount += uint64(len(batch))
+			start = append(append([]byte{}, lastKey...), 0x00)
+		}
+
+		checkpoint.Done = len(batch) < m.batchSize
+		if err := m.saveCheckpoint(chunk, checkpoint); err != nil {
+			return 0, err
+		}
+
+		if checkpoint.Done {
+			zlog.Info("chunk migrated", zap.String("table", chunk.table.Name), zap.Int("chunk", chunk.index), zap.Uint64("row_count", checkpoint.RowCount))
+			return checkpoint.RowCount, nil
+		}
+	}
+}
+
+func (m *Migration) writeBatch(ctx context.Context, batch []*migrationRow) error {
+	m.writeLock.Lock()
+	defer m.writeLock.Unlock()
+
+	for _, row := range batch {
+		if err := m.destination.Put(ctx, row.key, row.value); err != nil {
+			return fmt.Errorf("writing destination: %w", err)
+		}
+	}
+
+	if err := m.destination.FlushPuts(ctx); err != nil {
+		return fmt.Errorf("flushing destination: %w", err)
+	}
+
+	return nil
+}
+
+// migrationRow is a copy of a scanned row, iterators being free to reuse their buffers
+type migrationRow struct {
+	key   []byte
+	value []byte
+}
+
+func newMigrationRow(key, value []byte) *migrationRow {
+	return &migrationRow{key: append([]byte{}, key...), value: append([]byte{}, value...)}
+}
+
+type migrationCheckpoint struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	LastKey  string `json:"last_key,omitempty"`
+	RowCount uint64 `json:"row_count"`
+	Done     bool   `json:"done"`
+}
+
+func (m *Migration) checkpointPath(chunk *migrationChunk) string {
+	return filepath.Join(m.checkpointDir, fmt.Sprintf("%s-%05d.json", chunk.table.Name, chunk.index))
+}
+
+func (m *Migration) loadCheckpoint(chunk *migrationChunk) (*migrationCheckpoint, error) {
+	checkpoint := &migrationCheckpoint{Start: hex.EncodeToString(chunk.start), End: hex.EncodeToString(chunk.end)}
+	if m.checkpointDir == "" {
+		return checkpoint, nil
+	}
+
+	content, err := ioutil.ReadFile(m.checkpointPath(chunk))
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read checkpoint: %w", err)
+	}
+
+	saved := &migrationCheckpoint{}
+	if err := json.Unmarshal(content, saved); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %s: %w", m.checkpointPath(chunk), err)
+	}
+
+	// Chunk boundaries depend on the chunk count, which must not change between runs
+	if saved.Start != checkpoint.Start || saved.End != checkpoint.End {
+		return nil, fmt.Errorf("checkpoint %s was written for range %s-%s, not %s-%s, was the chunk count changed?", m.checkpointPath(chunk), saved.Start, saved.End, checkpoint.Start, checkpoint.End)
+	}
+
+	return saved, nil
+}
+
+func (m *Migration) saveCheckpoint(chunk *migrationChunk, checkpoint *migrationCheckpoint) error {
+	if m.checkpointDir == "" {
+		return nil
+	}
+
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("unable to marshal checkpoint: %w", err)
+	}
+
+	// Written then renamed so a crash never leaves a truncated checkpoint behind
+	path := m.checkpointPath(chunk)
+	if err := ioutil.WriteFile(path+".tmp", content, 0644); err != nil {
+		return fmt.Errorf("unable to write checkpoint: %w", err)
+	}
+
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("unable to write checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// MigrationVerification is the result of the verification of a table
+type MigrationVerification struct {
+	Table            string
+	SourceCount      uint64
+	DestinationCount uint64
+	SampleCount      uint64
+
+	// MismatchedKeys are the sampled keys missing from the destination or having a different
+	// value there.
+	MismatchedKeys [][]byte
+}
+
+func (v *MigrationVerification) Ok() bool {
+	return v.SourceCount == v.DestinationCount && len(v.MismatchedKeys) == 0
+}
+
+// Verify compares, for each table, the row counts of both stores and the values of the
+// sampled rows.
+func (m *Migration) Verify(ctx context.Context) (out []*MigrationVerification, err error) {
+	verifications := make(map[string]*MigrationVerification)
+	for _, table := range m.tables {
+		verification := &MigrationVerification{Table: table.Name}
+		verifications[table.Name] = verification
+		out = append(out, verification)
+	}
+
+	var verificationsLock sync.Mutex
+	err = m.forEachChunk(func(chunk *migrationChunk) error {
+		chunkVerification, err := m.verifyChunk(ctx, chunk)
+		if err != nil {
+			return fmt.Errorf("verifying %s chunk #%d: %w", chunk.table.Name, chunk.index, err)
+		}
+
+		verificationsLock.Lock()
+		defer verificationsLock.Unlock()
+
+		verification := verifications[chunk.table.Name]
+		verification.SourceCount += chunkVerification.SourceCount
+		verification.DestinationCount += chunkVerification.DestinationCount
+		verification.SampleCount += chunkVerification.SampleCount
+		verification.MismatchedKeys = append(verification.MismatchedKeys, chunkVerification.MismatchedKeys...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (m *Migration) verifyChunk(ctx context.Context, chunk *migrationChunk) (*MigrationVerification, error) {
+	out := &MigrationVerification{}
+
+	var samples []*migrationRow
+	err := m.scanChunk(ctx, m.source, chunk, func(key, value []byte) {
+		if out.SourceCount%m.sampleEvery == 0 {
+			samples = append(samples, newMigrationRow(key, value))
+		}
+		out.SourceCount++
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning source: %w", err)
+	}
+
+	err = m.scanChunk(ctx, m.destination, chunk, func(key, value []byte) {
+		out.DestinationCount++
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning destination: %w", err)
+	}
+
+	for _, sample := range samples {
+		out.SampleCount++
+
+		value, err := m.destination.Get(ctx, sample.key)
+		if err == store.ErrNotFound {
+			out.MismatchedKeys = append(out.MismatchedKeys, sample.key)
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("getting destination sample: %w", err)
+		}
+
+		if !bytes.Equal(value, sample.value) {
+			out.MismatchedKeys = append(out.MismatchedKeys, sample.key)
+		}
+	}
+
+	return out, nil
+}
+
+func (m *Migration) scanChunk(ctx context.Context, s store.KVStore, chunk *migrationChunk, f func(key, value []byte)) error {
+	start := chunk.start
+	for {
+		count := 0
+		it := s.Scan(ctx, start, chunk.end, m.batchSize)
+		for it.Next() {
+			item := it.Item()
+			f(item.Key, item.Value)
+
+			count++
+			start = append(append([]byte{}, item.Key...), 0x00)
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+
+		if count < m.batchSize {
+			return nil
+		}
+	}
+}
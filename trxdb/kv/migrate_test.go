@@ -0,0 +1,189 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dfuse-io/kvdb/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigration(t *testing.T) {
+	ctx := context.Background()
+	source, cleanSource := newTestMigrationStore(t)
+	defer cleanSource()
+	destination, cleanDestination := newTestMigrationStore(t)
+	defer cleanDestination()
+
+	rows := putTestMigrationRows(t, source)
+
+	checkpointDir, err := ioutil.TempDir("", "dfuse-trxdb-migration")
+	require.NoError(t, err)
+	defer os.RemoveAll(checkpointDir)
+
+	migration, err := NewMigration(source, destination, WithMigrationChunks(4, 2), WithMigrationBatchSize(3), WithMigrationCheckpointDir(checkpointDir), WithMigrationSampling(1))
+	require.NoError(t, err)
+
+	rowCounts, err := migration.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), rowCounts["blocks"])
+	assert.Equal(t, uint64(8), rowCounts["trxs"])
+	assert.Equal(t, uint64(4), rowCounts["timeline_bck"])
+
+	for key, value := range rows {
+		actual, err := destination.Get(ctx, []byte(key))
+		require.NoError(t, err)
+		assert.Equal(t, value, actual)
+	}
+
+	verifications, err := migration.Verify(ctx)
+	require.NoError(t, err)
+	require.Len(t, verifications, len(MigrationTables))
+	for _, verification := range verifications {
+		assert.True(t, verification.Ok(), "table %s", verification.Table)
+	}
+
+	// Completed chunks are skipped by the next runs, using their checkpointed counts
+	rowCounts, err = migration.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), rowCounts["trxs"])
+}
+
+func TestMigration_Resume(t *testing.T) {
+	ctx := context.Background()
+	source, cleanSource := newTestMigrationStore(t)
+	defer cleanSource()
+	destination, cleanDestination := newTestMigrationStore(t)
+	defer cleanDestination()
+
+	putTestMigrationRows(t, source)
+
+	checkpointDir, err := ioutil.TempDir("", "dfuse-trxdb-migration")
+	require.NoError(t, err)
+	defer os.RemoveAll(checkpointDir)
+
+	migration, err := NewMigration(source, destination, WithMigrationTables("trxs"), WithMigrationChunks(1, 1), WithMigrationBatchSize(3), WithMigrationCheckpointDir(checkpointDir))
+	require.NoError(t, err)
+
+	// Simulates a migration interrupted after its first batch
+	chunk := migration.chunks()[0]
+	firstKeys := scanTestMigrationKeys(t, source, chunk.start, chunk.end, 3)
+	require.NoError(t, migration.saveCheckpoint(chunk, &migrationCheckpoint{
+		Start:    fmt.Sprintf("%x", chunk.start),
+		End:      fmt.Sprintf("%x", chunk.end),
+		LastKey:  fmt.Sprintf("%x", firstKeys[2]),
+		RowCount: 3,
+	}))
+
+	rowCounts, err := migration.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), rowCounts["trxs"])
+
+	copiedKeys := scanTestMigrationKeys(t, destination, chunk.start, chunk.end, store.Unlimited)
+	assert.Len(t, copiedKeys, 5)
+	for _, key := range firstKeys {
+		_, err := destination.Get(ctx, key)
+		assert.Equal(t, store.ErrNotFound, err)
+	}
+
+	// Changing the chunk count would make the checkpoints cover other ranges
+	migration, err = NewMigration(source, destination, WithMigrationTables("trxs"), WithMigrationChunks(2, 1), WithMigrationCheckpointDir(checkpointDir))
+	require.NoError(t, err)
+
+	_, err = migration.Run(ctx)
+	assert.Error(t, err)
+}
+
+func TestMigration_VerifyMismatches(t *testing.T) {
+	ctx := context.Background()
+	source, cleanSource := newTestMigrationStore(t)
+	defer cleanSource()
+	destination, cleanDestination := newTestMigrationStore(t)
+	defer cleanDestination()
+
+	putTestMigrationRows(t, source)
+
+	migration, err := NewMigration(source, destination, WithMigrationTables("blocks", "accounts"), WithMigrationSampling(1))
+	require.NoError(t, err)
+
+	_, err = migration.Run(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, destination.Put(ctx, Keys.PackBlocksKey("00000002aa"), []byte("altered")))
+	require.NoError(t, destination.Put(ctx, Keys.PackAccountKey("extra"), []byte("extra")))
+	require.NoError(t, destination.FlushPuts(ctx))
+
+	verifications, err := migration.Verify(ctx)
+	require.NoError(t, err)
+	require.Len(t, verifications, 2)
+
+	assert.False(t, verifications[0].Ok())
+	assert.Equal(t, [][]byte{Keys.PackBlocksKey("00000002aa")}, verifications[0].MismatchedKeys)
+
+	assert.False(t, verifications[1].Ok())
+	assert.Equal(t, uint64(2), verifications[1].SourceCount)
+	assert.Equal(t, uint64(3), verifications[1].DestinationCount)
+	assert.Empty(t, verifications[1].MismatchedKeys)
+}
+
+func TestNewMigration_UnknownTable(t *testing.T) {
+	_, err := NewMigration(nil, nil, WithMigrationTables("blocks", "unknown"))
+	assert.EqualError(t, err, `unknown table "unknown"`)
+}
+
+func newTestMigrationStore(t *testing.T) (store.KVStore, func()) {
+	dir, err := ioutil.TempDir("", "dfuse-trxdb-kv-migration")
+	require.NoError(t, err)
+
+	kvStore, err := store.New(fmt.Sprintf("badger://%s", dir))
+	require.NoError(t, err)
+
+	return kvStore, func() {
+		kvStore.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func putTestMigrationRows(t *testing.T, s store.KVStore) map[string][]byte {
+	ctx := context.Background()
+	rows := map[string][]byte{}
+	put := func(key []byte, value string) {
+		rows[string(key)] = []byte(value)
+		require.NoError(t, s.Put(ctx, key, []byte(value)))
+	}
+
+	blockTime := time.Date(2020, time.February, 02, 12, 0, 0, 0, time.UTC)
+	for i, blockID := range []string{"00000002aa", "00000003aa", "00000004aa", "00000004bb"} {
+		put(Keys.PackBlocksKey(blockID), "block "+blockID)
+		put(Keys.PackIrrBlocksKey(blockID), "\x01")
+		put(Keys.PackTimelineKey(true, blockTime.Add(time.Duration(i)*time.Second), blockID), "\x01")
+		put(Keys.PackTimelineKey(false, blockTime.Add(time.Duration(i)*time.Second), blockID), "\x01")
+	}
+
+	// Transaction ids spread over all the chunks
+	for _, trxIDPrefix := range []string{"00", "3f", "40", "7f", "80", "bf", "c0", "ff"} {
+		trxID := trxIDPrefix + "aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899"[2:]
+		put(Keys.PackTrxsKey(trxID, "00000002aabbccddeeff00112233445566778899aabbccddeeff001122334455"), "trx "+trxID)
+	}
+
+	put(Keys.PackAccountKey("eoscanada1"), "account 1")
+	put(Keys.PackAccountKey("eoscanada2"), "account 2")
+
+	require.NoError(t, s.FlushPuts(ctx))
+	return rows
+}
+
+func scanTestMigrationKeys(t *testing.T, s store.KVStore, start, end []byte, limit int) (out [][]byte) {
+	it := s.Scan(context.Background(), start, end, limit)
+	for it.Next() {
+		out = append(out, append([]byte{}, it.Item().Key...))
+	}
+	require.NoError(t, it.Err())
+
+	return
+}
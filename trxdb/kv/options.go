@@ -1,6 +1,8 @@
 package kv
 
 import (
+	"fmt"
+
 	kvdbstore "github.com/dfuse-io/kvdb/store"
 	"go.uber.org/zap"
 )
@@ -16,6 +18,10 @@ func (db *DB) SetPurgeableStore(ttl, purgeInterval uint64) error {
 		zlog.Debug("applying pur")
 	}
 
+	if db.retentionStore != nil {
+		return fmt.Errorf("purgeable store cannot be combined with a retention policy")
+	}
+
 	if db.writeStore != nil {
 		db.writeStore = kvdbstore.NewPurgeableStore([]byte{TblTTL}, db.writeStore, ttl)
 	}
@@ -23,3 +29,8 @@ func (db *DB) SetPurgeableStore(ttl, purgeInterval uint64) error {
 	db.purgeInterval = purgeInterval
 	return nil
 }
+
+func (db *DB) SetAccountHistory() error {
+	db.enableAccountHistoryWrite = true
+	return nil
+}
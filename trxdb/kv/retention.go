@@ -0,0 +1,228 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dfuse-eosio/trxdb/metrics"
+	"github.com/dfuse-io/kvdb/store"
+	"go.uber.org/zap"
+)
+
+// RetentionTables are the tables a retention policy can be configured for, with their prefixes
+var RetentionTables = map[string][]byte{
+	"blocks":          {TblPrefixBlocks},
+	"trxs":            {TblPrefixTrxs},
+	"trx_traces":      {TblPrefixTrxTraces},
+	"implicit_trxs":   {TblPrefixImplTrxs},
+	"dtrxs":           {TblPrefixDtrxs},
+	"accounts":        {TblPrefixAccts},
+	"account_history": {TblPrefixAcctHistory},
+	"timeline":        {idxPrefixTimelineFwd, idxPrefixTimelineBck},
+}
+
+const retentionPurgeBatchSize = 1000
+
+// retentionStore schedules the purge of the rows it writes according to the TTL of their
+// table, rows of tables without a TTL being kept forever. Deferred transaction rows are not
+// scheduled when written, but once the deferred transaction is resolved, see
+// `scheduleResolvedDtrxsPurge`.
+type retentionStore struct {
+	store.KVStore
+
+	ttls   map[byte]uint64
+	height uint64
+}
+
+func newRetentionStore(s store.KVStore, ttls map[string]uint64) (*retentionStore, error) {
+	prefixTTLs := make(map[byte]uint64)
+	for table, ttl := range ttls {
+		prefixes, found := RetentionTables[table]
+		if !found {
+			return nil, fmt.Errorf("unknown retention table %q", table)
+		}
+
+		for _, prefix := range prefixes {
+			prefixTTLs[prefix] = ttl
+		}
+	}
+
+	return &retentionStore{KVStore: s, ttls: prefixTTLs}, nil
+}
+
+func (s *retentionStore) MarkCurrentHeight(height uint64) {
+	s.height = height
+}
+
+func (s *retentionStore) Put(ctx context.Context, key, value []byte) error {
+	if len(key) > 0 && key[0] != TblPrefixDtrxs {
+		if ttl, found := s.ttls[key[0]]; found {
+			if err := s.KVStore.Put(ctx, Keys.PackRetentionKey(s.height+ttl, key, false), oneByte); err != nil {
+				return fmt.Errorf("unable to schedule purge: %w", err)
+			}
+		}
+	}
+
+	return s.KVStore.Put(ctx, key, value)
+}
+
+// schedulePrefixPurge schedules the purge of all the keys starting with `prefix`, `ttl`
+// blocks from now.
+func (s *retentionStore) schedulePrefixPurge(ctx context.Context, prefix []byte, ttl uint64) error {
+	return s.KVStore.Put(ctx, Keys.PackRetentionKey(s.height+ttl, prefix, true), oneByte)
+}
+
+// purgeKeys deletes the keys scheduled to be purged at or before the current height, along
+// with their schedule, returning the number of purged keys per table prefix.
+func (s *retentionStore) purgeKeys(ctx context.Context) (purgedCounts map[byte]int, err error) {
+	// Keys scheduled for purge could still be buffered
+	if err := s.KVStore.FlushPuts(ctx); err != nil {
+		return nil, fmt.Errorf("unable to flush puts: %w", err)
+	}
+
+	purgedCounts = make(map[byte]int)
+	start := Keys.StartOfRetentionTable()
+	end := Keys.PackRetentionHeightPrefix(s.height + 1)
+	for {
+		var entries [][]byte
+		it := s.KVStore.Scan(ctx, start, end, retentionPurgeBatchSize)
+		for it.Next() {
+			entries = append(entries, append([]byte{}, it.Item().Key...))
+		}
+		if err := it.Err(); err != nil {
+			return nil, fmt.Errorf("unable to scan retention table: %w", err)
+		}
+
+		if len(entries) == 0 {
+			return purgedCounts, nil
+		}
+
+		keys := append([][]byte{}, entries...)
+		for _, entry := range entries {
+			_, target, isPrefix := Keys.UnpackRetentionKey(entry)
+			if !isPrefix {
+				keys = append(keys, target)
+				purgedCounts[target[0]]++
+				continue
+			}
+
+			prefixIt := s.KVStore.Prefix(ctx, target, store.Unlimited)
+			for prefixIt.Next() {
+				keys = append(keys, append([]byte{}, prefixIt.Item().Key...))
+				purgedCounts[target[0]]++
+			}
+			if err := prefixIt.Err(); err != nil {
+				return nil, fmt.Errorf("unable to scan purged prefix: %w", err)
+			}
+		}
+
+		if err := s.KVStore.BatchDelete(ctx, keys); err != nil {
+			return nil, fmt.Errorf("unable to delete purged keys: %w", err)
+		}
+
+		if len(entries) < retentionPurgeBatchSize {
+			return purgedCounts, nil
+		}
+
+		start = append(entries[len(entries)-1], 0x00)
+	}
+}
+
+func (db *DB) SetRetentionPolicy(ttls map[string]uint64, purgeInterval uint64) error {
+	if purgeInterval == 0 {
+		return fmt.Errorf("retention policy purge interval must be greater than 0")
+	}
+
+	if db.writeStore == nil {
+		return nil
+	}
+
+	if _, ok := db.writeStore.(store.Purgeable); ok {
+		return fmt.Errorf("retention policy cannot be combined with the purgeable store")
+	}
+
+	retentionStore, err := newRetentionStore(db.writeStore, ttls)
+	if err != nil {
+		return err
+	}
+
+	db.retentionStore = retentionStore
+	db.writeStore = retentionStore
+	db.purgeInterval = purgeInterval
+	return nil
+}
+
+// scheduleResolvedDtrxsPurge schedules the purge of the rows of the deferred transactions
+// resolved by the transaction, which are kept until then: the deferred transaction itself
+// once executed (or expired, nodeos producing a trace in that case too), and the ones it
+// cancelled or that failed.
+func (db *DB) scheduleResolvedDtrxsPurge(ctx context.Context, trxTrace *pbcodec.TransactionTrace) error {
+	ttl, found := db.retentionStore.ttls[TblPrefixDtrxs]
+	if !found {
+		return nil
+	}
+
+	if trxTrace.Scheduled {
+		if err := db.retentionStore.schedulePrefixPurge(ctx, Keys.PackDtrxsPrefix(trxTrace.Id), ttl); err != nil {
+			return err
+		}
+	}
+
+	for _, dtrxOp := range trxTrace.DtrxOps {
+		if dtrxOp.IsCancelOperation() || dtrxOp.IsFailedOperation() {
+			if err := db.retentionStore.schedulePrefixPurge(ctx, Keys.PackDtrxsPrefix(dtrxOp.TransactionId), ttl); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) purgeRetentionStore(ctx context.Context, s *retentionStore, blkNumber uint64) error {
+	s.MarkCurrentHeight(blkNumber)
+	if blkNumber == 0 || (blkNumber%db.purgeInterval) != 0 {
+		return nil
+	}
+
+	purgedCounts, err := s.purgeKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to purge store: %w", err)
+	}
+
+	total := 0
+	for prefix, count := range purgedCounts {
+		metrics.PurgedKeyCount.AddInt(count, retentionTableName(prefix))
+		total += count
+	}
+
+	db.logger.Debug("purged keys", zap.Uint64("block_num", blkNumber), zap.Int("purged_key_count", total))
+	return nil
+}
+
+func retentionTableName(prefix byte) string {
+	for table, prefixes := range RetentionTables {
+		for _, tablePrefix := range prefixes {
+			if tablePrefix == prefix {
+				return table
+			}
+		}
+	}
+
+	return fmt.Sprintf("0x%02x", prefix)
+}
@@ -0,0 +1,161 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/kvdb/store"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRetentionTrxID  = "00112233aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	testRetentionDtrxID = "44556677bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	testRetentionBlkID  = "00000002aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+)
+
+func TestRetentionPolicy(t *testing.T) {
+	ctx := context.Background()
+	db, clean := newTestRetentionDB(t, map[string]uint64{"trx_traces": 2, "dtrxs": 1}, 1)
+	defer clean()
+
+	traceKey := Keys.PackTrxTracesKey(testRetentionTrxID, testRetentionBlkID)
+	trxKey := Keys.PackTrxsKey(testRetentionTrxID, testRetentionBlkID)
+	dtrxKey := Keys.PackDtrxsKeyCreated(testRetentionDtrxID, testRetentionBlkID)
+
+	require.NoError(t, db.purgeSetupAndAttempt(ctx, db.writeStore, 10))
+	require.NoError(t, db.writeStore.Put(ctx, traceKey, []byte("trace")))
+	require.NoError(t, db.writeStore.Put(ctx, trxKey, []byte("trx")))
+	require.NoError(t, db.writeStore.Put(ctx, dtrxKey, []byte("dtrx")))
+	require.NoError(t, db.Flush(ctx))
+
+	require.NoError(t, db.purgeSetupAndAttempt(ctx, db.writeStore, 11))
+	assertRetentionKeys(t, db, traceKey, true, trxKey, true, dtrxKey, true)
+
+	require.NoError(t, db.purgeSetupAndAttempt(ctx, db.writeStore, 12))
+	assertRetentionKeys(t, db, traceKey, false, trxKey, true, dtrxKey, true)
+
+	// Deferred transaction rows are only purged once the deferred transaction executed
+	require.NoError(t, db.purgeSetupAndAttempt(ctx, db.writeStore, 20))
+	assertRetentionKeys(t, db, dtrxKey, true)
+
+	require.NoError(t, db.scheduleResolvedDtrxsPurge(ctx, &pbcodec.TransactionTrace{Id: testRetentionDtrxID, Scheduled: true}))
+	require.NoError(t, db.Flush(ctx))
+
+	require.NoError(t, db.purgeSetupAndAttempt(ctx, db.writeStore, 21))
+	assertRetentionKeys(t, db, dtrxKey, false, trxKey, true)
+
+	// Purged schedule entries are removed along with the keys
+	it := db.writeStore.Scan(ctx, Keys.StartOfRetentionTable(), Keys.EndOfRetentionTable(), store.Unlimited)
+	assert.False(t, it.Next())
+	require.NoError(t, it.Err())
+}
+
+func TestRetentionPolicy_CancelledDtrx(t *testing.T) {
+	ctx := context.Background()
+	db, clean := newTestRetentionDB(t, map[string]uint64{"dtrxs": 0}, 5)
+	defer clean()
+
+	createdKey := Keys.PackDtrxsKeyCreated(testRetentionDtrxID, testRetentionBlkID)
+	cancelledKey := Keys.PackDtrxsKeyCancelled(testRetentionDtrxID, "00000003aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+
+	require.NoError(t, db.purgeSetupAndAttempt(ctx, db.writeStore, 3))
+	require.NoError(t, db.writeStore.Put(ctx, createdKey, []byte("created")))
+	require.NoError(t, db.writeStore.Put(ctx, cancelledKey, []byte("cancelled")))
+	require.NoError(t, db.scheduleResolvedDtrxsPurge(ctx, &pbcodec.TransactionTrace{
+		Id:      testRetentionTrxID,
+		DtrxOps: []*pbcodec.DTrxOp{{Operation: pbcodec.DTrxOp_OPERATION_CANCEL, TransactionId: testRetentionDtrxID}},
+	}))
+	require.NoError(t, db.Flush(ctx))
+
+	// Purges only happen at the purge interval
+	require.NoError(t, db.purgeSetupAndAttempt(ctx, db.writeStore, 4))
+	assertRetentionKeys(t, db, createdKey, true, cancelledKey, true)
+
+	require.NoError(t, db.purgeSetupAndAttempt(ctx, db.writeStore, 5))
+	assertRetentionKeys(t, db, createdKey, false, cancelledKey, false)
+}
+
+func TestRetentionPolicy_IrreversibleBlockHeight(t *testing.T) {
+	ctx := context.Background()
+	db, clean := newTestRetentionDB(t, map[string]uint64{"blocks": 5, "timeline": 5}, 100)
+	defer clean()
+
+	blockTime, err := ptypes.TimestampProto(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	headBlock := &pbcodec.Block{Id: "00000014aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Number: 20, Header: &pbcodec.BlockHeader{Timestamp: blockTime}}
+	nextHeadBlock := &pbcodec.Block{Id: "00000015aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Number: 21, Header: &pbcodec.BlockHeader{Timestamp: blockTime}}
+	irrBlock := &pbcodec.Block{Id: "0000000aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Number: 10, Header: &pbcodec.BlockHeader{Timestamp: blockTime}}
+
+	// Irreversible rows are scheduled from their own block, whatever the head block is
+	require.NoError(t, db.PutBlock(ctx, headBlock))
+	require.NoError(t, db.UpdateNowIrreversibleBlock(ctx, irrBlock))
+	require.NoError(t, db.PutBlock(ctx, nextHeadBlock))
+	require.NoError(t, db.Flush(ctx))
+
+	purgeHeights := map[string]uint64{}
+	it := db.writeStore.Scan(ctx, Keys.StartOfRetentionTable(), Keys.EndOfRetentionTable(), store.Unlimited)
+	for it.Next() {
+		purgeHeight, target, _ := Keys.UnpackRetentionKey(it.Item().Key)
+		purgeHeights[string(target)] = purgeHeight
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, map[string]uint64{
+		string(Keys.PackBlocksKey(headBlock.Id)):                              25,
+		string(Keys.PackBlocksKey(nextHeadBlock.Id)):                          26,
+		string(Keys.PackTimelineKey(true, irrBlock.MustTime(), irrBlock.Id)):  15,
+		string(Keys.PackTimelineKey(false, irrBlock.MustTime(), irrBlock.Id)): 15,
+	}, purgeHeights)
+}
+
+func TestSetRetentionPolicy_Invalid(t *testing.T) {
+	db, clean := newTestRetentionDB(t, nil, 1)
+	defer clean()
+
+	assert.EqualError(t, db.SetPurgeableStore(10, 1), "purgeable store cannot be combined with a retention policy")
+
+	db, clean = newTestRetentionDB(t, nil, 0)
+	defer clean()
+
+	assert.EqualError(t, db.SetRetentionPolicy(map[string]uint64{"unknown": 1}, 1), `unknown retention table "unknown"`)
+	assert.EqualError(t, db.SetRetentionPolicy(nil, 0), "retention policy purge interval must be greater than 0")
+}
+
+// newTestRetentionDB creates a db, applying the retention policy unless `purgeInterval` is 0
+func newTestRetentionDB(t *testing.T, ttls map[string]uint64, purgeInterval uint64) (*DB, func()) {
+	dir, err := ioutil.TempDir("", "dfuse-trxdb-kv-retention")
+	require.NoError(t, err)
+
+	db, err := New([]string{fmt.Sprintf("badger://%s", dir)})
+	require.NoError(t, err)
+
+	if purgeInterval > 0 {
+		require.NoError(t, db.SetRetentionPolicy(ttls, purgeInterval))
+	}
+
+	return db, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+// assertRetentionKeys takes pairs of key and whether it is expected to exist
+func assertRetentionKeys(t *testing.T, db *DB, keysAndExists ...interface{}) {
+	for i := 0; i < len(keysAndExists); i += 2 {
+		key := keysAndExists[i].([]byte)
+		_, err := db.writeStore.Get(context.Background(), key)
+		if keysAndExists[i+1].(bool) {
+			assert.NoError(t, err, "key %x should exist", key)
+		} else {
+			assert.Equal(t, store.ErrNotFound, err, "key %x should be purged", key)
+		}
+	}
+}
@@ -36,6 +36,10 @@ func (db *DB) SetWriterChainID(chainID []byte) {
 }
 
 func (db *DB) purgeSetupAndAttempt(ctx context.Context, s kvdbstore.KVStore, blkNumber uint64) error {
+	if s, ok := s.(*retentionStore); ok {
+		return db.purgeRetentionStore(ctx, s, blkNumber)
+	}
+
 	if s, ok := s.(kvdbstore.Purgeable); ok {
 		s.MarkCurrentHeight(blkNumber)
 		if blkNumber > 0 && (blkNumber%db.purgeInterval) == 0 {
@@ -99,25 +103,14 @@ func (db *DB) putTransactions(ctx context.Context, blk *pbcodec.Block) error {
 			continue
 		}
 
-		signedTransaction, err := codec.ExtractEOSSignedTransactionFromReceipt(trxReceipt)
+		value, err := db.encodeTrxRow(trxReceipt)
 		if err != nil {
-			return fmt.Errorf("unable to extract EOS signed transaction from transaction receipt: %s", err)
-		}
-
-		signedTrx := codec.SignedTransactionToDEOS(signedTransaction)
-		pubKeyProto := &pbcodec.PublicKeys{
-			PublicKeys: codec.GetPublicKeysFromSignedTransaction(db.writerChainID, signedTransaction),
-		}
-
-		trxRow := &pbtrxdb.TrxRow{
-			Receipt:    trxReceipt,
-			SignedTrx:  signedTrx,
-			PublicKeys: pubKeyProto,
+			return err
 		}
 
 		key := Keys.PackTrxsKey(trxReceipt.Id, blk.Id)
 		// NOTE: This function is guarded by the parent with db.enableTrxWrite
-		err = db.writeStore.Put(ctx, key, db.enc.MustProto(trxRow))
+		err = db.writeStore.Put(ctx, key, value)
 
 		if err != nil {
 			return fmt.Errorf("put trx: write to db: %w", err)
@@ -127,39 +120,45 @@ func (db *DB) putTransactions(ctx context.Context, blk *pbcodec.Block) error {
 	return nil
 }
 
+func (db *DB) encodeTrxRow(trxReceipt *pbcodec.TransactionReceipt) ([]byte, error) {
+	signedTransaction, err := codec.ExtractEOSSignedTransactionFromReceipt(trxReceipt)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract EOS signed transaction from transaction receipt: %s", err)
+	}
+
+	signedTrx := codec.SignedTransactionToDEOS(signedTransaction)
+	pubKeyProto := &pbcodec.PublicKeys{
+		PublicKeys: codec.GetPublicKeysFromSignedTransaction(db.writerChainID, signedTransaction),
+	}
+
+	trxRow := &pbtrxdb.TrxRow{
+		Receipt:    trxReceipt,
+		SignedTrx:  signedTrx,
+		PublicKeys: pubKeyProto,
+	}
+
+	return db.enc.MustProto(trxRow), nil
+}
+
 func (db *DB) putTransactionTraces(ctx context.Context, blk *pbcodec.Block) error {
 	for _, trxTrace := range blk.TransactionTraces() {
 		// CHECK: can we have multiple dtrxops for the same transactionId in the same block?
 		for _, dtrxOp := range trxTrace.DtrxOps {
-			extDtrxOp := dtrxOp.ToExtDTrxOp(blk, trxTrace)
-
-			dtrxRow := &pbtrxdb.DtrxRow{}
-
-			var key []byte
-			if dtrxOp.IsCreateOperation() {
-				dtrxRow.SignedTrx = dtrxOp.Transaction
-				dtrxRow.CreatedBy = extDtrxOp
-				key = Keys.PackDtrxsKeyCreated(dtrxOp.TransactionId, blk.Id)
-			} else if dtrxOp.IsCancelOperation() {
-				dtrxRow.CanceledBy = extDtrxOp
-				key = Keys.PackDtrxsKeyCancelled(dtrxOp.TransactionId, blk.Id)
-			} else if dtrxOp.IsFailedOperation() {
-				key = Keys.PackDtrxsKeyFailed(dtrxOp.TransactionId, blk.Id)
-			} else {
-				return fmt.Errorf("put dtrxRow: handle dtrxOp Operation: unknown dtrxOp operation for trx id %s at action %d", trxTrace.Id, dtrxOp.ActionIndex)
+			key, value, err := db.encodeDtrxRow(blk, trxTrace, dtrxOp)
+			if err != nil {
+				return fmt.Errorf("put dtrxRow: %w", err)
 			}
 
 			// NOTE: This function is guarded by the parent with db.enableTrxWrite
-			if err := db.writeStore.Put(ctx, key, db.enc.MustProto(dtrxRow)); err != nil {
+			if err := db.writeStore.Put(ctx, key, value); err != nil {
 				return fmt.Errorf("put dtrxRow: write to db: %w", err)
 			}
 		}
 
-		codec.DeduplicateTransactionTrace(trxTrace)
-
-		trxTraceRow := &pbtrxdb.TrxTraceRow{
-			BlockHeader: blk.Header,
-			TrxTrace:    trxTrace,
+		if db.retentionStore != nil {
+			if err := db.scheduleResolvedDtrxsPurge(ctx, trxTrace); err != nil {
+				return fmt.Errorf("schedule resolved dtrxs purge: %w", err)
+			}
 		}
 
 		if traceEnabled {
@@ -168,16 +167,49 @@ func (db *DB) putTransactionTraces(ctx context.Context, blk *pbcodec.Block) erro
 
 		key := Keys.PackTrxTracesKey(trxTrace.Id, blk.Id)
 		// NOTE: This function is guarded by the parent with db.enableTrxWrite
-		if err := db.writeStore.Put(ctx, key, db.enc.MustProto(trxTraceRow)); err != nil {
+		if err := db.writeStore.Put(ctx, key, db.encodeTrxTraceRow(blk, trxTrace)); err != nil {
 			return fmt.Errorf("put trxTraceRow: write to db: %w", err)
 		}
-
-		codec.ReduplicateTransactionTrace(trxTrace)
 	}
 
 	return nil
 }
 
+func (db *DB) encodeDtrxRow(blk *pbcodec.Block, trxTrace *pbcodec.TransactionTrace, dtrxOp *pbcodec.DTrxOp) (key []byte, value []byte, err error) {
+	extDtrxOp := dtrxOp.ToExtDTrxOp(blk, trxTrace)
+
+	dtrxRow := &pbtrxdb.DtrxRow{}
+
+	if dtrxOp.IsCreateOperation() {
+		dtrxRow.SignedTrx = dtrxOp.Transaction
+		dtrxRow.CreatedBy = extDtrxOp
+		key = Keys.PackDtrxsKeyCreated(dtrxOp.TransactionId, blk.Id)
+	} else if dtrxOp.IsCancelOperation() {
+		dtrxRow.CanceledBy = extDtrxOp
+		key = Keys.PackDtrxsKeyCancelled(dtrxOp.TransactionId, blk.Id)
+	} else if dtrxOp.IsFailedOperation() {
+		key = Keys.PackDtrxsKeyFailed(dtrxOp.TransactionId, blk.Id)
+	} else {
+		return nil, nil, fmt.Errorf("handle dtrxOp Operation: unknown dtrxOp operation for trx id %s at action %d", trxTrace.Id, dtrxOp.ActionIndex)
+	}
+
+	return key, db.enc.MustProto(dtrxRow), nil
+}
+
+// encodeTrxTraceRow encodes the deduplicated form of the transaction trace, the trace
+// being left untouched once encoded.
+func (db *DB) encodeTrxTraceRow(blk *pbcodec.Block, trxTrace *pbcodec.TransactionTrace) []byte {
+	codec.DeduplicateTransactionTrace(trxTrace)
+	defer codec.ReduplicateTransactionTrace(trxTrace)
+
+	trxTraceRow := &pbtrxdb.TrxTraceRow{
+		BlockHeader: blk.Header,
+		TrxTrace:    trxTrace,
+	}
+
+	return db.enc.MustProto(trxTraceRow)
+}
+
 func (db *DB) putNewAccount(ctx context.Context, blk *pbcodec.Block, trace *pbcodec.TransactionTrace, act *pbcodec.ActionTrace) error {
 	t, err := ptypes.TimestampProto(blk.MustTime())
 	if err != nil {
@@ -207,14 +239,9 @@ func (db *DB) putNewAccount(ctx context.Context, blk *pbcodec.Block, trace *pbco
 
 func (db *DB) putImplicitTransactions(ctx context.Context, blk *pbcodec.Block) error {
 	for _, trxOp := range blk.ImplicitTransactionOps() {
-		implTrxRow := &pbtrxdb.ImplicitTrxRow{
-			Name:      trxOp.Name,
-			SignedTrx: trxOp.Transaction,
-		}
-
 		key := Keys.PackImplicitTrxsKey(trxOp.TransactionId, blk.Id)
 		// NOTE: This function is guarded by the parent with db.enableTrxWrite
-		if err := db.writeStore.Put(ctx, key, db.enc.MustProto(implTrxRow)); err != nil {
+		if err := db.writeStore.Put(ctx, key, db.encodeImplicitTrxRow(trxOp)); err != nil {
 			return fmt.Errorf("put implTrx: write to db: %w", err)
 		}
 	}
@@ -222,6 +249,15 @@ func (db *DB) putImplicitTransactions(ctx context.Context, blk *pbcodec.Block) e
 	return nil
 }
 
+func (db *DB) encodeImplicitTrxRow(trxOp *pbcodec.TrxOp) []byte {
+	implTrxRow := &pbtrxdb.ImplicitTrxRow{
+		Name:      trxOp.Name,
+		SignedTrx: trxOp.Transaction,
+	}
+
+	return db.enc.MustProto(implTrxRow)
+}
+
 func (db *DB) getRefs(blk *pbcodec.Block) (implicitTrxRefs, trxRefs, tracesRefs *pbcodec.TransactionRefs) {
 	implicitTrxRefs = &pbcodec.TransactionRefs{}
 	for _, trxOp := range blk.ImplicitTransactionOps() {
@@ -242,6 +278,19 @@ func (db *DB) getRefs(blk *pbcodec.Block) (implicitTrxRefs, trxRefs, tracesRefs
 }
 
 func (db *DB) putBlock(ctx context.Context, blk *pbcodec.Block) error {
+	db.logger.Debug("put block", zap.Stringer("block", blk.AsRef()))
+	key := Keys.PackBlocksKey(blk.Id)
+	// NOTE: This function is guarded by the parent with db.enableBlkWrite
+	if err := db.writeStore.Put(ctx, key, db.encodeBlockRow(blk)); err != nil {
+		return fmt.Errorf("put block: write to db: %w", err)
+	}
+
+	return nil
+}
+
+// encodeBlockRow encodes the block without its transactions, traces and implicit
+// transactions, stored in their own rows and only referenced by the block row.
+func (db *DB) encodeBlockRow(blk *pbcodec.Block) []byte {
 	implicitTrxRefs, trxRefs, tracesRefs := db.getRefs(blk)
 
 	holdUnfilteredTransactions := blk.UnfilteredTransactions
@@ -267,12 +316,7 @@ func (db *DB) putBlock(ctx context.Context, blk *pbcodec.Block) error {
 		TraceRefs:       tracesRefs,
 	}
 
-	db.logger.Debug("put block", zap.Stringer("block", blk.AsRef()))
-	key := Keys.PackBlocksKey(blk.Id)
-	// NOTE: This function is guarded by the parent with db.enableBlkWrite
-	if err := db.writeStore.Put(ctx, key, db.enc.MustProto(blockRow)); err != nil {
-		return fmt.Errorf("put block: write to db: %w", err)
-	}
+	value := db.enc.MustProto(blockRow)
 
 	blk.UnfilteredTransactions = holdUnfilteredTransactions
 	blk.UnfilteredTransactionTraces = holdUnfilteredTransactionTraces
@@ -282,12 +326,18 @@ func (db *DB) putBlock(ctx context.Context, blk *pbcodec.Block) error {
 	blk.FilteredTransactionTraces = holdFilteredTransactionTraces
 	blk.FilteredImplicitTransactionOps = holdFilteredImplicitTransactionOps
 
-	return nil
+	return value
 }
 
 var oneByte = []byte{0x01}
 
 func (db *DB) UpdateNowIrreversibleBlock(ctx context.Context, blk *pbcodec.Block) error {
+	if db.retentionStore != nil {
+		// In live mode, the last block put is the head block, but the rows written here must
+		// be retained from the irreversible block they belong to.
+		defer db.retentionStore.MarkCurrentHeight(db.retentionStore.height)
+		db.retentionStore.MarkCurrentHeight(uint64(blk.Number))
+	}
 
 	if db.enableBlkWrite {
 		blockTime := blk.MustTime()
@@ -317,6 +367,12 @@ func (db *DB) UpdateNowIrreversibleBlock(ctx context.Context, blk *pbcodec.Block
 		db.logger.Debug("account is not written, skipping")
 	}
 
+	if db.enableAccountHistoryWrite && db.writeStore != nil {
+		if err := db.putAccountHistory(ctx, blk); err != nil {
+			return fmt.Errorf("failed to put account history: %w", err)
+		}
+	}
+
 	if db.writeStore != nil {
 		// FIXME: to WHICH store are we writing this? Both `blk` and `trx` databases need that marker!
 		// We must do this operation regardless of the write only categories set since this is used
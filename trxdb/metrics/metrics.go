@@ -0,0 +1,23 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/dfuse-io/dmetrics"
+)
+
+var Metricset = dmetrics.NewSet()
+
+var PurgedKeyCount = Metricset.NewCounterVec("trxdb_purged_key_count", []string{"table"}, "Number of keys purged by the trxdb retention policy, per table")
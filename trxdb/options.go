@@ -14,7 +14,13 @@
 
 package trxdb
 
-import "go.uber.org/zap"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
 
 type Option func(db DB) error
 
@@ -39,3 +45,62 @@ func WithPurgeableStoreOption(ttl, purgeInterval uint64) Option {
 		return nil
 	}
 }
+
+// WithAccountHistoryOption enables the writing of the account history index, making it
+// available through `AccountHistoryReader` for drivers supporting it.
+func WithAccountHistoryOption() Option {
+	return func(db DB) error {
+		if d, ok := db.(interface {
+			SetAccountHistory() error
+		}); ok {
+			return d.SetAccountHistory()
+		}
+		return nil
+	}
+}
+
+// WithRetentionPolicyOption purges the rows of each table of `ttls` that many blocks after they
+// were written, rows of other tables being kept forever, checking for rows to purge every
+// `purgeInterval` blocks. Deferred transaction rows are kept until the deferred transaction
+// executes, expires, fails or is cancelled, their TTL counting from then.
+func WithRetentionPolicyOption(ttls map[string]uint64, purgeInterval uint64) Option {
+	return func(db DB) error {
+		if d, ok := db.(interface {
+			SetRetentionPolicy(ttls map[string]uint64, purgeInterval uint64) error
+		}); ok {
+			return d.SetRetentionPolicy(ttls, purgeInterval)
+		}
+		return nil
+	}
+}
+
+// ParseRetentionPolicy parses a comma separated list of `table=ttl` pairs, like
+// `trx_traces=100000,dtrxs=1000`, into the TTLs of `WithRetentionPolicyOption`.
+func ParseRetentionPolicy(in string) (map[string]uint64, error) {
+	out := make(map[string]uint64)
+	for _, pair := range strings.Split(in, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid retention %q, expected format is table=ttl", pair)
+		}
+
+		table := strings.TrimSpace(parts[0])
+		if _, found := out[table]; found {
+			return nil, fmt.Errorf("retention of table %q is defined more than once", table)
+		}
+
+		ttl, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention %q ttl: %w", pair, err)
+		}
+
+		out[table] = ttl
+	}
+
+	return out, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trxdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetentionPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		expected    map[string]uint64
+		expectedErr string
+	}{
+		{"empty", "", map[string]uint64{}, ""},
+		{"single", "trx_traces=100000", map[string]uint64{"trx_traces": 100000}, ""},
+		{"multiple with spaces", "trx_traces = 100000, dtrxs=0,", map[string]uint64{"trx_traces": 100000, "dtrxs": 0}, ""},
+		{"missing ttl", "trx_traces", nil, `invalid retention "trx_traces", expected format is table=ttl`},
+		{"invalid ttl", "trx_traces=-1", nil, `invalid retention "trx_traces=-1" ttl: strconv.ParseUint: parsing "-1": invalid syntax`},
+		{"duplicated table", "dtrxs=1,dtrxs=2", nil, `retention of table "dtrxs" is defined more than once`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := ParseRetentionPolicy(test.in)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"fmt"
+	"time"
+
+	"github.com/dfuse-io/bstream"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbtrxdb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/trxdb/v1"
+	"github.com/dfuse-io/kvdb"
+	"github.com/eoscanada/eos-go"
+	"go.uber.org/zap"
+)
+
+// selectBlocks reads block rows along with their irreversibility, blocks of the same number
+// must be ordered by id like the kv driver does.
+const selectBlocks = `SELECT b.value, i.id IS NOT NULL FROM blocks b LEFT JOIN irr_blocks i ON i.id = b.id`
+
+// This is in the writer interface, because it is required to start the the pipeline.
+func (db *DB) GetLastWrittenIrreversibleBlockRef(ctx context.Context) (ref bstream.BlockRef, err error) {
+	db.logger.Debug("get last written irr")
+
+	return db.getIrrBlockRef(ctx, `SELECT id FROM irr_blocks ORDER BY num DESC, id ASC LIMIT 1`)
+}
+
+func (db *DB) GetLastWrittenBlockID(ctx context.Context) (blockID string, err error) {
+	err = db.queryRow(ctx, `SELECT id FROM blocks ORDER BY num DESC, id ASC LIMIT 1`).Scan(&blockID)
+	if err == dbsql.ErrNoRows {
+		return "", kvdb.ErrNotFound
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("unable to get last written block: %w", err)
+	}
+
+	return blockID, nil
+}
+
+func (db *DB) GetBlock(ctx context.Context, id string) (*pbcodec.BlockWithRefs, error) {
+	blocks, err := db.listBlocks(ctx, selectBlocks+` WHERE b.id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get block: %w", err)
+	}
+
+	if len(blocks) == 0 {
+		return nil, kvdb.ErrNotFound
+	}
+
+	return blocks[0], nil
+}
+
+func (db *DB) GetBlockByNum(ctx context.Context, num uint32) (out []*pbcodec.BlockWithRefs, err error) {
+	db.logger.Debug("get block by num", zap.Uint32("block_num", num))
+	out, err = db.listBlocks(ctx, selectBlocks+` WHERE b.num = ? ORDER BY b.id ASC`, int64(num))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out) == 0 {
+		return nil, kvdb.ErrNotFound
+	}
+
+	return
+}
+
+func (db *DB) GetClosestIrreversibleIDAtBlockNum(ctx context.Context, num uint32) (ref bstream.BlockRef, err error) {
+	db.logger.Debug("get closest irr id at block num", zap.Uint32("block_num", num))
+
+	return db.getIrrBlockRef(ctx, `SELECT id FROM irr_blocks WHERE num <= ? ORDER BY num DESC, id ASC LIMIT 1`, int64(num))
+}
+
+func (db *DB) GetIrreversibleIDAtBlockID(ctx context.Context, ID string) (ref bstream.BlockRef, err error) {
+	blk, err := db.GetBlock(ctx, ID)
+	if err != nil {
+		return nil, fmt.Errorf("get irreversible id at block id: get block: %w", err)
+	}
+
+	dposIrrNum := blk.Block.DposIrreversibleBlocknum
+
+	db.logger.Debug("get irr block by num", zap.Uint32("block_num", dposIrrNum))
+	return db.getIrrBlockRef(ctx, `SELECT id FROM irr_blocks WHERE num = ? ORDER BY id ASC LIMIT 1`, int64(dposIrrNum))
+}
+
+func (db *DB) getIrrBlockRef(ctx context.Context, query string, args ...interface{}) (bstream.BlockRef, error) {
+	var blockID string
+	err := db.queryRow(ctx, query, args...).Scan(&blockID)
+	if err == dbsql.ErrNoRows {
+		return nil, kvdb.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to get irreversible block: %w", err)
+	}
+
+	return bstream.NewBlockRefFromID(blockID), nil
+}
+
+func (db *DB) ListBlocks(ctx context.Context, highBlockNum uint32, limit int) (out []*pbcodec.BlockWithRefs, err error) {
+	db.logger.Debug("list blocks", zap.Uint32("high_block_num", highBlockNum), zap.Int("limit", limit))
+
+	query := selectBlocks + ` WHERE b.num <= ? ORDER BY b.num DESC, b.id ASC`
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	return db.listBlocks(ctx, query, int64(highBlockNum))
+}
+
+func (db *DB) ListSiblingBlocks(ctx context.Context, blockNum uint32, spread uint32) (out []*pbcodec.BlockWithRefs, err error) {
+	highBlockNum := int64(blockNum) + int64(spread)
+	lowBlockNum := int64(blockNum) - int64(spread+1)
+	db.logger.Debug("list sibling blocks", zap.Int64("high_block_num", highBlockNum), zap.Int64("low_block_num", lowBlockNum))
+
+	return db.listBlocks(ctx, selectBlocks+` WHERE b.num > ? AND b.num <= ? ORDER BY b.num DESC, b.id ASC`, lowBlockNum, highBlockNum)
+}
+
+func (db *DB) listBlocks(ctx context.Context, query string, args ...interface{}) (out []*pbcodec.BlockWithRefs, err error) {
+	rows, err := db.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var value []byte
+		var irreversible bool
+		if err := rows.Scan(&value, &irreversible); err != nil {
+			return nil, fmt.Errorf("scan block row: %w", err)
+		}
+
+		blockRow := &pbtrxdb.BlockRow{}
+		db.dec.MustInto(value, blockRow)
+
+		out = append(out, &pbcodec.BlockWithRefs{
+			Id:                      blockRow.Block.Id,
+			Block:                   blockRow.Block,
+			ImplicitTransactionRefs: blockRow.ImplicitTrxRefs,
+			TransactionRefs:         blockRow.TrxRefs,
+			TransactionTraceRefs:    blockRow.TraceRefs,
+			Irreversible:            irreversible,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (db *DB) BlockIDAt(ctx context.Context, start time.Time) (id string, err error) {
+	var deciSeconds int64
+	err = db.queryRow(ctx, `SELECT block_time, block_id FROM timeline WHERE block_time >= ? ORDER BY block_time ASC, block_id ASC LIMIT 1`, timeToDeciSeconds(start)).Scan(&deciSeconds, &id)
+	if err == dbsql.ErrNoRows {
+		return "", kvdb.ErrNotFound
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("unable to get block id at time: %w", err)
+	}
+
+	if start.Equal(deciSecondsToTime(deciSeconds)) {
+		return id, nil
+	}
+
+	return "", kvdb.ErrNotFound
+}
+
+func (db *DB) BlockIDAfter(ctx context.Context, start time.Time, inclusive bool) (id string, foundTime time.Time, err error) {
+	return db.blockIDAround(ctx, true, start, inclusive)
+}
+
+func (db *DB) BlockIDBefore(ctx context.Context, start time.Time, inclusive bool) (id string, foundTime time.Time, err error) {
+	return db.blockIDAround(ctx, false, start, inclusive)
+}
+
+func (db *DB) blockIDAround(ctx context.Context, fwd bool, start time.Time, inclusive bool) (id string, foundTime time.Time, err error) {
+	startDeciSeconds := timeToDeciSeconds(start)
+
+	// The timeline has a deci-second precision, `start` can only be excluded when it is
+	// representable, like the kv driver does.
+	excludeStart := !inclusive && deciSecondsToTime(startDeciSeconds).Equal(start)
+
+	query := `SELECT block_time, block_id FROM timeline WHERE block_time >= ? ORDER BY block_time ASC, block_id ASC LIMIT 1`
+	switch {
+	case fwd && excludeStart:
+		query = `SELECT block_time, block_id FROM timeline WHERE block_time > ? ORDER BY block_time ASC, block_id ASC LIMIT 1`
+	case !fwd && excludeStart:
+		query = `SELECT block_time, block_id FROM timeline WHERE block_time < ? ORDER BY block_time DESC, block_id ASC LIMIT 1`
+	case !fwd:
+		query = `SELECT block_time, block_id FROM timeline WHERE block_time <= ? ORDER BY block_time DESC, block_id ASC LIMIT 1`
+	}
+
+	var deciSeconds int64
+	err = db.queryRow(ctx, query, startDeciSeconds).Scan(&deciSeconds, &id)
+	if err == dbsql.ErrNoRows {
+		err = kvdb.ErrNotFound
+		return
+	}
+
+	if err != nil {
+		err = fmt.Errorf("unable to get block id around time: %w", err)
+		return
+	}
+
+	return id, deciSecondsToTime(deciSeconds), nil
+}
+
+func timeToDeciSeconds(t time.Time) int64 {
+	return t.UnixNano() / 100000000
+}
+
+func deciSecondsToTime(deciSeconds int64) time.Time {
+	return time.Unix(deciSeconds/10, (deciSeconds%10)*100000000).UTC()
+}
+
+func (db *DB) GetAccount(ctx context.Context, accountName string) (*pbcodec.AccountCreationRef, error) {
+	var value []byte
+	err := db.queryRow(ctx, `SELECT value FROM accounts WHERE name = ?`, accountName).Scan(&value)
+	if err == dbsql.ErrNoRows {
+		return nil, kvdb.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to get account: %w", err)
+	}
+
+	acctRow := &pbtrxdb.AccountRow{}
+	db.dec.MustInto(value, acctRow)
+	return &pbcodec.AccountCreationRef{
+		Account:       acctRow.Name,
+		Creator:       acctRow.Creator,
+		BlockNum:      uint64(eos.BlockNum(acctRow.BlockId)),
+		BlockId:       acctRow.BlockId,
+		BlockTime:     acctRow.BlockTime,
+		TransactionId: acctRow.TrxId,
+	}, nil
+}
+
+func (db *DB) ListAccountNames(ctx context.Context, concurrentReadCount uint32) (out []string, err error) {
+	if concurrentReadCount == 0 {
+		return nil, fmt.Errorf("invalid concurrent read")
+	}
+
+	rows, err := db.query(ctx, `SELECT name FROM accounts ORDER BY name ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scan account row: %w", err)
+		}
+
+		out = append(out, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return
+}
@@ -0,0 +1,276 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dfuse-io/dfuse-eosio/codec"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbtrxdb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/trxdb/v1"
+	"github.com/eoscanada/eos-go"
+)
+
+type trxEventType int
+
+const (
+	trxAdditionEvent trxEventType = iota + 1
+	trxExecutionEvent
+	implicitTrxEvent
+	dtrxEvent
+)
+
+var allTrxEventTypes = []trxEventType{trxAdditionEvent, trxExecutionEvent, implicitTrxEvent, dtrxEvent}
+
+func (db *DB) GetTransactionTraces(ctx context.Context, idPrefix string) (out []*pbcodec.TransactionEvent, err error) {
+	out, err = db.getTransactionEvents(ctx, []string{idPrefix}, trxExecutionEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = db.fillIrreversibilityData(ctx, out)
+	return
+}
+
+func (db *DB) GetTransactionEvents(ctx context.Context, idPrefix string) (out []*pbcodec.TransactionEvent, err error) {
+	out, err = db.getTransactionEvents(ctx, []string{idPrefix}, allTrxEventTypes...)
+	if err != nil {
+		return nil, err
+	}
+	err = db.fillIrreversibilityData(ctx, out)
+	return
+}
+
+func (db *DB) GetTransactionEventsBatch(ctx context.Context, idPrefixes []string) (out [][]*pbcodec.TransactionEvent, err error) {
+	flat, err := db.getTransactionEvents(ctx, idPrefixes, allTrxEventTypes...)
+	if err != nil {
+		return nil, err
+	}
+	err = db.fillIrreversibilityData(ctx, flat)
+	if err != nil {
+		return nil, err
+	}
+	out = splitEventsPerTrx(idPrefixes, flat)
+	return
+}
+
+func (db *DB) GetTransactionTracesBatch(ctx context.Context, idPrefixes []string) (out [][]*pbcodec.TransactionEvent, err error) {
+	flat, err := db.getTransactionEvents(ctx, idPrefixes, trxExecutionEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = db.fillIrreversibilityData(ctx, flat)
+	if err != nil {
+		return nil, err
+	}
+	out = splitEventsPerTrx(idPrefixes, flat)
+	return
+}
+
+func splitEventsPerTrx(inPrefixes []string, flatEvents []*pbcodec.TransactionEvent) (out [][]*pbcodec.TransactionEvent) {
+	for _, pref := range inPrefixes {
+		var trxEvs []*pbcodec.TransactionEvent
+		for _, ev := range flatEvents {
+			if strings.HasPrefix(ev.Id, pref) {
+				trxEvs = append(trxEvs, ev)
+			}
+		}
+		out = append(out, trxEvs)
+	}
+	return
+}
+
+func (db *DB) fillIrreversibilityData(ctx context.Context, events []*pbcodec.TransactionEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	blockIDs := make(map[string]bool)
+	var args []interface{}
+	for _, ev := range events {
+		if _, found := blockIDs[ev.BlockId]; !found {
+			blockIDs[ev.BlockId] = false
+			args = append(args, ev.BlockId)
+		}
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+	rows, err := db.query(ctx, `SELECT id FROM irr_blocks WHERE id IN (`+placeholders+`)`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var blockID string
+		if err := rows.Scan(&blockID); err != nil {
+			return fmt.Errorf("scan irr block row: %w", err)
+		}
+
+		blockIDs[blockID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, ev := range events {
+		ev.Irreversible = blockIDs[ev.BlockId]
+	}
+
+	return nil
+}
+
+func (db *DB) getTransactionEvents(ctx context.Context, idPrefixes []string, eventTypes ...trxEventType) (out []*pbcodec.TransactionEvent, err error) {
+	for _, idPrefix := range idPrefixes {
+		if !isHex(idPrefix) {
+			return nil, fmt.Errorf("invalid trx ID hex prefix %q", idPrefix)
+		}
+
+		for _, eventType := range eventTypes {
+			events, err := db.getTransactionEventsOfType(ctx, strings.ToLower(idPrefix), eventType)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, events...)
+		}
+	}
+
+	return
+}
+
+func (db *DB) getTransactionEventsOfType(ctx context.Context, idPrefix string, eventType trxEventType) (out []*pbcodec.TransactionEvent, err error) {
+	var query string
+	switch eventType {
+	case trxAdditionEvent:
+		query = `SELECT trx_id, block_id, value FROM trxs WHERE trx_id LIKE ? ORDER BY trx_id, block_id`
+	case trxExecutionEvent:
+		query = `SELECT trx_id, block_id, value FROM trx_traces WHERE trx_id LIKE ? ORDER BY trx_id, block_id`
+	case implicitTrxEvent:
+		query = `SELECT trx_id, block_id, value FROM implicit_trxs WHERE trx_id LIKE ? ORDER BY trx_id, block_id`
+	case dtrxEvent:
+		query = `SELECT trx_id, block_id, value FROM dtrxs WHERE trx_id LIKE ? ORDER BY trx_id, block_id, op`
+	default:
+		panic("invalid trx event")
+	}
+
+	// The prefix is validated as hexadecimal, it cannot contain `LIKE` wildcards
+	rows, err := db.query(ctx, query, idPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var trxID, blockID string
+		var value []byte
+		if err := rows.Scan(&trxID, &blockID, &value); err != nil {
+			return nil, fmt.Errorf("scan transaction row: %w", err)
+		}
+
+		ev := &pbcodec.TransactionEvent{
+			Id:       trxID,
+			BlockId:  blockID,
+			BlockNum: eos.BlockNum(blockID),
+		}
+
+		switch eventType {
+		// Implicit Transaction Addition
+		case implicitTrxEvent:
+			row := &pbtrxdb.ImplicitTrxRow{}
+			db.dec.MustInto(value, row)
+
+			ev.Event = &pbcodec.TransactionEvent_InternalAddition{
+				InternalAddition: &pbcodec.TransactionEvent_AddedInternally{
+					Transaction: row.SignedTrx,
+				},
+			}
+
+		// Transaction Addition
+		case trxAdditionEvent:
+			row := &pbtrxdb.TrxRow{}
+			db.dec.MustInto(value, row)
+
+			if row.Receipt != nil {
+				ev.Event = &pbcodec.TransactionEvent_Addition{
+					Addition: &pbcodec.TransactionEvent_Added{
+						Receipt:     row.Receipt,
+						Transaction: row.SignedTrx,
+						PublicKeys:  row.PublicKeys,
+					},
+				}
+			} else {
+				ev.Event = &pbcodec.TransactionEvent_InternalAddition{
+					InternalAddition: &pbcodec.TransactionEvent_AddedInternally{
+						Transaction: row.SignedTrx,
+					},
+				}
+			}
+
+		// Transaction Execution
+		case trxExecutionEvent:
+			row := &pbtrxdb.TrxTraceRow{}
+			db.dec.MustInto(value, row)
+
+			codec.ReduplicateTransactionTrace(row.TrxTrace)
+			ev.Event = &pbcodec.TransactionEvent_Execution{
+				Execution: &pbcodec.TransactionEvent_Executed{
+					Trace:       row.TrxTrace,
+					BlockHeader: row.BlockHeader,
+				},
+			}
+
+		// Deferred Trx
+		case dtrxEvent:
+			row := &pbtrxdb.DtrxRow{}
+			db.dec.MustInto(value, row)
+
+			if row.CreatedBy != nil {
+				ev.Event = &pbcodec.TransactionEvent_DtrxScheduling{
+					DtrxScheduling: &pbcodec.TransactionEvent_DtrxScheduled{
+						CreatedBy:   row.CreatedBy,
+						Transaction: row.SignedTrx,
+					},
+				}
+			} else {
+				ev.Event = &pbcodec.TransactionEvent_DtrxCancellation{
+					DtrxCancellation: &pbcodec.TransactionEvent_DtrxCanceled{
+						CanceledBy: row.CanceledBy,
+					},
+				}
+			}
+		}
+
+		out = append(out, ev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func isHex(in string) bool {
+	for _, char := range in {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", char) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,30 @@
+//go:build sqlite3
+// +build sqlite3
+
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// The SQLite driver links against the C library through cgo, so it is left out of the
+// default (static) builds, use `go build -tags sqlite3` to enable `sqlite3://` dsns.
+func init() {
+	sqliteEnabled = true
+	trxdb.Register("sqlite3", factory)
+}
@@ -0,0 +1,39 @@
+//go:build sqlite3
+// +build sqlite3
+
+package sql
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/dfuse-io/dfuse-eosio/trxdb/trxdbtest"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with `go test -tags sqlite3`, SQLite support requiring cgo
+func TestAll(t *testing.T) {
+	factory := newTestDBFactory(t)
+	trxdbtest.TestAll(t, "sql", factory)
+}
+
+func newTestDBFactory(t *testing.T) trxdbtest.DriverFactory {
+	return func() (trxdb.DB, trxdbtest.DriverCleanupFunc) {
+		dir, err := ioutil.TempDir("", "dfuse-trxdb-sql")
+		require.NoError(t, err)
+
+		db, err := New([]string{fmt.Sprintf("sqlite3://%s", filepath.Join(dir, "trxdb.db"))})
+		require.NoError(t, err)
+
+		db.logger = zlog
+
+		return db, func() {
+			db.Close()
+			require.NoError(t, os.RemoveAll(dir))
+		}
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dfuse-io/dfuse-eosio/codec"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	pbtrxdb "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/trxdb/v1"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/golang/protobuf/ptypes"
+	"go.uber.org/zap"
+)
+
+const (
+	dtrxOpCreated   = 1
+	dtrxOpCancelled = 2
+	dtrxOpFailed    = 3
+)
+
+func (db *DB) PutBlock(ctx context.Context, blk *pbcodec.Block) error {
+	if traceEnabled {
+		db.logger.Debug("put transactions (trx, trace, dtrx)")
+	}
+
+	if err := db.putTransactions(ctx, blk); err != nil {
+		return fmt.Errorf("put block: unable to putTransactions: %w", err)
+	}
+
+	if err := db.putTransactionTraces(ctx, blk); err != nil {
+		return fmt.Errorf("put block: unable to putTransactionTraces: %w", err)
+	}
+
+	if err := db.putImplicitTransactions(ctx, blk); err != nil {
+		return fmt.Errorf("put block: unable to putImplicitTransactions: %w", err)
+	}
+
+	return db.putBlock(ctx, blk)
+}
+
+func (db *DB) putTransactions(ctx context.Context, blk *pbcodec.Block) error {
+	for _, trxReceipt := range blk.Transactions() {
+		if trxReceipt.PackedTransaction == nil {
+			// This means we deal with a deferred transaction receipt, and that it
+			// has been handled through DtrxOps already
+			continue
+		}
+
+		signedTransaction, err := codec.ExtractEOSSignedTransactionFromReceipt(trxReceipt)
+		if err != nil {
+			return fmt.Errorf("unable to extract EOS signed transaction from transaction receipt: %s", err)
+		}
+
+		trxRow := &pbtrxdb.TrxRow{
+			Receipt:   trxReceipt,
+			SignedTrx: codec.SignedTransactionToDEOS(signedTransaction),
+			PublicKeys: &pbcodec.PublicKeys{
+				PublicKeys: codec.GetPublicKeysFromSignedTransaction(db.writerChainID, signedTransaction),
+			},
+		}
+
+		if err := db.putTrxTableRow(ctx, "trxs", trxReceipt.Id, blk.Id, db.enc.MustProto(trxRow)); err != nil {
+			return fmt.Errorf("put trx: write to db: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) putTransactionTraces(ctx context.Context, blk *pbcodec.Block) error {
+	for _, trxTrace := range blk.TransactionTraces() {
+		for _, dtrxOp := range trxTrace.DtrxOps {
+			extDtrxOp := dtrxOp.ToExtDTrxOp(blk, trxTrace)
+
+			dtrxRow := &pbtrxdb.DtrxRow{}
+
+			var op int
+			if dtrxOp.IsCreateOperation() {
+				dtrxRow.SignedTrx = dtrxOp.Transaction
+				dtrxRow.CreatedBy = extDtrxOp
+				op = dtrxOpCreated
+			} else if dtrxOp.IsCancelOperation() {
+				dtrxRow.CanceledBy = extDtrxOp
+				op = dtrxOpCancelled
+			} else if dtrxOp.IsFailedOperation() {
+				op = dtrxOpFailed
+			} else {
+				return fmt.Errorf("put dtrxRow: handle dtrxOp Operation: unknown dtrxOp operation for trx id %s at action %d", trxTrace.Id, dtrxOp.ActionIndex)
+			}
+
+			err := db.write(ctx,
+				`INSERT INTO dtrxs (trx_id, block_id, op, value) VALUES (?, ?, ?, ?) ON CONFLICT (trx_id, block_id, op) DO UPDATE SET value = excluded.value`,
+				dtrxOp.TransactionId, blk.Id, op, db.enc.MustProto(dtrxRow),
+			)
+			if err != nil {
+				return fmt.Errorf("put dtrxRow: write to db: %w", err)
+			}
+		}
+
+		codec.DeduplicateTransactionTrace(trxTrace)
+
+		trxTraceRow := &pbtrxdb.TrxTraceRow{
+			BlockHeader: blk.Header,
+			TrxTrace:    trxTrace,
+		}
+
+		if traceEnabled {
+			db.logger.Debug("put transaction trace row", zap.String("trx_id", trxTrace.Id), zap.String("block_id", blk.Id))
+		}
+
+		value := db.enc.MustProto(trxTraceRow)
+		codec.ReduplicateTransactionTrace(trxTrace)
+
+		if err := db.putTrxTableRow(ctx, "trx_traces", trxTrace.Id, blk.Id, value); err != nil {
+			return fmt.Errorf("put trxTraceRow: write to db: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) putImplicitTransactions(ctx context.Context, blk *pbcodec.Block) error {
+	for _, trxOp := range blk.ImplicitTransactionOps() {
+		implTrxRow := &pbtrxdb.ImplicitTrxRow{
+			Name:      trxOp.Name,
+			SignedTrx: trxOp.Transaction,
+		}
+
+		if err := db.putTrxTableRow(ctx, "implicit_trxs", trxOp.TransactionId, blk.Id, db.enc.MustProto(implTrxRow)); err != nil {
+			return fmt.Errorf("put implTrx: write to db: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// putTrxTableRow writes a row of one of the tables keyed by transaction id and block id,
+// `table` being a constant of this package.
+func (db *DB) putTrxTableRow(ctx context.Context, table string, trxID, blockID string, value []byte) error {
+	return db.write(ctx,
+		`INSERT INTO `+table+` (trx_id, block_id, value) VALUES (?, ?, ?) ON CONFLICT (trx_id, block_id) DO UPDATE SET value = excluded.value`,
+		trxID, blockID, value,
+	)
+}
+
+func (db *DB) getRefs(blk *pbcodec.Block) (implicitTrxRefs, trxRefs, tracesRefs *pbcodec.TransactionRefs) {
+	implicitTrxRefs = &pbcodec.TransactionRefs{}
+	for _, trxOp := range blk.ImplicitTransactionOps() {
+		implicitTrxRefs.Hashes = append(implicitTrxRefs.Hashes, trxdb.MustHexDecode(trxOp.TransactionId))
+	}
+
+	trxRefs = &pbcodec.TransactionRefs{}
+	for _, trx := range blk.Transactions() {
+		trxRefs.Hashes = append(trxRefs.Hashes, trxdb.MustHexDecode(trx.Id))
+	}
+
+	tracesRefs = &pbcodec.TransactionRefs{}
+	for _, trx := range blk.TransactionTraces() {
+		tracesRefs.Hashes = append(tracesRefs.Hashes, trxdb.MustHexDecode(trx.Id))
+	}
+
+	return
+}
+
+func (db *DB) putBlock(ctx context.Context, blk *pbcodec.Block) error {
+	implicitTrxRefs, trxRefs, tracesRefs := db.getRefs(blk)
+
+	// The block row only references the transactions, which are stored in their own tables
+	strippedBlk := *blk
+	strippedBlk.UnfilteredTransactions = nil
+	strippedBlk.UnfilteredTransactionTraces = nil
+	strippedBlk.UnfilteredImplicitTransactionOps = nil
+	strippedBlk.FilteredTransactions = nil
+	strippedBlk.FilteredTransactionTraces = nil
+	strippedBlk.FilteredImplicitTransactionOps = nil
+
+	blockRow := &pbtrxdb.BlockRow{
+		Block:           &strippedBlk,
+		ImplicitTrxRefs: implicitTrxRefs,
+		TrxRefs:         trxRefs,
+		TraceRefs:       tracesRefs,
+	}
+
+	db.logger.Debug("put block", zap.Stringer("block", blk.AsRef()))
+	err := db.write(ctx,
+		`INSERT INTO blocks (id, num, value) VALUES (?, ?, ?) ON CONFLICT (id) DO UPDATE SET num = excluded.num, value = excluded.value`,
+		blk.Id, int64(blk.Number), db.enc.MustProto(blockRow),
+	)
+	if err != nil {
+		return fmt.Errorf("put block: write to db: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) UpdateNowIrreversibleBlock(ctx context.Context, blk *pbcodec.Block) error {
+	err := db.write(ctx,
+		`INSERT INTO timeline (block_time, block_id) VALUES (?, ?) ON CONFLICT (block_time, block_id) DO NOTHING`,
+		timeToDeciSeconds(blk.MustTime()), blk.Id,
+	)
+	if err != nil {
+		return fmt.Errorf("put timeline: write to db: %w", err)
+	}
+
+	// Specialized indexing for `newaccount` on the chain, this might loop on filtered transaction traces, so
+	// the filtering rules might exclude the `newaccount`.
+	for _, trxTrace := range blk.TransactionTraces() {
+		for _, act := range trxTrace.ActionTraces {
+			if act.FullName() == "eosio:eosio:newaccount" {
+				if err := db.putNewAccount(ctx, blk, trxTrace, act); err != nil {
+					return fmt.Errorf("failed to put new account: %w", err)
+				}
+			}
+		}
+	}
+
+	db.logger.Debug("adding irreversible block", zap.Stringer("block", blk.AsRef()))
+	err = db.write(ctx,
+		`INSERT INTO irr_blocks (id, num) VALUES (?, ?) ON CONFLICT (id) DO NOTHING`,
+		blk.Id, int64(blk.Number),
+	)
+	if err != nil {
+		return fmt.Errorf("put irr block: write to db: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) putNewAccount(ctx context.Context, blk *pbcodec.Block, trace *pbcodec.TransactionTrace, act *pbcodec.ActionTrace) error {
+	t, err := ptypes.TimestampProto(blk.MustTime())
+	if err != nil {
+		return fmt.Errorf("block time to proto: %w", err)
+	}
+
+	acctRow := &pbtrxdb.AccountRow{
+		Name:      act.GetData("name").String(),
+		Creator:   act.GetData("creator").String(),
+		BlockTime: t,
+		BlockId:   blk.Id,
+		TrxId:     trace.Id,
+	}
+
+	if traceEnabled {
+		db.logger.Debug("put account row", zap.String("name", acctRow.Name))
+	}
+
+	err = db.write(ctx,
+		`INSERT INTO accounts (name, value) VALUES (?, ?) ON CONFLICT (name) DO UPDATE SET value = excluded.value`,
+		acctRow.Name, db.enc.MustProto(acctRow),
+	)
+	if err != nil {
+		return fmt.Errorf("put acctRow: write to db: %w", err)
+	}
+
+	return nil
+}
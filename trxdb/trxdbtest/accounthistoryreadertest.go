@@ -0,0 +1,169 @@
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trxdbtest
+
+import (
+	"context"
+	"testing"
+
+	ct "github.com/dfuse-io/dfuse-eosio/codec/testing"
+	pbcodec "github.com/dfuse-io/dfuse-eosio/pb/dfuse/eosio/codec/v1"
+	"github.com/dfuse-io/dfuse-eosio/trxdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var accountHistoryReaderTests = []DriverTestFunc{
+	TestListAccountHistory,
+	TestListAccountHistory_Filtered,
+}
+
+func TestListAccountHistory(t *testing.T, driverFactory DriverFactory) {
+	db, clean := driverFactory()
+	defer clean()
+
+	historyReader := mustAccountHistoryReader(t, db)
+	putHistoryBlocks(t, db, false)
+
+	type page struct {
+		cursor       string
+		limit        int
+		expectSeqs   []uint64
+		expectCursor string
+	}
+
+	tests := []struct {
+		name    string
+		account string
+		pages   []page
+	}{
+		{
+			name:    "receiver and authorizer, single page",
+			account: "alice",
+			pages: []page{
+				{limit: 10, expectSeqs: []uint64{11001, 11000, 10001, 10000}},
+			},
+		},
+		{
+			name:    "receiver and authorizer, multiple pages",
+			account: "alice",
+			pages: []page{
+				{limit: 3, expectSeqs: []uint64{11001, 11000, 10001}, expectCursor: "10001"},
+				{cursor: "10001", limit: 3, expectSeqs: []uint64{10000}},
+			},
+		},
+		{
+			name:    "exact page size",
+			account: "bob",
+			pages: []page{
+				{limit: 2, expectSeqs: []uint64{10003, 10002}},
+			},
+		},
+		{
+			name:    "unknown account",
+			account: "charlie",
+			pages: []page{
+				{limit: 10},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, p := range test.pages {
+				entries, cursor, err := historyReader.ListAccountHistory(context.Background(), test.account, p.cursor, p.limit)
+				require.NoError(t, err)
+
+				var seqs []uint64
+				for _, entry := range entries {
+					assert.Equal(t, test.account, entry.AccountName)
+					seqs = append(seqs, entry.GlobalSequence)
+				}
+
+				assert.Equal(t, p.expectSeqs, seqs)
+				assert.Equal(t, p.expectCursor, cursor)
+			}
+		})
+	}
+
+	entries, _, err := historyReader.ListAccountHistory(context.Background(), "bob", "", 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, ct.BlockID(10), entries[0].BlockID)
+	assert.Equal(t, uint32(0), entries[0].ActionIndex)
+}
+
+func TestListAccountHistory_Filtered(t *testing.T, driverFactory DriverFactory) {
+	db, clean := driverFactory()
+	defer clean()
+
+	historyReader := mustAccountHistoryReader(t, db)
+	putHistoryBlocks(t, db, true)
+
+	entries, _, err := historyReader.ListAccountHistory(context.Background(), "alice", "", 10)
+	require.NoError(t, err)
+
+	var seqs []uint64
+	for _, entry := range entries {
+		seqs = append(seqs, entry.GlobalSequence)
+	}
+
+	// Only the `eosio.token:transfer` actions received by `eosio.token` matched the filter
+	assert.Equal(t, []uint64{11000, 10000}, seqs)
+}
+
+func mustAccountHistoryReader(t *testing.T, db trxdb.DB) trxdb.AccountHistoryReader {
+	historyReader, ok := db.(trxdb.AccountHistoryReader)
+	if !ok {
+		t.Skip("driver does not implement trxdb.AccountHistoryReader")
+	}
+
+	require.NoError(t, trxdb.WithAccountHistoryOption()(db))
+	return historyReader
+}
+
+// putHistoryBlocks writes two irreversible blocks, when `filtered` is true, only the actions
+// received by `eosio.token` are marked as matching the filter.
+func putHistoryBlocks(t *testing.T, db trxdb.DB, filtered bool) {
+	blocks := []*pbcodec.Block{
+		ct.NewBlock(10).
+			Trx("").Action("eosio.token", "transfer", nil).Auth("alice@active").Notify("alice", "bob").
+			Trx("").Action("eosio", "buyrambytes", nil).Auth("bob@active").
+			Build(t),
+		ct.NewBlock(11).
+			Trx("").Action("eosio.token", "transfer", nil).Auth("alice@active").Notify("alice").
+			Build(t),
+	}
+
+	ctx := context.Background()
+	for _, blk := range blocks {
+		if filtered {
+			blk.FilteringApplied = true
+			blk.FilteredTransactionTraces = blk.UnfilteredTransactionTraces
+			blk.UnfilteredTransactionTraces = nil
+
+			for _, trxTrace := range blk.FilteredTransactionTraces {
+				for _, actTrace := range trxTrace.ActionTraces {
+					actTrace.FilteringMatched = actTrace.Receiver == "eosio.token"
+				}
+			}
+		}
+
+		require.NoError(t, db.PutBlock(ctx, blk))
+		require.NoError(t, db.UpdateNowIrreversibleBlock(ctx, blk))
+	}
+
+	require.NoError(t, db.Flush(ctx))
+}
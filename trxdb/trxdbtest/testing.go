@@ -33,11 +33,12 @@ type DriverTestFunc func(t *testing.T, driverFactory DriverFactory)
 
 func TestAll(t *testing.T, driverName string, driverFactory DriverFactory) {
 	all := map[string][]DriverTestFunc{
-		"accounts_reader":    accountsReaderTest,
-		"db_reader":          dbReaderTests,
-		"db_writer":          dbWritterTests,
-		"timeline_exporter":  timelineExplorerTests,
-		"transaction_reader": transactionReaderTests,
+		"accounts_reader":        accountsReaderTest,
+		"account_history_reader": accountHistoryReaderTests,
+		"db_reader":              dbReaderTests,
+		"db_writer":              dbWritterTests,
+		"timeline_exporter":      timelineExplorerTests,
+		"transaction_reader":     transactionReaderTests,
 	}
 
 	for driverName, testFuncs := range all {
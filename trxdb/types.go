@@ -88,3 +88,15 @@ func toIndexableCategory(in string) (pbtrxdb.IndexableCategory, error) {
 
 	return pbtrxdb.IndexableCategory(value), nil
 }
+
+// AccountHistoryEntry is an irreversible action touching an account, the account being either
+// the receiver of the action or one of its authorizers.
+type AccountHistoryEntry struct {
+	AccountName    string
+	GlobalSequence uint64
+
+	TrxID   string
+	BlockID string
+	// ActionIndex is the execution index of the action trace in the transaction trace
+	ActionIndex uint32
+}